@@ -29,11 +29,14 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/prometheus/client_golang/prometheus"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlbuilder "sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
@@ -43,6 +46,7 @@ import (
 	"github.com/kube-logging/logging-operator/pkg/resources/loggingdataprovider"
 	"github.com/kube-logging/logging-operator/pkg/resources/model"
 	"github.com/kube-logging/logging-operator/pkg/resources/nodeagent"
+	"github.com/kube-logging/logging-operator/pkg/resources/pki"
 	"github.com/kube-logging/logging-operator/pkg/resources/syslogng"
 	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/render"
 	syslogngconfig "github.com/kube-logging/logging-operator/pkg/sdk/logging/model/syslogng/config"
@@ -64,7 +68,7 @@ type LoggingReconciler struct {
 	Log logr.Logger
 }
 
-// +kubebuilder:rbac:groups=logging.banzaicloud.io,resources=loggings;fluentbitagents;flows;clusterflows;outputs;clusteroutputs;nodeagents,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=logging.banzaicloud.io,resources=loggings;fluentbitagents;flows;clusterflows;outputs;clusteroutputs;nodeagents;nodeagentprofiles,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=logging.banzaicloud.io,resources=loggings/status;fluentbitagents/status;flows/status;clusterflows/status;outputs/status;clusteroutputs/status;nodeagents/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=logging.banzaicloud.io,resources=syslogngflows;syslogngclusterflows;syslogngoutputs;syslogngclusteroutputs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=logging.banzaicloud.io,resources=syslogngflows/status;syslogngclusterflows/status;syslogngoutputs/status;syslogngclusteroutputs/status,verbs=get;update;patch
@@ -80,6 +84,8 @@ type LoggingReconciler struct {
 // +kubebuilder:rbac:groups=monitoring.coreos.com,resources=prometheusrules;servicemonitors,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=*
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=discovery.k8s.io,resources=endpointslices,verbs=get;list;watch
+// +kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile logging resources
 func (r *LoggingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -145,8 +151,10 @@ func (r *LoggingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		}
 	}()
 
+	pkiReconciler := pki.New(r.Client, r.Log, &logging)
 	reconcilers := []resources.ComponentReconciler{
 		model.NewValidationReconciler(ctx, r.Client, loggingResources, &secretLoaderFactory{Client: r.Client, Path: fluentd.OutputSecretPath}),
+		pkiReconciler.Reconcile,
 	}
 
 	if logging.Spec.FluentdSpec != nil && logging.Spec.SyslogNGSpec != nil {
@@ -199,6 +207,8 @@ func (r *LoggingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 				logging.Spec.FluentbitSpec,
 				loggingDataProvider,
 				nameProvider,
+				loggingResources,
+				nil,
 			).Reconcile)
 		}
 	default:
@@ -216,6 +226,8 @@ func (r *LoggingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 				&f.Spec,
 				loggingDataProvider,
 				loggingv1beta1.NewStandaloneFluentbitNameProvider(&f),
+				loggingResources,
+				&f,
 			).Reconcile)
 		}
 	}
@@ -235,7 +247,11 @@ func (r *LoggingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 				log.Error(errors.New("nodeagent definition conflict"), problem)
 			}
 		}
-		reconcilers = append(reconcilers, nodeagent.New(r.Client, r.Log, &logging, agents, reconcilerOpts, fluentd.NewDataProvider(r.Client, &logging)).Reconcile)
+		profiles := make(map[string]loggingv1beta1.NodeAgentProfileSpec)
+		for _, p := range loggingResources.NodeAgentProfiles {
+			profiles[p.Name] = p.Spec
+		}
+		reconcilers = append(reconcilers, nodeagent.New(r.Client, r.Log, &logging, agents, profiles, reconcilerOpts, fluentd.NewDataProvider(r.Client, &logging)).Reconcile)
 	}
 
 	for _, rec := range reconcilers {
@@ -248,6 +264,10 @@ func (r *LoggingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 			return *result, err
 		}
 	}
+	// wake up in time to renew the certificates issued by the operator
+	if requeueAfter := pkiReconciler.RequeueAfter(); requeueAfter > 0 {
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
 	return ctrl.Result{}, nil
 }
 
@@ -396,8 +416,24 @@ func SetupLoggingWithManager(mgr ctrl.Manager, logger logr.Logger) *ctrl.Builder
 			return reconcileRequestsForLoggingRef(loggingList.Items, o.Spec.LoggingRef)
 		case *loggingv1beta1.NodeAgent:
 			return reconcileRequestsForLoggingRef(loggingList.Items, o.Spec.LoggingRef)
+		case *loggingv1beta1.NodeAgentProfile:
+			// profiles are shared by the node agents of all the logging resources
+			var requestList []reconcile.Request
+			for _, l := range loggingList.Items {
+				requestList = append(requestList, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: l.Namespace, Name: l.Name}})
+			}
+			return requestList
 		case *loggingv1beta1.FluentbitAgent:
 			return reconcileRequestsForLoggingRef(loggingList.Items, o.Spec.LoggingRef)
+		case *discoveryv1.EndpointSlice:
+			// the upstream config of fluent-bit follows the endpoints of the headless service of fluentd
+			var requestList []reconcile.Request
+			for _, l := range loggingList.Items {
+				if l.Spec.FluentdSpec != nil && o.Namespace == l.Spec.ControlNamespace && o.Labels[discoveryv1.LabelServiceName] == l.QualifiedName(fluentd.ServiceName+"-headless") {
+					requestList = append(requestList, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: l.Namespace, Name: l.Name}})
+				}
+			}
+			return requestList
 		case *corev1.Secret:
 			r := regexp.MustCompile(`^logging\.banzaicloud\.io/(.*)`)
 			var requestList []reconcile.Request
@@ -435,7 +471,11 @@ func SetupLoggingWithManager(mgr ctrl.Manager, logger logr.Logger) *ctrl.Builder
 		builder.Watches(&source.Kind{Type: &loggingv1beta1.NodeAgent{}}, requestMapper)
 	}
 
-	builder.Watches(&source.Kind{Type: &loggingv1beta1.FluentbitAgent{}}, requestMapper)
+	// the status updates of the agents must not trigger a reconcile, otherwise the status patches would loop
+	builder.Watches(&source.Kind{Type: &loggingv1beta1.FluentbitAgent{}}, requestMapper, ctrlbuilder.WithPredicates(predicate.GenerationChangedPredicate{}))
+	builder.Watches(&source.Kind{Type: &loggingv1beta1.NodeAgentProfile{}}, requestMapper)
+	builder.Watches(&source.Kind{Type: &discoveryv1.EndpointSlice{}}, requestMapper)
+	builder.Watches(fluentbit.NewUpstreamDNSSource(mgr.GetClient(), logger.WithName("upstream-dns")), &handler.EnqueueRequestForObject{})
 
 	fluentd.RegisterWatches(builder)
 	fluentbit.RegisterWatches(builder)
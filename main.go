@@ -28,11 +28,13 @@ import (
 	"github.com/spf13/cast"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	"k8s.io/client-go/rest"
@@ -234,7 +236,17 @@ func detectContainerRuntime(ctx context.Context, c client.Reader) error {
 }
 
 func setupCustomCache(mgrOptions *ctrl.Options, namespace string, loggingRef string) (*ctrl.Options, error) {
+	endpointSliceSelector, err := endpointSliceSelector(loggingRef)
+	if err != nil {
+		return nil, err
+	}
+
 	if namespace == "" && loggingRef == "" {
+		mgrOptions.NewCache = cache.BuilderWithOptions(cache.Options{SelectorsByObject: cache.SelectorsByObject{
+			&discoveryv1.EndpointSlice{}: {
+				Label: endpointSliceSelector,
+			},
+		}})
 		return mgrOptions, nil
 	}
 
@@ -268,9 +280,27 @@ func setupCustomCache(mgrOptions *ctrl.Options, namespace string, loggingRef str
 			Field: namespaceSelector,
 			Label: labelSelector,
 		},
+		&discoveryv1.EndpointSlice{}: {
+			Field: namespaceSelector,
+			Label: endpointSliceSelector,
+		},
 	}
 
 	mgrOptions.NewCache = cache.BuilderWithOptions(cache.Options{SelectorsByObject: selectorsByObject})
 
 	return mgrOptions, nil
 }
+
+// endpointSliceSelector selects the EndpointSlices of the headless services of the aggregators, only these are watched
+// for the upstream discovery of fluent-bit. The EndpointSlices inherit the labels of their service.
+func endpointSliceSelector(loggingRef string) (labels.Selector, error) {
+	set := labels.Set{"app.kubernetes.io/name": "fluentd"}
+	if loggingRef != "" {
+		set["app.kubernetes.io/managed-by"] = loggingRef
+	}
+	serviceName, err := labels.NewRequirement(discoveryv1.LabelServiceName, selection.Exists, nil)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to create the EndpointSlice selector")
+	}
+	return labels.SelectorFromSet(set).Add(*serviceName), nil
+}
@@ -55,6 +55,15 @@ func NewConfigCheckCleaner(c client.Client, component string) *ConfigCheckCleane
 	}
 }
 
+// NewConfigCheckCleanerWithLabels creates a cleaner for configcheck resources selected by the given labels,
+// for components that have multiple instances in the same namespace
+func NewConfigCheckCleanerWithLabels(c client.Client, labels client.MatchingLabels) *ConfigCheckCleaner {
+	return &ConfigCheckCleaner{
+		client: c,
+		labels: labels,
+	}
+}
+
 // SecretCleanup cleans up configcheck secrets that have the logging.banzaicloud.io/config-hash label, but
 // doesn't match the current config hash
 func (c *ConfigCheckCleaner) SecretCleanup(ctx context.Context, hash string) (multierr error) {
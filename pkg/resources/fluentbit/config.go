@@ -20,6 +20,7 @@ const CustomParsersConfigName = "custom-parsers.conf"
 const StockConfigPath = "/fluent-bit/etc"
 const StockBinPath = "/fluent-bit/bin/fluent-bit"
 const OperatorConfigPath = "/fluent-bit/etc-operator"
+const LuaScriptsPath = "/fluent-bit/scripts"
 
 var fluentBitConfigTemplate = `
 [SERVICE]
@@ -37,6 +38,12 @@ var fluentBitConfigTemplate = `
     HTTP_Listen  0.0.0.0
     HTTP_Port    {{ .Monitor.Port }}
     {{- end }}
+    {{- if .StorageMetrics }}
+    storage.metrics On
+    {{- end }}
+    {{- if .HotReload }}
+    Hot_Reload   On
+    {{- end }}
     {{- range $key, $value := .BufferStorage }}
     {{- if $value }}
     {{ $key }}  {{$value}}
@@ -59,6 +66,21 @@ var fluentBitConfigTemplate = `
     multiline.parser {{- range $i, $v := .Input.MultilineParser }}{{ if $i }},{{ end}} {{ $v }}{{ end }}
     {{- end }}
 
+{{- range $input := .AdditionalInputs }}
+
+[INPUT]
+    Name         {{ $input.Name }}
+    Tag          {{ $input.Tag }}
+    {{- range $key, $value := $input.Values }}
+    {{- if $value }}
+    {{ $key }}  {{$value}}
+    {{- end }}
+    {{- end }}
+    {{- range $filter := $input.SystemdFilters }}
+    Systemd_Filter  {{ $filter }}
+    {{- end }}
+{{- end }}
+
 {{- if not .DisableKubernetesFilter }}
 [FILTER]
     Name        kubernetes
@@ -69,6 +91,37 @@ var fluentBitConfigTemplate = `
     {{- end }}
 {{- end}}
 
+{{- with .NamespaceFilter }}
+
+[FILTER]
+    Name        grep
+    Match       {{ .Match }}
+    Regex       $kubernetes['namespace_name'] {{ .Regex }}
+{{- end}}
+
+{{- with .DirectOutputRouting }}
+
+[FILTER]
+    Name         lua
+    Match        {{ .Match }}
+    call         direct_output
+    code         {{ .Code }}
+
+[FILTER]
+    Name         rewrite_tag
+    Match        {{ .Match }}
+    Rule         {{ .Rule }}
+    Emitter_Name direct-outputs
+    {{- with .EmitterStorageType }}
+    Emitter_Storage.type {{ . }}
+    {{- end }}
+
+[FILTER]
+    Name         modify
+    Match        direct.*
+    Remove       {{ .Key }}
+{{- end}}
+
 {{- if .AwsFilter }}
 [FILTER]
     Name        aws
@@ -95,10 +148,43 @@ var fluentBitConfigTemplate = `
     {{- end }}
 {{- end}}
 
+{{- range $filter := .Filters }}
+
+[FILTER]
+    Name         {{ $filter.Name }}
+    {{- range $key, $value := $filter.Values }}
+    {{- if $value }}
+    {{ $key }}  {{$value}}
+    {{- end }}
+    {{- end }}
+    {{- range $param := $filter.Params }}
+    {{ $param.Key }}  {{ $param.Value }}
+    {{- end }}
+{{- end}}
+
+{{- range $output := .DirectOutputs }}
+
+[OUTPUT]
+    Name         {{ $output.Name }}
+    Match        {{ $output.Match }}
+    {{- range $key, $value := $output.Values }}
+    {{- if $value }}
+    {{ $key }}  {{$value}}
+    {{- end }}
+    {{- end }}
+    {{- range $param := $output.Params }}
+    {{ $param.Key }}  {{ $param.Value }}
+    {{- end }}
+{{- end}}
+
 {{- with .FluentForwardOutput }}
 [OUTPUT]
     Name          forward
+    {{- if $.DirectOutputRouting }}
+    Match_Regex   ^(?!direct\.).*
+    {{- else }}
     Match         *
+    {{- end }}
     {{- if .Upstream.Enabled }}
     Upstream      upstream.conf
     {{- else }}
@@ -107,7 +193,10 @@ var fluentBitConfigTemplate = `
     {{- end }}
     {{ if .TLS.Enabled }}
     tls           On
-    tls.verify    Off
+    tls.verify    {{ if .TLS.Verify }}On{{ else }}Off{{ end }}
+    {{- with .TLS.VHost }}
+    tls.vhost     {{ . }}
+    {{- end }}
     tls.ca_file   /fluent-bit/tls/ca.crt
     tls.crt_file  /fluent-bit/tls/tls.crt
     tls.key_file  /fluent-bit/tls/tls.key
@@ -156,7 +245,11 @@ var fluentBitConfigTemplate = `
 {{- with .SyslogNGOutput }}
 [OUTPUT]
     Name tcp
+    {{- if $.DirectOutputRouting }}
+    Match_Regex ^(?!direct\.).*
+    {{- else }}
     Match *
+    {{- end }}
     Host {{ .Host }}
     Port {{ .Port }}
     Format json_lines
@@ -169,6 +262,16 @@ var fluentBitConfigTemplate = `
     {{- with .Workers }}
     Workers {{ . }}
     {{- end }}
+    {{- if .TLS.Enabled }}
+    tls On
+    tls.verify {{ if .TLS.Verify }}On{{ else }}Off{{ end }}
+    {{- with .TLS.VHost }}
+    tls.vhost {{ . }}
+    {{- end }}
+    tls.ca_file /fluent-bit/tls/ca.crt
+    tls.crt_file /fluent-bit/tls/tls.crt
+    tls.key_file /fluent-bit/tls/tls.key
+    {{- end }}
     {{- if .Network.ConnectTimeoutSet }}
     net.connect_timeout {{.Network.ConnectTimeout}}
     {{- end }}
@@ -0,0 +1,332 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentbit
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/cisco-open/operator-tools/pkg/merge"
+	util "github.com/cisco-open/operator-tools/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/kube-logging/logging-operator/pkg/resources/configcheck"
+)
+
+const (
+	componentConfigCheck = "fluentbit-configcheck"
+	configCheckVolume    = "config"
+)
+
+type ConfigCheckResult struct {
+	Valid   bool
+	Ready   bool
+	Message string
+}
+
+// checkConfig validates the generated or the custom config with a dry-run pod and blocks the rollout of an invalid config.
+// The results are recorded in the status of the FluentbitAgent, the legacy fluentbit spec relies on the
+// existing configcheck pod of the current hash instead.
+func (r *Reconciler) checkConfig(ctx context.Context) (*reconcile.Result, error) {
+	if r.fluentbitSpec.ConfigCheckDisabled {
+		return nil, nil
+	}
+
+	configs, err := r.configCheckData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := r.configHash(configs)
+	if err != nil {
+		return nil, err
+	}
+
+	if valid, ok := r.configCheckResults()[hash]; ok {
+		// Fail when the current config is invalid
+		if !valid {
+			return nil, errors.Errorf("current config is invalid")
+		}
+		return r.configCheckCleanup(ctx, hash, valid)
+	}
+
+	result, err := r.configCheck(ctx, hash, configs)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to validate config")
+	}
+	if !result.Ready {
+		if result.Message != "" {
+			r.logger.Info(result.Message)
+		} else {
+			r.logger.Info("still waiting for the configcheck result...")
+		}
+		return &reconcile.Result{RequeueAfter: time.Minute}, nil
+	}
+
+	if r.fluentbitAgent != nil {
+		patchBase := client.MergeFrom(r.fluentbitAgent.DeepCopy())
+		if r.fluentbitAgent.Status.ConfigCheckResults == nil {
+			r.fluentbitAgent.Status.ConfigCheckResults = make(map[string]bool)
+		}
+		r.fluentbitAgent.Status.ConfigCheckResults[hash] = result.Valid
+		if err := r.resourceReconciler.Client.Status().Patch(ctx, r.fluentbitAgent, patchBase); err != nil {
+			return nil, errors.WrapWithDetails(err, "failed to patch status", "fluentbitagent", r.fluentbitAgent.Name)
+		}
+		// explicitly ask for a requeue to short circuit the controller loop after the status update
+		return &reconcile.Result{Requeue: true}, nil
+	}
+
+	if !result.Valid {
+		return nil, errors.Errorf("current config is invalid")
+	}
+	return r.configCheckCleanup(ctx, hash, result.Valid)
+}
+
+func (r *Reconciler) configCheckResults() map[string]bool {
+	if r.fluentbitAgent != nil {
+		return r.fluentbitAgent.Status.ConfigCheckResults
+	}
+	return nil
+}
+
+// configCheckCleanup removes the configcheck resources and results of the previous configs
+func (r *Reconciler) configCheckCleanup(ctx context.Context, hash string, result bool) (*reconcile.Result, error) {
+	cleaner := configcheck.NewConfigCheckCleanerWithLabels(r.resourceReconciler.Client, r.configCheckSelectorLabels())
+
+	var cleanupErrs error
+	cleanupErrs = errors.Append(cleanupErrs, cleaner.SecretCleanup(ctx, hash))
+	cleanupErrs = errors.Append(cleanupErrs, cleaner.PodCleanup(ctx, hash))
+	if cleanupErrs != nil {
+		// Errors with the cleanup should not block the reconciliation, we just note it
+		r.logger.Error(cleanupErrs, "issues during configcheck cleanup, moving on")
+		return nil, nil
+	}
+
+	if r.fluentbitAgent != nil && len(r.fluentbitAgent.Status.ConfigCheckResults) > 1 {
+		patchBase := client.MergeFrom(r.fluentbitAgent.DeepCopy())
+		r.fluentbitAgent.Status.ConfigCheckResults = map[string]bool{
+			hash: result,
+		}
+		if err := r.resourceReconciler.Client.Status().Patch(ctx, r.fluentbitAgent, patchBase); err != nil {
+			return nil, errors.WrapWithDetails(err, "failed to patch status", "fluentbitagent", r.fluentbitAgent.Name)
+		}
+		// explicitly ask for a requeue to short circuit the controller loop after the status update
+		return &reconcile.Result{Requeue: true}, nil
+	}
+	return nil, nil
+}
+
+// configCheckData returns the config files to validate, either the generated ones or the ones of the custom config secret
+func (r *Reconciler) configCheckData(ctx context.Context) (map[string][]byte, error) {
+	if r.fluentbitSpec.CustomConfigSecret != "" {
+		secret := &corev1.Secret{}
+		key := types.NamespacedName{Namespace: r.Logging.Spec.ControlNamespace, Name: r.fluentbitSpec.CustomConfigSecret}
+		if err := r.resourceReconciler.Client.Get(ctx, key, secret); err != nil {
+			return nil, errors.WrapIff(err, "failed to get custom config secret %s for the configcheck", r.fluentbitSpec.CustomConfigSecret)
+		}
+		return secret.Data, nil
+	}
+
+	if _, _, err := r.configSecret(); err != nil {
+		return nil, errors.WrapIf(err, "failed to generate config for the configcheck")
+	}
+	return r.configs, nil
+}
+
+func (r *Reconciler) configHash(configs map[string][]byte) (string, error) {
+	keys := make([]string, 0, len(configs))
+	for key := range configs {
+		// the discovered aggregator pods change with the scaling of the aggregator, they don't need a new check
+		if key == UpstreamConfigName && r.fluentbitSpec.UpstreamDiscovery != nil {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	hasher := fnv.New32()
+	for _, key := range keys {
+		if _, err := hasher.Write([]byte(key)); err != nil {
+			return "", errors.WrapIf(err, "failed to calculate hash for the config")
+		}
+		if _, err := hasher.Write(configs[key]); err != nil {
+			return "", errors.WrapIf(err, "failed to calculate hash for the config")
+		}
+	}
+	return fmt.Sprintf("%x", hasher.Sum32()), nil
+}
+
+func (r *Reconciler) configCheck(ctx context.Context, hashKey string, configs map[string][]byte) (*ConfigCheckResult, error) {
+	c := r.resourceReconciler.Client
+
+	checkSecret := r.newCheckSecret(hashKey, configs)
+	configcheck.WithHashLabel(checkSecret, hashKey)
+	err := c.Create(ctx, checkSecret)
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return nil, errors.WrapIf(err, "failed to create secret for fluentbit configcheck")
+	}
+
+	pod, err := r.newCheckPod(hashKey)
+	if err != nil {
+		return nil, errors.WrapIff(err, "failed to create resource description for config check pod %s", hashKey)
+	}
+	configcheck.WithHashLabel(pod, hashKey)
+
+	err = c.Get(ctx, types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}, pod)
+	if err == nil {
+		switch pod.Status.Phase {
+		case corev1.PodSucceeded:
+			return &ConfigCheckResult{
+				Valid: true,
+				Ready: true,
+			}, nil
+		case corev1.PodPending:
+			fallthrough
+		case corev1.PodRunning:
+			return &ConfigCheckResult{}, nil
+		case corev1.PodFailed:
+			return &ConfigCheckResult{
+				Ready: true,
+				Valid: false,
+			}, nil
+		case corev1.PodUnknown:
+			fallthrough
+		default:
+			return nil, errors.Errorf("invalid pod status %s, unable to a validate config", pod.Status.Phase)
+		}
+	}
+
+	if !apierrors.IsNotFound(err) {
+		return nil, errors.WrapIff(err, "failed to get configcheck pod %s:%s", pod.Namespace, pod.Name)
+	}
+
+	err = c.Create(ctx, pod)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to create pod for fluentbit configcheck")
+	}
+
+	return &ConfigCheckResult{}, nil
+}
+
+// configCheckSelectorLabels selects the configcheck resources of this fluentbit instance
+func (r *Reconciler) configCheckSelectorLabels() map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/instance":  r.nameProvider.Name(),
+		"app.kubernetes.io/component": componentConfigCheck,
+	}
+}
+
+// configCheckLabels must not match the selector of the DaemonSet, otherwise the pod would be adopted by it
+func (r *Reconciler) configCheckLabels() map[string]string {
+	return util.MergeLabels(r.getFluentBitLabels(), r.configCheckSelectorLabels(), map[string]string{
+		"app.kubernetes.io/name": componentConfigCheck,
+	})
+}
+
+func (r *Reconciler) newCheckSecret(hashKey string, configs map[string][]byte) *corev1.Secret {
+	meta := r.FluentbitObjectMeta(configCheckResourceName(hashKey))
+	meta.Labels = r.configCheckLabels()
+	return &corev1.Secret{
+		ObjectMeta: meta,
+		Data:       configs,
+	}
+}
+
+func (r *Reconciler) newCheckPod(hashKey string) (*corev1.Pod, error) {
+	meta := r.FluentbitObjectMeta(configCheckResourceName(hashKey))
+	meta.Labels = r.configCheckLabels()
+
+	volumes := []corev1.Volume{
+		{
+			Name: configCheckVolume,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: r.nameProvider.ComponentName(configCheckResourceName(hashKey)),
+				},
+			},
+		},
+	}
+	mounts := []corev1.VolumeMount{
+		{
+			Name:      configCheckVolume,
+			MountPath: OperatorConfigPath,
+		},
+	}
+	// the scripts of the lua filters and the TLS files of the forward and the direct outputs are referenced from the config
+	referencedSecrets := map[string]bool{"fluent-bit-tls": true}
+	for _, v := range r.directOutputVolumes() {
+		referencedSecrets[v.Name] = true
+	}
+	for _, v := range r.generateVolume() {
+		if v.ConfigMap == nil && !referencedSecrets[v.Name] {
+			continue
+		}
+		volumes = append(volumes, v)
+		for _, m := range r.generateVolumeMounts() {
+			if m.Name == v.Name {
+				mounts = append(mounts, m)
+			}
+		}
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: meta,
+		Spec: corev1.PodSpec{
+			RestartPolicy:      corev1.RestartPolicyNever,
+			ServiceAccountName: r.getServiceAccount(),
+			ImagePullSecrets:   r.fluentbitSpec.Image.ImagePullSecrets,
+			Volumes:            volumes,
+			Containers: []corev1.Container{
+				{
+					Name:            containerName,
+					Image:           r.fluentbitSpec.Image.RepositoryWithTag(),
+					ImagePullPolicy: corev1.PullPolicy(r.fluentbitSpec.Image.PullPolicy),
+					Command: []string{
+						StockBinPath, "-c", fmt.Sprintf("%s/%s", OperatorConfigPath, BaseConfigName), "--dry-run",
+					},
+					// the passwords of the direct outputs are referenced as env vars from the config
+					Env: append(append([]corev1.EnvVar{}, r.fluentbitSpec.EnvVars...), r.directOutputEnvVars()...),
+					Resources: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{
+							corev1.ResourceMemory: resource.MustParse("100M"),
+							corev1.ResourceCPU:    resource.MustParse("200m"),
+						},
+						Requests: corev1.ResourceList{
+							corev1.ResourceMemory: resource.MustParse("50M"),
+							corev1.ResourceCPU:    resource.MustParse("100m"),
+						},
+					},
+					VolumeMounts: mounts,
+				},
+			},
+		},
+	}
+
+	err := merge.Merge(&pod.Spec, r.fluentbitSpec.ConfigCheckPodOverrides)
+
+	return pod, err
+}
+
+func configCheckResourceName(hash string) string {
+	return fmt.Sprintf("fluentbit-configcheck-%s", hash)
+}
@@ -0,0 +1,231 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentbit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cisco-open/operator-tools/pkg/reconciler"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kube-logging/logging-operator/pkg/resources/configcheck"
+	"github.com/kube-logging/logging-operator/pkg/resources/model"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+)
+
+func newTestAgentReconciler(t *testing.T, spec v1beta1.FluentbitSpec, objs ...client.Object) *Reconciler {
+	logging := &v1beta1.Logging{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: v1beta1.LoggingSpec{
+			ControlNamespace: "logging",
+		},
+	}
+	agent := &v1beta1.FluentbitAgent{
+		ObjectMeta: metav1.ObjectMeta{Name: "agent"},
+		Spec:       spec,
+	}
+	require.NoError(t, v1beta1.FluentBitDefaults(&agent.Spec))
+
+	sch := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(sch))
+	require.NoError(t, v1beta1.AddToScheme(sch))
+	c := fake.NewClientBuilder().WithScheme(sch).WithObjects(append(objs, agent)...).Build()
+
+	return New(c, logr.Discard(), logging, reconciler.ReconcilerOpts{}, &agent.Spec, nil,
+		v1beta1.NewStandaloneFluentbitNameProvider(agent), model.LoggingResources{}, agent)
+}
+
+func getCheckPod(t *testing.T, r *Reconciler) *corev1.Pod {
+	pods := &corev1.PodList{}
+	require.NoError(t, r.resourceReconciler.Client.List(context.TODO(), pods, client.MatchingLabels(r.configCheckSelectorLabels())))
+	require.Len(t, pods.Items, 1)
+	return &pods.Items[0]
+}
+
+func setCheckPodPhase(t *testing.T, r *Reconciler, phase corev1.PodPhase) {
+	pod := getCheckPod(t, r)
+	pod.Status.Phase = phase
+	require.NoError(t, r.resourceReconciler.Client.Update(context.TODO(), pod))
+}
+
+func TestCheckConfig(t *testing.T) {
+	tests := map[string]struct {
+		phase      corev1.PodPhase
+		wantValid  bool
+		wantErr    string
+		wantRemain int
+	}{
+		"valid config": {
+			phase:      corev1.PodSucceeded,
+			wantValid:  true,
+			wantRemain: 1,
+		},
+		"invalid config": {
+			phase:      corev1.PodFailed,
+			wantValid:  false,
+			wantErr:    "current config is invalid",
+			wantRemain: 2,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.TODO()
+			r := newTestAgentReconciler(t, v1beta1.FluentbitSpec{})
+
+			// the first pass starts the check and waits for it
+			result, err := r.checkConfig(ctx)
+			require.NoError(t, err)
+			require.Equal(t, time.Minute, result.RequeueAfter)
+			hash, err := r.configHash(r.configs)
+			require.NoError(t, err)
+			pod := getCheckPod(t, r)
+			require.Equal(t, r.nameProvider.ComponentName(configCheckResourceName(hash)), pod.Name)
+			require.Equal(t, []string{StockBinPath, "-c", OperatorConfigPath + "/" + BaseConfigName, "--dry-run"}, pod.Spec.Containers[0].Command)
+
+			// the result is recorded in the status
+			setCheckPodPhase(t, r, test.phase)
+			result, err = r.checkConfig(ctx)
+			require.NoError(t, err)
+			require.True(t, result.Requeue)
+			require.Equal(t, map[string]bool{hash: test.wantValid}, r.fluentbitAgent.Status.ConfigCheckResults)
+
+			// the recorded result decides the rollout, the checks of the previous configs are removed after a valid one
+			stale, err := r.newCheckPod("stale")
+			require.NoError(t, err)
+			configcheck.WithHashLabel(stale, "stale")
+			require.NoError(t, r.resourceReconciler.Client.Create(ctx, stale))
+			result, err = r.checkConfig(ctx)
+			if test.wantErr != "" {
+				require.EqualError(t, err, test.wantErr)
+			} else {
+				require.NoError(t, err)
+				require.Nil(t, result)
+			}
+			pods := &corev1.PodList{}
+			require.NoError(t, r.resourceReconciler.Client.List(ctx, pods, client.MatchingLabels(r.configCheckSelectorLabels())))
+			require.Len(t, pods.Items, test.wantRemain)
+		})
+	}
+}
+
+func TestCheckConfigDisabled(t *testing.T) {
+	r := newTestAgentReconciler(t, v1beta1.FluentbitSpec{ConfigCheckDisabled: true})
+
+	result, err := r.checkConfig(context.TODO())
+	require.NoError(t, err)
+	require.Nil(t, result)
+
+	pods := &corev1.PodList{}
+	require.NoError(t, r.resourceReconciler.Client.List(context.TODO(), pods))
+	require.Empty(t, pods.Items)
+}
+
+func TestCheckConfigCustomConfigSecret(t *testing.T) {
+	custom := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "custom", Namespace: "logging"},
+		Data: map[string][]byte{
+			BaseConfigName: []byte("[SERVICE]\n    Flush 1\n"),
+		},
+	}
+	r := newTestAgentReconciler(t, v1beta1.FluentbitSpec{CustomConfigSecret: "custom"}, custom)
+
+	result, err := r.checkConfig(context.TODO())
+	require.NoError(t, err)
+	require.Equal(t, time.Minute, result.RequeueAfter)
+	require.Nil(t, r.configs)
+
+	hash, err := r.configHash(custom.Data)
+	require.NoError(t, err)
+	checkSecret := &corev1.Secret{}
+	require.NoError(t, r.resourceReconciler.Client.Get(context.TODO(),
+		client.ObjectKey{Namespace: "logging", Name: r.nameProvider.ComponentName(configCheckResourceName(hash))}, checkSecret))
+	require.Equal(t, custom.Data, checkSecret.Data)
+}
+
+func TestCheckConfigMissingCustomConfigSecret(t *testing.T) {
+	r := newTestAgentReconciler(t, v1beta1.FluentbitSpec{CustomConfigSecret: "custom"})
+
+	_, err := r.checkConfig(context.TODO())
+	require.ErrorContains(t, err, "failed to get custom config secret custom for the configcheck")
+}
+
+func TestNewCheckPod(t *testing.T) {
+	r := newTestAgentReconciler(t, v1beta1.FluentbitSpec{
+		EnvVars: []corev1.EnvVar{{Name: "CLUSTER", Value: "test"}},
+		Filters: []v1beta1.FluentbitFilter{
+			{Lua: &v1beta1.FilterLua{
+				Script: corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "scripts"},
+					Key:                  "filter.lua",
+				},
+				Call: "cb_filter",
+			}},
+		},
+		DirectOutputs: []v1beta1.FluentbitDirectOutput{
+			{
+				Name:       "loki",
+				Namespaces: []string{"audit"},
+				TLS:        &v1beta1.FluentbitDirectOutputTLS{SecretName: "loki-tls"},
+				Loki: &v1beta1.DirectOutputLoki{
+					Host: "loki",
+					HTTPPasswd: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "loki-auth"},
+						Key:                  "password",
+					},
+				},
+			},
+		},
+	})
+
+	pod, err := r.newCheckPod("abc")
+	require.NoError(t, err)
+
+	var volumes []string
+	for _, v := range pod.Spec.Volumes {
+		volumes = append(volumes, v.Name)
+	}
+	require.ElementsMatch(t, []string{configCheckVolume, luaScriptVolumeName(0), directOutputTLSVolumeName("loki")}, volumes)
+
+	mounts := make(map[string]string)
+	for _, m := range pod.Spec.Containers[0].VolumeMounts {
+		mounts[m.Name] = m.MountPath
+	}
+	require.Equal(t, map[string]string{
+		configCheckVolume:                 OperatorConfigPath,
+		luaScriptVolumeName(0):            luaScriptDir(0),
+		directOutputTLSVolumeName("loki"): directOutputTLSDir("loki"),
+	}, mounts)
+
+	require.Equal(t, []corev1.EnvVar{
+		{Name: "CLUSTER", Value: "test"},
+		{
+			Name: directOutputEnvVarName(0, "HTTP_PASSWD"),
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "loki-auth"},
+				Key:                  "password",
+			}},
+		},
+	}, pod.Spec.Containers[0].Env)
+	require.Equal(t, r.configCheckLabels(), pod.Labels)
+}
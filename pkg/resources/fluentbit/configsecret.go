@@ -18,6 +18,9 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
 	"text/template"
 
 	"emperror.dev/errors"
@@ -38,6 +41,31 @@ type fluentbitInputConfig struct {
 	MultilineParser []string
 }
 
+type fluentbitAdditionalInputConfig struct {
+	Name           string
+	Tag            string
+	Values         map[string]string
+	SystemdFilters []string
+}
+
+type fluentbitFilterConfig struct {
+	Name   string
+	Values map[string]string
+	// Params are rendered after the values, in order, and may repeat the same key
+	Params []fluentbitParam
+}
+
+type fluentbitParam struct {
+	Key   string
+	Value string
+}
+
+// namespaceFilterConfig keeps the container logs of the namespaces matching the regex
+type namespaceFilterConfig struct {
+	Match string
+	Regex string
+}
+
 type upstreamNode struct {
 	Name string
 	Host string
@@ -56,17 +84,24 @@ type fluentBitConfig struct {
 		Port    int32
 		Path    string
 	}
+	HotReload               bool
+	StorageMetrics          bool
 	Flush                   int32
 	Grace                   int32
 	LogLevel                string
 	CoroStackSize           int32
 	Output                  map[string]string
 	Input                   fluentbitInputConfig
+	AdditionalInputs        []fluentbitAdditionalInputConfig
 	DisableKubernetesFilter bool
 	KubernetesFilter        map[string]string
+	NamespaceFilter         *namespaceFilterConfig
 	AwsFilter               map[string]string
 	BufferStorage           map[string]string
 	FilterModify            []v1beta1.FilterModify
+	Filters                 []fluentbitFilterConfig
+	DirectOutputRouting     *directOutputRoutingConfig
+	DirectOutputs           []fluentbitDirectOutputConfig
 	FluentForwardOutput     *fluentForwardOutputConfig
 	SyslogNGOutput          *syslogNGOutputConfig
 	DefaultParsers          string
@@ -85,6 +120,8 @@ type fluentForwardOutputConfig struct {
 type fluentForwardOutputTLSConfig struct {
 	Enabled   bool
 	SharedKey string
+	Verify    bool
+	VHost     string
 }
 
 type fluentForwardOutputUpstreamConfig struct {
@@ -117,6 +154,13 @@ type syslogNGOutputConfig struct {
 	JSONDateFormat string
 	Workers        *int
 	Network        FluentbitNetwork
+	TLS            syslogNGOutputTLSConfig
+}
+
+type syslogNGOutputTLSConfig struct {
+	Enabled bool
+	Verify  bool
+	VHost   string
 }
 
 func newFluentbitNetwork(network v1beta1.FluentbitNetwork) (result FluentbitNetwork) {
@@ -202,6 +246,16 @@ func (r *Reconciler) configSecret() (runtime.Object, reconciler.DesiredState, er
 		input.Monitor.Enabled = true
 		input.Monitor.Port = r.fluentbitSpec.Metrics.Port
 		input.Monitor.Path = r.fluentbitSpec.Metrics.Path
+		input.StorageMetrics = true
+	}
+
+	if r.fluentbitSpec.ConfigHotReload != nil {
+		// the reload is triggered through the HTTP API
+		input.HotReload = true
+		if !input.Monitor.Enabled {
+			input.Monitor.Enabled = true
+			input.Monitor.Port = r.httpPort()
+		}
 	}
 
 	if r.fluentbitSpec.InputTail.Parser == "" {
@@ -234,6 +288,10 @@ func (r *Reconciler) configSecret() (runtime.Object, reconciler.DesiredState, er
 				SharedKey: r.fluentbitSpec.TLS.SharedKey,
 			},
 		}
+		if r.managedTLS() {
+			input.FluentForwardOutput.TLS.Verify = true
+			input.FluentForwardOutput.TLS.VHost = fmt.Sprintf("%s.%s.svc%s", r.Logging.QualifiedName(fluentd.ServiceName), r.Logging.Spec.ControlNamespace, r.Logging.ClusterDomainAsSuffix())
+		}
 	}
 
 	mapper := types.NewStructToStringMapper(nil)
@@ -270,11 +328,43 @@ func (r *Reconciler) configSecret() (runtime.Object, reconciler.DesiredState, er
 	}
 	input.Input.Values = fluentbitInputValues
 
+	input.AdditionalInputs, err = r.additionalInputs(mapper)
+	if err != nil {
+		return nil, reconciler.StatePresent, err
+	}
+
+	input.Filters, err = r.filters(mapper)
+	if err != nil {
+		return nil, reconciler.StatePresent, err
+	}
+
 	input.KubernetesFilter, err = mapper.StringsMap(r.fluentbitSpec.FilterKubernetes)
 	if err != nil {
 		return nil, reconciler.StatePresent, errors.WrapIf(err, "failed to map kubernetes filter for fluentbit")
 	}
 
+	if r.fluentbitSpec.DropUnmatchedNamespaces {
+		if disableKubernetesFilter {
+			r.logger.Info("Notice: dropping the logs of unmatched namespaces requires the kubernetes filter, forwarding all logs")
+		} else if namespaces, all := r.routedNamespaces(); !all {
+			input.NamespaceFilter = &namespaceFilterConfig{
+				Match: input.KubernetesFilter["Match"],
+				Regex: namespacesRegex(namespaces),
+			}
+		}
+	}
+
+	if len(r.fluentbitSpec.DirectOutputs) > 0 {
+		if disableKubernetesFilter {
+			return nil, reconciler.StatePresent, errors.New("direct outputs require the kubernetes filter")
+		}
+		input.DirectOutputs, err = r.directOutputs(mapper)
+		if err != nil {
+			return nil, reconciler.StatePresent, err
+		}
+		input.DirectOutputRouting = r.directOutputRouting(input.KubernetesFilter["Match"])
+	}
+
 	input.BufferStorage, err = mapper.StringsMap(r.fluentbitSpec.BufferStorage)
 	if err != nil {
 		return nil, reconciler.StatePresent, errors.WrapIf(err, "failed to map buffer storage for fluentbit")
@@ -324,9 +414,11 @@ func (r *Reconciler) configSecret() (runtime.Object, reconciler.DesiredState, er
 		if r.fluentbitSpec.EnableUpstream {
 			input.FluentForwardOutput.Upstream.Enabled = true
 			input.FluentForwardOutput.Upstream.Config.Name = "fluentd-upstream"
-			for i := int32(0); i < utils.PointerToInt32(aggregatorReplicas); i++ {
-				input.FluentForwardOutput.Upstream.Config.Nodes = append(input.FluentForwardOutput.Upstream.Config.Nodes, r.generateUpstreamNode(i))
+			nodes, err := r.upstreamNodes(context.TODO(), utils.PointerToInt32(aggregatorReplicas))
+			if err != nil {
+				return nil, nil, err
 			}
+			input.FluentForwardOutput.Upstream.Config.Nodes = nodes
 		}
 	}
 
@@ -336,6 +428,11 @@ func (r *Reconciler) configSecret() (runtime.Object, reconciler.DesiredState, er
 		input.SyslogNGOutput.Port = syslogng.ServicePort
 		input.SyslogNGOutput.JSONDateKey = "ts"
 		input.SyslogNGOutput.JSONDateFormat = "iso8601"
+		input.SyslogNGOutput.TLS.Enabled = *r.fluentbitSpec.TLS.Enabled
+		if r.managedTLS() {
+			input.SyslogNGOutput.TLS.Verify = true
+			input.SyslogNGOutput.TLS.VHost = fmt.Sprintf("%s.%s.svc%s", r.Logging.QualifiedName(syslogng.ServiceName), r.Logging.Spec.ControlNamespace, r.Logging.ClusterDomainAsSuffix())
+		}
 
 		if r.fluentbitSpec.SyslogNGOutput != nil {
 			input.SyslogNGOutput.JSONDateKey = r.fluentbitSpec.SyslogNGOutput.JsonDateKey
@@ -377,6 +474,191 @@ func (r *Reconciler) configSecret() (runtime.Object, reconciler.DesiredState, er
 	}, reconciler.StatePresent, nil
 }
 
+func (r *Reconciler) additionalInputs(mapper *types.StructToStringMapper) ([]fluentbitAdditionalInputConfig, error) {
+	var inputs []fluentbitAdditionalInputConfig
+	tagPrefixes := make(map[string]bool)
+	containerTagPrefix := strings.TrimSuffix(r.fluentbitSpec.InputTail.Tag, "*")
+	for i, in := range r.fluentbitSpec.AdditionalInputs {
+		var config fluentbitAdditionalInputConfig
+		var values any
+		switch {
+		case in.Systemd != nil && in.Tail == nil && in.Kmsg == nil:
+			systemd := *in.Systemd
+			if systemd.Path == "" {
+				systemd.Path = "/var/log/journal"
+			}
+			config.Name = "systemd"
+			config.SystemdFilters = systemd.SystemdFilter
+			systemd.SystemdFilter = nil
+			values = systemd
+		case in.Tail != nil && in.Systemd == nil && in.Kmsg == nil:
+			if in.Tail.Path == "" {
+				return nil, errors.Errorf("path is required for the tail input with index %d", i)
+			}
+			config.Name = "tail"
+			values = in.Tail
+		case in.Kmsg != nil && in.Systemd == nil && in.Tail == nil:
+			config.Name = "kmsg"
+			values = in.Kmsg
+		default:
+			return nil, errors.Errorf("exactly one input type has to be set for the additional input with index %d", i)
+		}
+
+		tagPrefix := in.TagPrefix
+		if tagPrefix == "" {
+			tagPrefix = "host." + config.Name
+		}
+		if tagPrefixes[tagPrefix] {
+			return nil, errors.Errorf("duplicate tag prefix %q of the additional input with index %d", tagPrefix, i)
+		}
+		if containerTagPrefix != "" && strings.HasPrefix(tagPrefix+".", containerTagPrefix) {
+			return nil, errors.Errorf("tag prefix %q of the additional input with index %d overlaps with the container log tag %q", tagPrefix, i, r.fluentbitSpec.InputTail.Tag)
+		}
+		tagPrefixes[tagPrefix] = true
+
+		// the systemd and tail inputs expand the wildcard with the unit name and the file path
+		config.Tag = tagPrefix + ".*"
+		if config.Name == "kmsg" {
+			config.Tag = tagPrefix
+		}
+
+		var err error
+		config.Values, err = mapper.StringsMap(values)
+		if err != nil {
+			return nil, errors.WrapIff(err, "failed to map the %s input for fluentbit", config.Name)
+		}
+		inputs = append(inputs, config)
+	}
+	return inputs, nil
+}
+
+func (r *Reconciler) filters(mapper *types.StructToStringMapper) ([]fluentbitFilterConfig, error) {
+	var filters []fluentbitFilterConfig
+	for i, f := range r.fluentbitSpec.Filters {
+		var config fluentbitFilterConfig
+		var values any
+		switch {
+		case filterTypeCount(f) != 1:
+			return nil, errors.Errorf("exactly one filter type has to be set for the filter with index %d", i)
+		case f.Grep != nil:
+			grep := *f.Grep
+			config.Name = "grep"
+			for _, regex := range grep.Regex {
+				config.Params = append(config.Params, fluentbitParam{Key: "Regex", Value: regex})
+			}
+			for _, exclude := range grep.Exclude {
+				config.Params = append(config.Params, fluentbitParam{Key: "Exclude", Value: exclude})
+			}
+			grep.Regex, grep.Exclude = nil, nil
+			values = grep
+		case f.Lua != nil:
+			lua := *f.Lua
+			if lua.Call == "" {
+				return nil, errors.Errorf("call is required for the lua filter with index %d", i)
+			}
+			config.Name = "lua"
+			config.Params = append(config.Params, fluentbitParam{Key: "script", Value: filepath.Join(luaScriptDir(i), lua.Script.Key)})
+			if len(lua.TypeIntKey) > 0 {
+				config.Params = append(config.Params, fluentbitParam{Key: "type_int_key", Value: strings.Join(lua.TypeIntKey, " ")})
+			}
+			if len(lua.TypeArrayKey) > 0 {
+				config.Params = append(config.Params, fluentbitParam{Key: "type_array_key", Value: strings.Join(lua.TypeArrayKey, " ")})
+			}
+			lua.Script = corev1.ConfigMapKeySelector{}
+			lua.TypeIntKey, lua.TypeArrayKey = nil, nil
+			values = lua
+		case f.Nest != nil:
+			nest := *f.Nest
+			config.Name = "nest"
+			for _, wildcard := range nest.Wildcard {
+				config.Params = append(config.Params, fluentbitParam{Key: "Wildcard", Value: wildcard})
+			}
+			nest.Wildcard = nil
+			values = nest
+		case f.RewriteTag != nil:
+			rewriteTag := *f.RewriteTag
+			if len(rewriteTag.Rules) == 0 {
+				return nil, errors.Errorf("at least one rule is required for the rewrite_tag filter with index %d", i)
+			}
+			if rewriteTag.Match == "" || rewriteTag.Match == "*" {
+				return nil, errors.Errorf("an explicit match is required for the rewrite_tag filter with index %d, the re-emitted records must not match the filter again", i)
+			}
+			for _, rule := range rewriteTag.Rules {
+				if tagCanMatch(rewriteTag.Match, rule.NewTag) {
+					return nil, errors.Errorf("the new tag %s of the rewrite_tag filter with index %d can match the filter again, the records would be re-emitted in a loop", rule.NewTag, i)
+				}
+			}
+			config.Name = "rewrite_tag"
+			for _, rule := range rewriteTag.Rules {
+				config.Params = append(config.Params, fluentbitParam{
+					Key:   "Rule",
+					Value: fmt.Sprintf("%s %s %s %t", rule.Key, rule.Regex, rule.NewTag, rule.Keep),
+				})
+			}
+			rewriteTag.Rules = nil
+			values = rewriteTag
+		case f.Throttle != nil:
+			config.Name = "throttle"
+			values = f.Throttle
+		case f.Multiline != nil:
+			multiline := *f.Multiline
+			if len(multiline.Parser) == 0 {
+				return nil, errors.Errorf("at least one parser is required for the multiline filter with index %d", i)
+			}
+			config.Name = "multiline"
+			config.Params = append(config.Params, fluentbitParam{Key: "multiline.parser", Value: strings.Join(multiline.Parser, ",")})
+			multiline.Parser = nil
+			values = multiline
+		}
+
+		var err error
+		config.Values, err = mapper.StringsMap(values)
+		if err != nil {
+			return nil, errors.WrapIff(err, "failed to map the %s filter for fluentbit", config.Name)
+		}
+		filters = append(filters, config)
+	}
+	return filters, nil
+}
+
+// managedTLS returns true if the agent uses the client certificate managed by the operator,
+// the server certificates of the aggregators are issued for their service names by the same CA
+func (r *Reconciler) managedTLS() bool {
+	return r.Logging.Spec.ManagedTLS != nil && *r.fluentbitSpec.TLS.Enabled && r.fluentbitSpec.TLS.SecretName == r.Logging.ManagedTLSAgentSecretName()
+}
+
+func namespacesRegex(namespaces []string) string {
+	quoted := make([]string, 0, len(namespaces))
+	for _, ns := range namespaces {
+		quoted = append(quoted, regexp.QuoteMeta(ns))
+	}
+	return "^(" + strings.Join(quoted, "|") + ")$"
+}
+
+// tagCanMatch returns true if the tags produced by the rewrite_tag template can match the match pattern.
+// Templates with placeholders are checked by their literal prefix, so the check is conservative.
+func tagCanMatch(match, newTag string) bool {
+	if !strings.Contains(newTag, "$") {
+		return regexp.MustCompile("^" + strings.ReplaceAll(regexp.QuoteMeta(match), `\*`, ".*") + "$").MatchString(newTag)
+	}
+	matchPrefix, _, _ := strings.Cut(match, "*")
+	tagPrefix, _, _ := strings.Cut(newTag, "$")
+	return strings.HasPrefix(matchPrefix, tagPrefix) || strings.HasPrefix(tagPrefix, matchPrefix)
+}
+
+func filterTypeCount(f v1beta1.FluentbitFilter) (count int) {
+	for _, set := range []bool{f.Grep != nil, f.Lua != nil, f.Nest != nil, f.RewriteTag != nil, f.Throttle != nil, f.Multiline != nil} {
+		if set {
+			count++
+		}
+	}
+	return
+}
+
+func luaScriptDir(filterIndex int) string {
+	return fmt.Sprintf("%s/filter-%d", LuaScriptsPath, filterIndex)
+}
+
 func generateConfig(input fluentBitConfig) (string, error) {
 	output := new(bytes.Buffer)
 	tmpl, err := template.New("test").Parse(fluentBitConfigTemplate)
@@ -408,11 +690,7 @@ func (r *Reconciler) generateUpstreamNode(index int32) upstreamNode {
 	podName := r.Logging.QualifiedName(fmt.Sprintf("%s-%d", fluentd.ComponentFluentd, index))
 	return upstreamNode{
 		Name: podName,
-		Host: fmt.Sprintf("%s.%s.%s.svc%s",
-			podName,
-			r.Logging.QualifiedName(fluentd.ServiceName+"-headless"),
-			r.Logging.Spec.ControlNamespace,
-			r.Logging.ClusterDomainAsSuffix()),
-		Port: 24240,
+		Host: r.aggregatorPodHost(podName),
+		Port: fluentdForwardPort,
 	}
 }
@@ -0,0 +1,500 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentbit
+
+import (
+	"testing"
+
+	"github.com/cisco-open/operator-tools/pkg/reconciler"
+	"github.com/cisco-open/operator-tools/pkg/utils"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kube-logging/logging-operator/pkg/resources/fluentd"
+	"github.com/kube-logging/logging-operator/pkg/resources/model"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/types"
+)
+
+func newTestReconciler(t *testing.T, spec v1beta1.FluentbitSpec, objs ...client.Object) *Reconciler {
+	logging := &v1beta1.Logging{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: v1beta1.LoggingSpec{
+			ControlNamespace: "logging",
+		},
+	}
+	require.NoError(t, v1beta1.FluentBitDefaults(&spec))
+
+	sch := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(sch))
+	require.NoError(t, v1beta1.AddToScheme(sch))
+	c := fake.NewClientBuilder().WithScheme(sch).WithObjects(objs...).Build()
+
+	return New(c, logr.Discard(), logging, reconciler.ReconcilerOpts{}, &spec, nil,
+		v1beta1.NewLegacyFluentbitNameProvider(logging), model.LoggingResources{}, nil)
+}
+
+func TestAdditionalInputs(t *testing.T) {
+	tests := map[string]struct {
+		inputs  []v1beta1.FluentbitAdditionalInput
+		want    []fluentbitAdditionalInputConfig
+		wantErr string
+	}{
+		"default tags": {
+			inputs: []v1beta1.FluentbitAdditionalInput{
+				{Systemd: &v1beta1.InputSystemd{}},
+				{Tail: &v1beta1.InputHostTail{Path: "/var/log/audit/*.log"}},
+				{Kmsg: &v1beta1.InputKmsg{PrioLevel: "4"}},
+			},
+			want: []fluentbitAdditionalInputConfig{
+				{Name: "systemd", Tag: "host.systemd.*", Values: map[string]string{"Path": "/var/log/journal"}},
+				{Name: "tail", Tag: "host.tail.*", Values: map[string]string{"Path": "/var/log/audit/*.log"}},
+				{Name: "kmsg", Tag: "host.kmsg", Values: map[string]string{"Prio_Level": "4"}},
+			},
+		},
+		"custom tag prefixes": {
+			inputs: []v1beta1.FluentbitAdditionalInput{
+				{TagPrefix: "node.kubelet", Systemd: &v1beta1.InputSystemd{Path: "/run/log/journal"}},
+				{TagPrefix: "node.kernel", Kmsg: &v1beta1.InputKmsg{}},
+			},
+			want: []fluentbitAdditionalInputConfig{
+				{Name: "systemd", Tag: "node.kubelet.*", Values: map[string]string{"Path": "/run/log/journal"}},
+				{Name: "kmsg", Tag: "node.kernel", Values: map[string]string{}},
+			},
+		},
+		"systemd filters": {
+			inputs: []v1beta1.FluentbitAdditionalInput{
+				{Systemd: &v1beta1.InputSystemd{
+					SystemdFilter:     []string{"_SYSTEMD_UNIT=kubelet.service", "_SYSTEMD_UNIT=containerd.service"},
+					SystemdFilterType: "Or",
+				}},
+			},
+			want: []fluentbitAdditionalInputConfig{
+				{
+					Name:           "systemd",
+					Tag:            "host.systemd.*",
+					Values:         map[string]string{"Path": "/var/log/journal", "Systemd_Filter_Type": "Or"},
+					SystemdFilters: []string{"_SYSTEMD_UNIT=kubelet.service", "_SYSTEMD_UNIT=containerd.service"},
+				},
+			},
+		},
+		"duplicate default tag prefix": {
+			inputs: []v1beta1.FluentbitAdditionalInput{
+				{Tail: &v1beta1.InputHostTail{Path: "/var/log/audit/*.log"}},
+				{Tail: &v1beta1.InputHostTail{Path: "/var/log/messages"}},
+			},
+			wantErr: `duplicate tag prefix "host.tail" of the additional input with index 1`,
+		},
+		"duplicate custom tag prefix": {
+			inputs: []v1beta1.FluentbitAdditionalInput{
+				{TagPrefix: "node", Systemd: &v1beta1.InputSystemd{}},
+				{TagPrefix: "node", Kmsg: &v1beta1.InputKmsg{}},
+			},
+			wantErr: `duplicate tag prefix "node" of the additional input with index 1`,
+		},
+		"tag prefix overlaps with the container logs": {
+			inputs: []v1beta1.FluentbitAdditionalInput{
+				{TagPrefix: "kubernetes", Systemd: &v1beta1.InputSystemd{}},
+			},
+			wantErr: `tag prefix "kubernetes" of the additional input with index 0 overlaps with the container log tag "kubernetes.*"`,
+		},
+		"nested tag prefix overlaps with the container logs": {
+			inputs: []v1beta1.FluentbitAdditionalInput{
+				{TagPrefix: "kubernetes.node", Kmsg: &v1beta1.InputKmsg{}},
+			},
+			wantErr: `tag prefix "kubernetes.node" of the additional input with index 0 overlaps with the container log tag "kubernetes.*"`,
+		},
+		"tag prefix sharing the first characters of the container tag": {
+			inputs: []v1beta1.FluentbitAdditionalInput{
+				{TagPrefix: "kube", Kmsg: &v1beta1.InputKmsg{}},
+			},
+			want: []fluentbitAdditionalInputConfig{
+				{Name: "kmsg", Tag: "kube", Values: map[string]string{}},
+			},
+		},
+		"tail without path": {
+			inputs: []v1beta1.FluentbitAdditionalInput{
+				{Tail: &v1beta1.InputHostTail{}},
+			},
+			wantErr: "path is required for the tail input with index 0",
+		},
+		"no input type": {
+			inputs:  []v1beta1.FluentbitAdditionalInput{{TagPrefix: "node"}},
+			wantErr: "exactly one input type has to be set for the additional input with index 0",
+		},
+		"multiple input types": {
+			inputs: []v1beta1.FluentbitAdditionalInput{
+				{Systemd: &v1beta1.InputSystemd{}, Kmsg: &v1beta1.InputKmsg{}},
+			},
+			wantErr: "exactly one input type has to be set for the additional input with index 0",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := newTestReconciler(t, v1beta1.FluentbitSpec{AdditionalInputs: test.inputs})
+
+			inputs, err := r.additionalInputs(types.NewStructToStringMapper(nil))
+			if test.wantErr != "" {
+				require.EqualError(t, err, test.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.want, inputs)
+		})
+	}
+}
+
+func TestGenerateConfigAdditionalInputs(t *testing.T) {
+	r := newTestReconciler(t, v1beta1.FluentbitSpec{
+		AdditionalInputs: []v1beta1.FluentbitAdditionalInput{
+			{Systemd: &v1beta1.InputSystemd{
+				SystemdFilter: []string{"_SYSTEMD_UNIT=kubelet.service", "_SYSTEMD_UNIT=containerd.service"},
+			}},
+			{Kmsg: &v1beta1.InputKmsg{}},
+		},
+	})
+	inputs, err := r.additionalInputs(types.NewStructToStringMapper(nil))
+	require.NoError(t, err)
+
+	conf, err := generateConfig(fluentBitConfig{AdditionalInputs: inputs, DisableKubernetesFilter: true})
+	require.NoError(t, err)
+	require.Contains(t, conf, `
+[INPUT]
+    Name         systemd
+    Tag          host.systemd.*
+    Path  /var/log/journal
+    Systemd_Filter  _SYSTEMD_UNIT=kubelet.service
+    Systemd_Filter  _SYSTEMD_UNIT=containerd.service
+`)
+	require.Contains(t, conf, `
+[INPUT]
+    Name         kmsg
+    Tag          host.kmsg
+`)
+}
+
+func TestKmsgVolume(t *testing.T) {
+	hasKmsg := func(r *Reconciler) (volume bool, mount bool) {
+		for _, v := range r.generateVolume() {
+			if v.Name == "kmsg" {
+				require.NotNil(t, v.HostPath)
+				require.Equal(t, "/dev/kmsg", v.HostPath.Path)
+				volume = true
+			}
+		}
+		for _, m := range r.generateVolumeMounts() {
+			if m.Name == "kmsg" {
+				require.Equal(t, "/dev/kmsg", m.MountPath)
+				require.True(t, m.ReadOnly)
+				mount = true
+			}
+		}
+		return
+	}
+
+	volume, mount := hasKmsg(newTestReconciler(t, v1beta1.FluentbitSpec{
+		AdditionalInputs: []v1beta1.FluentbitAdditionalInput{
+			{Systemd: &v1beta1.InputSystemd{}},
+		},
+	}))
+	require.False(t, volume)
+	require.False(t, mount)
+
+	volume, mount = hasKmsg(newTestReconciler(t, v1beta1.FluentbitSpec{
+		AdditionalInputs: []v1beta1.FluentbitAdditionalInput{
+			{Systemd: &v1beta1.InputSystemd{}},
+			{Kmsg: &v1beta1.InputKmsg{}},
+		},
+	}))
+	require.True(t, volume)
+	require.True(t, mount)
+}
+
+func TestFilters(t *testing.T) {
+	tests := map[string]struct {
+		filters []v1beta1.FluentbitFilter
+		want    []fluentbitFilterConfig
+		wantErr string
+	}{
+		"grep": {
+			filters: []v1beta1.FluentbitFilter{
+				{Grep: &v1beta1.FilterGrep{
+					Match:     "kubernetes.*",
+					Regex:     []string{"level ^(error|warn)$", "log timeout"},
+					Exclude:   []string{"log ^debug"},
+					LogicalOp: "or",
+				}},
+			},
+			want: []fluentbitFilterConfig{
+				{
+					Name:   "grep",
+					Values: map[string]string{"Match": "kubernetes.*", "Logical_Op": "or"},
+					Params: []fluentbitParam{
+						{Key: "Regex", Value: "level ^(error|warn)$"},
+						{Key: "Regex", Value: "log timeout"},
+						{Key: "Exclude", Value: "log ^debug"},
+					},
+				},
+			},
+		},
+		"lua": {
+			filters: []v1beta1.FluentbitFilter{
+				{Throttle: &v1beta1.FilterThrottle{Rate: "800", Window: "3"}},
+				{Lua: &v1beta1.FilterLua{
+					Script: corev1.ConfigMapKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "scripts"},
+						Key:                  "filter.lua",
+					},
+					Call:         "cb_filter",
+					TypeIntKey:   []string{"status", "bytes"},
+					TypeArrayKey: []string{"tags"},
+				}},
+			},
+			want: []fluentbitFilterConfig{
+				{
+					Name:   "throttle",
+					Values: map[string]string{"Match": "*", "Rate": "800", "Window": "3"},
+				},
+				{
+					Name:   "lua",
+					Values: map[string]string{"Match": "*", "call": "cb_filter"},
+					Params: []fluentbitParam{
+						{Key: "script", Value: LuaScriptsPath + "/filter-1/filter.lua"},
+						{Key: "type_int_key", Value: "status bytes"},
+						{Key: "type_array_key", Value: "tags"},
+					},
+				},
+			},
+		},
+		"nest": {
+			filters: []v1beta1.FluentbitFilter{
+				{Nest: &v1beta1.FilterNest{Operation: "nest", Wildcard: []string{"Mem.*", "Swap.*"}, NestUnder: "memory"}},
+			},
+			want: []fluentbitFilterConfig{
+				{
+					Name:   "nest",
+					Values: map[string]string{"Match": "*", "Operation": "nest", "Nest_under": "memory"},
+					Params: []fluentbitParam{
+						{Key: "Wildcard", Value: "Mem.*"},
+						{Key: "Wildcard", Value: "Swap.*"},
+					},
+				},
+			},
+		},
+		"rewrite tag": {
+			filters: []v1beta1.FluentbitFilter{
+				{RewriteTag: &v1beta1.FilterRewriteTag{
+					Match: "kube.*",
+					Rules: []v1beta1.FilterRewriteTagRule{
+						{Key: "$level", Regex: "^(error)$", NewTag: "alert.$TAG", Keep: true},
+					},
+					EmitterName: "alerts",
+				}},
+			},
+			want: []fluentbitFilterConfig{
+				{
+					Name:   "rewrite_tag",
+					Values: map[string]string{"Match": "kube.*", "Emitter_Name": "alerts"},
+					Params: []fluentbitParam{{Key: "Rule", Value: "$level ^(error)$ alert.$TAG true"}},
+				},
+			},
+		},
+		"multiline": {
+			filters: []v1beta1.FluentbitFilter{
+				{Multiline: &v1beta1.FilterMultiline{Parser: []string{"go", "java"}, KeyContent: "log"}},
+			},
+			want: []fluentbitFilterConfig{
+				{
+					Name:   "multiline",
+					Values: map[string]string{"Match": "*", "multiline.key_content": "log"},
+					Params: []fluentbitParam{{Key: "multiline.parser", Value: "go,java"}},
+				},
+			},
+		},
+		"no filter type": {
+			filters: []v1beta1.FluentbitFilter{{}},
+			wantErr: "exactly one filter type has to be set for the filter with index 0",
+		},
+		"multiple filter types": {
+			filters: []v1beta1.FluentbitFilter{
+				{Grep: &v1beta1.FilterGrep{}, Nest: &v1beta1.FilterNest{Operation: "lift"}},
+			},
+			wantErr: "exactly one filter type has to be set for the filter with index 0",
+		},
+		"lua without call": {
+			filters: []v1beta1.FluentbitFilter{
+				{Lua: &v1beta1.FilterLua{Script: corev1.ConfigMapKeySelector{Key: "filter.lua"}}},
+			},
+			wantErr: "call is required for the lua filter with index 0",
+		},
+		"rewrite tag without rules": {
+			filters: []v1beta1.FluentbitFilter{
+				{RewriteTag: &v1beta1.FilterRewriteTag{}},
+			},
+			wantErr: "at least one rule is required for the rewrite_tag filter with index 0",
+		},
+		"rewrite tag without match": {
+			filters: []v1beta1.FluentbitFilter{
+				{RewriteTag: &v1beta1.FilterRewriteTag{
+					Rules: []v1beta1.FilterRewriteTagRule{{Key: "$level", Regex: "^(error)$", NewTag: "alert.$TAG"}},
+				}},
+			},
+			wantErr: "an explicit match is required for the rewrite_tag filter with index 0, the re-emitted records must not match the filter again",
+		},
+		"rewrite tag matching every record": {
+			filters: []v1beta1.FluentbitFilter{
+				{RewriteTag: &v1beta1.FilterRewriteTag{
+					Match: "*",
+					Rules: []v1beta1.FilterRewriteTagRule{{Key: "$level", Regex: "^(error)$", NewTag: "alert.$TAG"}},
+				}},
+			},
+			wantErr: "an explicit match is required for the rewrite_tag filter with index 0, the re-emitted records must not match the filter again",
+		},
+		"rewrite tag matching its new tag": {
+			filters: []v1beta1.FluentbitFilter{
+				{RewriteTag: &v1beta1.FilterRewriteTag{
+					Match: "kube.*",
+					Rules: []v1beta1.FilterRewriteTagRule{{Key: "$level", Regex: "^(error)$", NewTag: "kube.errors"}},
+				}},
+			},
+			wantErr: "the new tag kube.errors of the rewrite_tag filter with index 0 can match the filter again, the records would be re-emitted in a loop",
+		},
+		"rewrite tag matching its new tag template": {
+			filters: []v1beta1.FluentbitFilter{
+				{RewriteTag: &v1beta1.FilterRewriteTag{
+					Match: "kube.*",
+					Rules: []v1beta1.FilterRewriteTagRule{{Key: "$level", Regex: "^(error)$", NewTag: "kube.$TAG[1]"}},
+				}},
+			},
+			wantErr: "the new tag kube.$TAG[1] of the rewrite_tag filter with index 0 can match the filter again, the records would be re-emitted in a loop",
+		},
+		"multiline without parser": {
+			filters: []v1beta1.FluentbitFilter{
+				{Multiline: &v1beta1.FilterMultiline{}},
+			},
+			wantErr: "at least one parser is required for the multiline filter with index 0",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := newTestReconciler(t, v1beta1.FluentbitSpec{Filters: test.filters})
+
+			filters, err := r.filters(types.NewStructToStringMapper(nil))
+			if test.wantErr != "" {
+				require.EqualError(t, err, test.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.want, filters)
+		})
+	}
+}
+
+func TestGenerateConfigFilters(t *testing.T) {
+	r := newTestReconciler(t, v1beta1.FluentbitSpec{
+		Filters: []v1beta1.FluentbitFilter{
+			{Grep: &v1beta1.FilterGrep{Regex: []string{"level ^error$", "log timeout"}}},
+		},
+	})
+	filters, err := r.filters(types.NewStructToStringMapper(nil))
+	require.NoError(t, err)
+
+	conf, err := generateConfig(fluentBitConfig{Filters: filters, DisableKubernetesFilter: true})
+	require.NoError(t, err)
+	require.Contains(t, conf, `
+[FILTER]
+    Name         grep
+    Match  *
+    Regex  level ^error$
+    Regex  log timeout
+`)
+}
+
+func TestConfigSecretManagedTLS(t *testing.T) {
+	tests := map[string]struct {
+		fluentd    bool
+		secretName string
+		want       string
+	}{
+		"fluentd with managed certificates": {
+			fluentd: true,
+			want: `
+    tls           On
+    tls.verify    On
+    tls.vhost     test-fluentd.logging.svc.cluster.local
+    tls.ca_file   /fluent-bit/tls/ca.crt
+`,
+		},
+		"fluentd with own certificates": {
+			fluentd:    true,
+			secretName: "agent-tls",
+			want: `
+    tls           On
+    tls.verify    Off
+    tls.ca_file   /fluent-bit/tls/ca.crt
+`,
+		},
+		"syslog-ng with managed certificates": {
+			want: `
+    Format json_lines
+    json_date_key ts
+    json_date_format iso8601
+    tls On
+    tls.verify On
+    tls.vhost test-syslog-ng.logging.svc.cluster.local
+    tls.ca_file /fluent-bit/tls/ca.crt
+    tls.crt_file /fluent-bit/tls/tls.crt
+    tls.key_file /fluent-bit/tls/tls.key
+`,
+		},
+		"syslog-ng with own certificates": {
+			secretName: "agent-tls",
+			want: `
+    tls On
+    tls.verify Off
+    tls.ca_file /fluent-bit/tls/ca.crt
+`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := newTestReconciler(t, v1beta1.FluentbitSpec{})
+			r.Logging.Spec.ManagedTLS = &v1beta1.ManagedTLS{}
+			if test.fluentd {
+				r.Logging.Spec.FluentdSpec = &v1beta1.FluentdSpec{}
+				r.loggingDataProvider = fluentd.NewDataProvider(r.resourceReconciler.Client, r.Logging)
+			} else {
+				r.Logging.Spec.SyslogNGSpec = &v1beta1.SyslogNGSpec{}
+			}
+			require.NoError(t, r.Logging.SetDefaults())
+			// the agent secret is defaulted by the reconciler
+			r.fluentbitSpec.TLS.Enabled = utils.BoolPointer(true)
+			r.fluentbitSpec.TLS.SecretName = r.Logging.ManagedTLSAgentSecretName()
+			if test.secretName != "" {
+				r.fluentbitSpec.TLS.SecretName = test.secretName
+			}
+
+			_, _, err := r.configSecret()
+			require.NoError(t, err)
+			require.Contains(t, string(r.configs[BaseConfigName]), test.want)
+		})
+	}
+}
@@ -15,14 +15,17 @@
 package fluentbit
 
 import (
+	"context"
 	"crypto/sha256"
 	"fmt"
 	"strconv"
 	"strings"
 
+	"emperror.dev/errors"
 	"github.com/cisco-open/operator-tools/pkg/reconciler"
 	util "github.com/cisco-open/operator-tools/pkg/utils"
 
+	"github.com/kube-logging/logging-operator/pkg/resources/pki"
 	"github.com/kube-logging/logging-operator/pkg/resources/templates"
 	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
 
@@ -30,6 +33,8 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
@@ -44,10 +49,11 @@ func (r *Reconciler) daemonSet() (runtime.Object, reconciler.DesiredState, error
 	meta.Annotations = util.MergeLabels(meta.Annotations, r.fluentbitSpec.DaemonSetAnnotations)
 	podMeta := metav1.ObjectMeta{
 		Labels:      labels,
-		Annotations: r.fluentbitSpec.Annotations,
+		Annotations: util.MergeLabels(r.fluentbitSpec.Annotations),
 	}
 
-	if r.configs != nil {
+	// the config changes are reloaded in place when hot reload is enabled, no need to roll the pods
+	if r.configs != nil && r.fluentbitSpec.ConfigHotReload == nil {
 		for key, config := range r.configs {
 			h := sha256.New()
 			_, _ = h.Write(config)
@@ -55,12 +61,34 @@ func (r *Reconciler) daemonSet() (runtime.Object, reconciler.DesiredState, error
 		}
 	}
 
+	// the lua scripts are not watched by the config reloader, roll the pods when they change
+	if r.hasLuaFilter() {
+		checksum, err := r.luaScriptChecksum(context.TODO())
+		if err != nil {
+			return nil, nil, err
+		}
+		podMeta = templates.Annotate(podMeta, LuaScriptChecksumAnnotation, checksum)
+	}
+
+	if *r.fluentbitSpec.TLS.Enabled {
+		checksum, err := pki.SecretChecksum(context.TODO(), r.resourceReconciler.Client, r.Logging, r.fluentbitSpec.TLS.SecretName)
+		if err != nil {
+			return nil, nil, err
+		}
+		if checksum != "" {
+			podMeta = templates.Annotate(podMeta, pki.ChecksumAnnotation, checksum)
+		}
+	}
+
 	containers := []corev1.Container{
 		*r.fluentbitContainer(),
 	}
 	if c := r.bufferMetricsSidecarContainer(); c != nil {
 		containers = append(containers, *c)
 	}
+	if c := r.configReloaderContainer(); c != nil {
+		containers = append(containers, *c)
+	}
 
 	desired := &appsv1.DaemonSet{
 		ObjectMeta: meta,
@@ -127,7 +155,7 @@ func (r *Reconciler) fluentbitContainer() *corev1.Container {
 		Command: []string{
 			StockBinPath, "-c", fmt.Sprintf("%s/%s", OperatorConfigPath, BaseConfigName),
 		},
-		Env:            r.fluentbitSpec.EnvVars,
+		Env:            append(append([]corev1.EnvVar{}, r.fluentbitSpec.EnvVars...), r.directOutputEnvVars()...),
 		LivenessProbe:  r.fluentbitSpec.LivenessProbe,
 		ReadinessProbe: r.fluentbitSpec.ReadinessProbe,
 	}
@@ -170,6 +198,26 @@ func (r *Reconciler) generateVolumeMounts() (v []corev1.VolumeMount) {
 		})
 	}
 
+	for i, f := range r.fluentbitSpec.Filters {
+		if f.Lua != nil {
+			v = append(v, corev1.VolumeMount{
+				Name:      luaScriptVolumeName(i),
+				ReadOnly:  true,
+				MountPath: luaScriptDir(i),
+			})
+		}
+	}
+
+	v = append(v, r.directOutputVolumeMounts()...)
+
+	if r.hasKmsgInput() {
+		v = append(v, corev1.VolumeMount{
+			Name:      "kmsg",
+			ReadOnly:  true,
+			MountPath: "/dev/kmsg",
+		})
+	}
+
 	if *r.fluentbitSpec.TLS.Enabled {
 		tlsRelatedVolume := []corev1.VolumeMount{
 			{
@@ -212,6 +260,38 @@ func (r *Reconciler) generateVolume() (v []corev1.Volume) {
 			}})
 	}
 
+	for i, f := range r.fluentbitSpec.Filters {
+		if f.Lua != nil {
+			v = append(v, corev1.Volume{
+				Name: luaScriptVolumeName(i),
+				VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: f.Lua.Script.LocalObjectReference,
+						Items: []corev1.KeyToPath{
+							{
+								Key:  f.Lua.Script.Key,
+								Path: f.Lua.Script.Key,
+							},
+						},
+					},
+				},
+			})
+		}
+	}
+
+	v = append(v, r.directOutputVolumes()...)
+
+	if r.hasKmsgInput() {
+		v = append(v, corev1.Volume{
+			Name: "kmsg",
+			VolumeSource: corev1.VolumeSource{
+				HostPath: &corev1.HostPathVolumeSource{
+					Path: "/dev/kmsg",
+				},
+			},
+		})
+	}
+
 	if r.fluentbitSpec.CustomConfigSecret == "" {
 		volume := corev1.Volume{
 			Name: "config",
@@ -221,12 +301,6 @@ func (r *Reconciler) generateVolume() (v []corev1.Volume) {
 				},
 			},
 		}
-		if r.fluentbitSpec.EnableUpstream {
-			volume.VolumeSource.Secret.Items = append(volume.VolumeSource.Secret.Items, corev1.KeyToPath{
-				Key:  UpstreamConfigName,
-				Path: UpstreamConfigName,
-			})
-		}
 		v = append(v, volume)
 	} else {
 		v = append(v, corev1.Volume{
@@ -252,6 +326,46 @@ func (r *Reconciler) generateVolume() (v []corev1.Volume) {
 	return
 }
 
+func luaScriptVolumeName(filterIndex int) string {
+	return fmt.Sprintf("lua-script-%d", filterIndex)
+}
+
+func (r *Reconciler) hasLuaFilter() bool {
+	for _, f := range r.fluentbitSpec.Filters {
+		if f.Lua != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// luaScriptChecksum returns the checksum of the lua scripts referenced by the lua filters
+func (r *Reconciler) luaScriptChecksum(ctx context.Context) (string, error) {
+	h := sha256.New()
+	for i, f := range r.fluentbitSpec.Filters {
+		if f.Lua == nil {
+			continue
+		}
+		cm := &corev1.ConfigMap{}
+		err := r.resourceReconciler.Client.Get(ctx, types.NamespacedName{Namespace: r.Logging.Spec.ControlNamespace, Name: f.Lua.Script.Name}, cm)
+		if client.IgnoreNotFound(err) != nil {
+			return "", errors.WrapIff(err, "failed to get lua script configmap %s", f.Lua.Script.Name)
+		}
+		_, _ = h.Write([]byte(luaScriptVolumeName(i)))
+		_, _ = h.Write([]byte(cm.Data[f.Lua.Script.Key]))
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
+
+func (r *Reconciler) hasKmsgInput() bool {
+	for _, in := range r.fluentbitSpec.AdditionalInputs {
+		if in.Kmsg != nil {
+			return true
+		}
+	}
+	return false
+}
+
 func (r *Reconciler) generatePortsBufferVolumeMetrics() []corev1.ContainerPort {
 	port := int32(defaultBufferVolumeMetricsPort)
 	if r.fluentbitSpec.Metrics != nil && r.fluentbitSpec.BufferVolumeMetrics.Port != 0 {
@@ -308,3 +422,46 @@ func (r *Reconciler) bufferMetricsSidecarContainer() *corev1.Container {
 	}
 	return nil
 }
+
+// httpPort is the port of the HTTP server of fluent-bit, it serves the metrics and the hot reload API
+func (r *Reconciler) httpPort() int32 {
+	if r.fluentbitSpec.Metrics != nil && r.fluentbitSpec.Metrics.Port != 0 {
+		return r.fluentbitSpec.Metrics.Port
+	}
+	return defaultHTTPPort
+}
+
+func (r *Reconciler) configReloaderContainer() *corev1.Container {
+	if r.fluentbitSpec.ConfigHotReload == nil {
+		return nil
+	}
+	c := &corev1.Container{
+		Name:            "config-reloader",
+		Image:           r.fluentbitSpec.ConfigHotReload.Image.RepositoryWithTag(),
+		ImagePullPolicy: corev1.PullPolicy(r.fluentbitSpec.ConfigHotReload.Image.PullPolicy),
+		Resources:       r.fluentbitSpec.ConfigHotReload.Resources,
+		Args: []string{
+			"--volume-dir=" + OperatorConfigPath,
+			fmt.Sprintf("--webhook-url=http://127.0.0.1:%d/api/v2/reload", r.httpPort()),
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      "config",
+				ReadOnly:  true,
+				MountPath: OperatorConfigPath,
+			},
+		},
+	}
+	if r.fluentbitSpec.Security.SecurityContext != nil {
+		c.SecurityContext = &corev1.SecurityContext{
+			RunAsUser:                r.fluentbitSpec.Security.SecurityContext.RunAsUser,
+			RunAsGroup:               r.fluentbitSpec.Security.SecurityContext.RunAsGroup,
+			ReadOnlyRootFilesystem:   r.fluentbitSpec.Security.SecurityContext.ReadOnlyRootFilesystem,
+			AllowPrivilegeEscalation: r.fluentbitSpec.Security.SecurityContext.AllowPrivilegeEscalation,
+			Privileged:               r.fluentbitSpec.Security.SecurityContext.Privileged,
+			RunAsNonRoot:             r.fluentbitSpec.Security.SecurityContext.RunAsNonRoot,
+			SELinuxOptions:           r.fluentbitSpec.Security.SecurityContext.SELinuxOptions,
+		}
+	}
+	return c
+}
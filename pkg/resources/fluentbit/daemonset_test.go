@@ -0,0 +1,151 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentbit
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/kube-logging/logging-operator/pkg/resources/kubetool"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+)
+
+func TestDaemonSetLuaScriptChecksum(t *testing.T) {
+	spec := v1beta1.FluentbitSpec{
+		Filters: []v1beta1.FluentbitFilter{
+			{Lua: &v1beta1.FilterLua{
+				Script: corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "scripts"},
+					Key:                  "filter.lua",
+				},
+				Call: "cb_filter",
+			}},
+		},
+	}
+	scripts := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "scripts", Namespace: "logging"},
+		Data: map[string]string{
+			"filter.lua": "function cb_filter(tag, ts, record) return 0, ts, record end",
+			"other.lua":  "-- not referenced",
+		},
+	}
+	r := newTestReconciler(t, spec, scripts)
+
+	checksum := func() string {
+		obj, _, err := r.daemonSet()
+		require.NoError(t, err)
+		return obj.(*appsv1.DaemonSet).Spec.Template.Annotations[LuaScriptChecksumAnnotation]
+	}
+
+	initial := checksum()
+	require.NotEmpty(t, initial)
+
+	scripts.Data["other.lua"] = "-- still not referenced"
+	require.NoError(t, r.resourceReconciler.Client.Update(context.TODO(), scripts))
+	require.Equal(t, initial, checksum())
+
+	scripts.Data["filter.lua"] = "function cb_filter(tag, ts, record) return -1, ts, record end"
+	require.NoError(t, r.resourceReconciler.Client.Update(context.TODO(), scripts))
+	require.NotEqual(t, initial, checksum())
+}
+
+func TestDaemonSetWithoutLuaFilter(t *testing.T) {
+	r := newTestReconciler(t, v1beta1.FluentbitSpec{})
+
+	obj, _, err := r.daemonSet()
+	require.NoError(t, err)
+	require.NotContains(t, obj.(*appsv1.DaemonSet).Spec.Template.Annotations, LuaScriptChecksumAnnotation)
+}
+
+func TestDaemonSetConfigHotReload(t *testing.T) {
+	tests := map[string]struct {
+		spec           v1beta1.FluentbitSpec
+		wantHotReload  bool
+		wantHTTPPort   int32
+		wantWebhookURL string
+	}{
+		"disabled": {
+			spec: v1beta1.FluentbitSpec{},
+		},
+		"enabled": {
+			spec:           v1beta1.FluentbitSpec{ConfigHotReload: &v1beta1.HotReload{}},
+			wantHotReload:  true,
+			wantHTTPPort:   defaultHTTPPort,
+			wantWebhookURL: "http://127.0.0.1:2020/api/v2/reload",
+		},
+		"enabled with metrics": {
+			spec: v1beta1.FluentbitSpec{
+				ConfigHotReload: &v1beta1.HotReload{},
+				Metrics:         &v1beta1.Metrics{Port: 2021},
+			},
+			wantHotReload:  true,
+			wantHTTPPort:   2021,
+			wantWebhookURL: "http://127.0.0.1:2021/api/v2/reload",
+		},
+		"enabled by the upstream discovery": {
+			spec: v1beta1.FluentbitSpec{
+				EnableUpstream:    true,
+				UpstreamDiscovery: &v1beta1.FluentbitUpstreamDiscovery{Source: v1beta1.UpstreamDiscoveryDNS},
+			},
+			wantHotReload:  true,
+			wantHTTPPort:   defaultHTTPPort,
+			wantWebhookURL: "http://127.0.0.1:2020/api/v2/reload",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test.spec.Annotations = map[string]string{"team": "logging"}
+			r := newTestReconciler(t, test.spec)
+			r.Logging.Spec.SyslogNGSpec = &v1beta1.SyslogNGSpec{}
+
+			_, _, err := r.configSecret()
+			require.NoError(t, err)
+			conf := string(r.configs[BaseConfigName])
+
+			obj, _, err := r.daemonSet()
+			require.NoError(t, err)
+			template := obj.(*appsv1.DaemonSet).Spec.Template
+			reloader := kubetool.FindContainerByName(template.Spec.Containers, "config-reloader")
+			require.Equal(t, map[string]string{"team": "logging"}, r.fluentbitSpec.Annotations)
+			require.Equal(t, "logging", template.Annotations["team"])
+
+			if !test.wantHotReload {
+				require.NotContains(t, conf, "Hot_Reload")
+				require.NotContains(t, conf, "HTTP_Server")
+				require.Contains(t, template.Annotations, "checksum/"+BaseConfigName)
+				require.Nil(t, reloader)
+				return
+			}
+
+			require.Contains(t, conf, "\n    Hot_Reload   On\n")
+			require.Contains(t, conf, fmt.Sprintf("\n    HTTP_Server  On\n    HTTP_Listen  0.0.0.0\n    HTTP_Port    %d\n", test.wantHTTPPort))
+			// the config changes are reloaded in place, they must not roll the pods
+			for key := range template.Annotations {
+				require.NotContains(t, key, "checksum/")
+			}
+
+			require.NotNil(t, reloader)
+			require.Equal(t, []string{"--volume-dir=" + OperatorConfigPath, "--webhook-url=" + test.wantWebhookURL}, reloader.Args)
+			require.Equal(t, []corev1.VolumeMount{{Name: "config", ReadOnly: true, MountPath: OperatorConfigPath}}, reloader.VolumeMounts)
+			require.Equal(t, v1beta1.DefaultFluentbitConfigReloaderImageRepository+":"+v1beta1.DefaultFluentbitConfigReloaderImageTag, reloader.Image)
+		})
+	}
+}
@@ -0,0 +1,344 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentbit
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"emperror.dev/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/types"
+)
+
+const (
+	DirectOutputsPath = "/fluent-bit/direct-outputs"
+
+	directOutputTagPrefix = "direct."
+	// directOutputKey is set by the routing filter to the name of the direct output of the record
+	directOutputKey = "_direct_output"
+)
+
+type fluentbitDirectOutputConfig struct {
+	Name   string
+	Match  string
+	Values map[string]string
+	Params []fluentbitParam
+}
+
+// directOutputRoutingConfig retags the records of the direct outputs, so they are excluded from the aggregator output
+type directOutputRoutingConfig struct {
+	Match              string
+	Code               string
+	Rule               string
+	Key                string
+	EmitterStorageType string
+}
+
+func (r *Reconciler) directOutputs(mapper *types.StructToStringMapper) ([]fluentbitDirectOutputConfig, error) {
+	var outputs []fluentbitDirectOutputConfig
+	names := make(map[string]bool)
+	for i, o := range r.fluentbitSpec.DirectOutputs {
+		if names[o.Name] {
+			return nil, errors.Errorf("duplicate direct output name %q", o.Name)
+		}
+		names[o.Name] = true
+		if len(o.Namespaces) == 0 && len(o.Labels) == 0 {
+			return nil, errors.Errorf("namespaces or labels have to be set for the direct output %q", o.Name)
+		}
+
+		config := fluentbitDirectOutputConfig{
+			Match: directOutputTagPrefix + o.Name,
+		}
+		var values any
+		switch {
+		case directOutputTypeCount(o) != 1:
+			return nil, errors.Errorf("exactly one output type has to be set for the direct output %q", o.Name)
+		case o.Loki != nil:
+			config.Name = "loki"
+			loki := *o.Loki
+			config.Params = appendJoinedParam(config.Params, "labels", loki.Labels)
+			config.Params = appendJoinedParam(config.Params, "label_keys", loki.LabelKeys)
+			config.Params = appendJoinedParam(config.Params, "remove_keys", loki.RemoveKeys)
+			if loki.HTTPPasswd != nil {
+				config.Params = append(config.Params, fluentbitParam{Key: "http_passwd", Value: envVarReference(directOutputEnvVarName(i, "HTTP_PASSWD"))})
+			}
+			config.Params = append(config.Params, directOutputTLSParams(o)...)
+			values = loki
+		case o.S3 != nil:
+			if o.TLS != nil {
+				return nil, errors.Errorf("tls is not supported by the s3 direct output %q", o.Name)
+			}
+			config.Name = "s3"
+			values = o.S3
+		case o.Elasticsearch != nil:
+			config.Name = "es"
+			if o.Elasticsearch.HTTPPasswd != nil {
+				config.Params = append(config.Params, fluentbitParam{Key: "HTTP_Passwd", Value: envVarReference(directOutputEnvVarName(i, "HTTP_PASSWD"))})
+			}
+			config.Params = append(config.Params, directOutputTLSParams(o)...)
+			values = o.Elasticsearch
+		case o.HTTP != nil:
+			config.Name = "http"
+			for _, header := range o.HTTP.Header {
+				config.Params = append(config.Params, fluentbitParam{Key: "header", Value: header})
+			}
+			if o.HTTP.HTTPPasswd != nil {
+				config.Params = append(config.Params, fluentbitParam{Key: "http_passwd", Value: envVarReference(directOutputEnvVarName(i, "HTTP_PASSWD"))})
+			}
+			config.Params = append(config.Params, directOutputTLSParams(o)...)
+			values = o.HTTP
+		case o.Kafka != nil:
+			config.Name = "kafka"
+			kafka := o.Kafka
+			keys := make([]string, 0, len(kafka.RDKafkaOptions))
+			for key := range kafka.RDKafkaOptions {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				config.Params = append(config.Params, fluentbitParam{Key: "rdkafka." + key, Value: kafka.RDKafkaOptions[key]})
+			}
+			if kafka.SASLUsername != "" {
+				config.Params = append(config.Params, fluentbitParam{Key: "rdkafka.sasl.username", Value: kafka.SASLUsername})
+			}
+			if kafka.SASLPassword != nil {
+				config.Params = append(config.Params, fluentbitParam{Key: "rdkafka.sasl.password", Value: envVarReference(directOutputEnvVarName(i, "SASL_PASSWORD"))})
+			}
+			config.Params = append(config.Params, kafkaTLSParams(o)...)
+			values = kafka
+		}
+
+		var err error
+		config.Values, err = mapper.StringsMap(values)
+		if err != nil {
+			return nil, errors.WrapIff(err, "failed to map the direct output %q for fluentbit", o.Name)
+		}
+		if o.RetryLimit != "" {
+			config.Values["Retry_Limit"] = o.RetryLimit
+		}
+		if o.Workers != nil {
+			config.Values["Workers"] = strconv.Itoa(*o.Workers)
+		}
+		outputs = append(outputs, config)
+	}
+	return outputs, nil
+}
+
+func (r *Reconciler) directOutputRouting(match string) *directOutputRoutingConfig {
+	routing := &directOutputRoutingConfig{
+		Match: match,
+		Code:  directOutputRoutingCode(r.fluentbitSpec.DirectOutputs),
+		Rule:  fmt.Sprintf("$%s ^(.+)$ %s$1 false", directOutputKey, directOutputTagPrefix),
+		Key:   directOutputKey,
+	}
+	if r.fluentbitSpec.BufferStorage.StoragePath != "" {
+		routing.EmitterStorageType = "filesystem"
+	}
+	return routing
+}
+
+// routedNamespaces returns the namespaces of the container logs that the flows or the direct outputs can match,
+// the namespace filter is rendered before the routing of the direct outputs
+func (r *Reconciler) routedNamespaces() ([]string, bool) {
+	namespaces, all := r.loggingResources.RoutedNamespaces()
+	if all {
+		return nil, true
+	}
+	set := make(map[string]bool)
+	for _, ns := range namespaces {
+		set[ns] = true
+	}
+	for _, o := range r.fluentbitSpec.DirectOutputs {
+		// the direct outputs selecting by labels only match the pods of any namespace
+		if len(o.Namespaces) == 0 {
+			return nil, true
+		}
+		for _, ns := range o.Namespaces {
+			set[ns] = true
+		}
+	}
+	namespaces = namespaces[:0]
+	for ns := range set {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+	return namespaces, false
+}
+
+// directOutputRoutingCode generates an inline lua function that sets the name of the first matching direct output on the records
+func directOutputRoutingCode(outputs []v1beta1.FluentbitDirectOutput) string {
+	var b strings.Builder
+	b.WriteString(`function direct_output(tag, timestamp, record) local k = record["kubernetes"] if type(k) ~= "table" then return 0, timestamp, record end local labels = k["labels"] or {}`)
+	for _, o := range outputs {
+		var conditions []string
+		if len(o.Namespaces) > 0 {
+			namespaces := make([]string, 0, len(o.Namespaces))
+			for _, ns := range o.Namespaces {
+				namespaces = append(namespaces, fmt.Sprintf(`k["namespace_name"] == %s`, strconv.Quote(ns)))
+			}
+			conditions = append(conditions, "("+strings.Join(namespaces, " or ")+")")
+		}
+		keys := make([]string, 0, len(o.Labels))
+		for key := range o.Labels {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			conditions = append(conditions, fmt.Sprintf(`labels[%s] == %s`, strconv.Quote(key), strconv.Quote(o.Labels[key])))
+		}
+		fmt.Fprintf(&b, ` if %s then record[%s] = %s return 2, timestamp, record end`,
+			strings.Join(conditions, " and "), strconv.Quote(directOutputKey), strconv.Quote(o.Name))
+	}
+	b.WriteString(` return 0, timestamp, record end`)
+	return b.String()
+}
+
+func directOutputTypeCount(o v1beta1.FluentbitDirectOutput) (count int) {
+	for _, set := range []bool{o.Loki != nil, o.S3 != nil, o.Elasticsearch != nil, o.HTTP != nil, o.Kafka != nil} {
+		if set {
+			count++
+		}
+	}
+	return
+}
+
+func appendJoinedParam(params []fluentbitParam, key string, values []string) []fluentbitParam {
+	if len(values) == 0 {
+		return params
+	}
+	return append(params, fluentbitParam{Key: key, Value: strings.Join(values, ",")})
+}
+
+func directOutputTLSParams(o v1beta1.FluentbitDirectOutput) []fluentbitParam {
+	if o.TLS == nil {
+		return nil
+	}
+	params := []fluentbitParam{
+		{Key: "tls", Value: "On"},
+		{Key: "tls.verify", Value: onOff(o.TLS.Verify == nil || *o.TLS.Verify)},
+	}
+	if o.TLS.SecretName != "" {
+		params = append(params, fluentbitParam{Key: "tls.ca_file", Value: directOutputTLSDir(o.Name) + "/ca.crt"})
+		if o.TLS.ClientAuth {
+			params = append(params,
+				fluentbitParam{Key: "tls.crt_file", Value: directOutputTLSDir(o.Name) + "/tls.crt"},
+				fluentbitParam{Key: "tls.key_file", Value: directOutputTLSDir(o.Name) + "/tls.key"},
+			)
+		}
+	}
+	return params
+}
+
+// kafkaTLSParams configures TLS through the librdkafka properties, the security protocol is left to the rdkafka options
+func kafkaTLSParams(o v1beta1.FluentbitDirectOutput) []fluentbitParam {
+	if o.TLS == nil {
+		return nil
+	}
+	params := []fluentbitParam{
+		{Key: "rdkafka.enable.ssl.certificate.verification", Value: strconv.FormatBool(o.TLS.Verify == nil || *o.TLS.Verify)},
+	}
+	if o.TLS.SecretName != "" {
+		params = append(params, fluentbitParam{Key: "rdkafka.ssl.ca.location", Value: directOutputTLSDir(o.Name) + "/ca.crt"})
+		if o.TLS.ClientAuth {
+			params = append(params,
+				fluentbitParam{Key: "rdkafka.ssl.certificate.location", Value: directOutputTLSDir(o.Name) + "/tls.crt"},
+				fluentbitParam{Key: "rdkafka.ssl.key.location", Value: directOutputTLSDir(o.Name) + "/tls.key"},
+			)
+		}
+	}
+	return params
+}
+
+func onOff(b bool) string {
+	if b {
+		return "On"
+	}
+	return "Off"
+}
+
+func directOutputTLSDir(name string) string {
+	return fmt.Sprintf("%s/%s/tls", DirectOutputsPath, name)
+}
+
+func directOutputTLSVolumeName(name string) string {
+	return fmt.Sprintf("direct-output-%s-tls", name)
+}
+
+func directOutputEnvVarName(index int, suffix string) string {
+	return fmt.Sprintf("FLUENTBIT_DIRECT_OUTPUT_%d_%s", index, suffix)
+}
+
+func envVarReference(name string) string {
+	return fmt.Sprintf("${%s}", name)
+}
+
+// directOutputEnvVars loads the credentials of the direct outputs from secrets, the config references them as environment variables
+func (r *Reconciler) directOutputEnvVars() (env []corev1.EnvVar) {
+	secretEnv := func(name string, selector *corev1.SecretKeySelector) {
+		if selector != nil {
+			env = append(env, corev1.EnvVar{
+				Name:      name,
+				ValueFrom: &corev1.EnvVarSource{SecretKeyRef: selector},
+			})
+		}
+	}
+	for i, o := range r.fluentbitSpec.DirectOutputs {
+		switch {
+		case o.Loki != nil:
+			secretEnv(directOutputEnvVarName(i, "HTTP_PASSWD"), o.Loki.HTTPPasswd)
+		case o.Elasticsearch != nil:
+			secretEnv(directOutputEnvVarName(i, "HTTP_PASSWD"), o.Elasticsearch.HTTPPasswd)
+		case o.HTTP != nil:
+			secretEnv(directOutputEnvVarName(i, "HTTP_PASSWD"), o.HTTP.HTTPPasswd)
+		case o.Kafka != nil:
+			secretEnv(directOutputEnvVarName(i, "SASL_PASSWORD"), o.Kafka.SASLPassword)
+		}
+	}
+	return
+}
+
+func (r *Reconciler) directOutputVolumes() (v []corev1.Volume) {
+	for _, o := range r.fluentbitSpec.DirectOutputs {
+		if o.TLS != nil && o.TLS.SecretName != "" {
+			v = append(v, corev1.Volume{
+				Name: directOutputTLSVolumeName(o.Name),
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: o.TLS.SecretName,
+					},
+				},
+			})
+		}
+	}
+	return
+}
+
+func (r *Reconciler) directOutputVolumeMounts() (v []corev1.VolumeMount) {
+	for _, o := range r.fluentbitSpec.DirectOutputs {
+		if o.TLS != nil && o.TLS.SecretName != "" {
+			v = append(v, corev1.VolumeMount{
+				Name:      directOutputTLSVolumeName(o.Name),
+				ReadOnly:  true,
+				MountPath: directOutputTLSDir(o.Name),
+			})
+		}
+	}
+	return
+}
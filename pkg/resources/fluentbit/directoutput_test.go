@@ -0,0 +1,215 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentbit
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kube-logging/logging-operator/pkg/resources/model"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/types"
+)
+
+func testDirectOutputs() []v1beta1.FluentbitDirectOutput {
+	return []v1beta1.FluentbitDirectOutput{
+		{
+			Name:       "audit",
+			Namespaces: []string{"audit", "security"},
+			TLS:        &v1beta1.FluentbitDirectOutputTLS{SecretName: "loki-tls"},
+			Loki: &v1beta1.DirectOutputLoki{
+				Host:   "loki.monitoring.svc",
+				Port:   3100,
+				Labels: []string{"job=fluent-bit"},
+				HTTPPasswd: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "loki-auth"},
+					Key:                  "password",
+				},
+			},
+		},
+		{
+			Name:   "billing",
+			Labels: map[string]string{"app": "billing", "tier": "backend"},
+			Kafka: &v1beta1.DirectOutputKafka{
+				Brokers: "kafka:9092",
+				Topics:  "billing",
+			},
+		},
+	}
+}
+
+func TestGenerateConfigDirectOutputs(t *testing.T) {
+	r := newTestReconciler(t, v1beta1.FluentbitSpec{DirectOutputs: testDirectOutputs()})
+	outputs, err := r.directOutputs(types.NewStructToStringMapper(nil))
+	require.NoError(t, err)
+
+	conf, err := generateConfig(fluentBitConfig{
+		KubernetesFilter:    map[string]string{"Match": "kubernetes.*"},
+		NamespaceFilter:     &namespaceFilterConfig{Match: "kubernetes.*", Regex: "^(app|audit|security)$"},
+		DirectOutputRouting: r.directOutputRouting("kubernetes.*"),
+		DirectOutputs:       outputs,
+		FluentForwardOutput: &fluentForwardOutputConfig{TargetHost: "test-fluentd.logging.svc.cluster.local", TargetPort: 24240},
+	})
+	require.NoError(t, err)
+	require.Contains(t, conf, `[FILTER]
+    Name        kubernetes
+    Match  kubernetes.*
+
+[FILTER]
+    Name        grep
+    Match       kubernetes.*
+    Regex       $kubernetes['namespace_name'] ^(app|audit|security)$
+
+[FILTER]
+    Name         lua
+    Match        kubernetes.*
+    call         direct_output
+    code         function direct_output(tag, timestamp, record) local k = record["kubernetes"] if type(k) ~= "table" then return 0, timestamp, record end local labels = k["labels"] or {} if (k["namespace_name"] == "audit" or k["namespace_name"] == "security") then record["_direct_output"] = "audit" return 2, timestamp, record end if labels["app"] == "billing" and labels["tier"] == "backend" then record["_direct_output"] = "billing" return 2, timestamp, record end return 0, timestamp, record end
+
+[FILTER]
+    Name         rewrite_tag
+    Match        kubernetes.*
+    Rule         $_direct_output ^(.+)$ direct.$1 false
+    Emitter_Name direct-outputs
+    Emitter_Storage.type filesystem
+
+[FILTER]
+    Name         modify
+    Match        direct.*
+    Remove       _direct_output
+
+[OUTPUT]
+    Name         loki
+    Match        direct.audit
+    host  loki.monitoring.svc
+    port  3100
+    labels  job=fluent-bit
+    http_passwd  ${FLUENTBIT_DIRECT_OUTPUT_0_HTTP_PASSWD}
+    tls  On
+    tls.verify  On
+    tls.ca_file  /fluent-bit/direct-outputs/audit/tls/ca.crt
+
+[OUTPUT]
+    Name         kafka
+    Match        direct.billing
+    brokers  kafka:9092
+    topics  billing
+[OUTPUT]
+    Name          forward
+    Match_Regex   ^(?!direct\.).*
+    Host          test-fluentd.logging.svc.cluster.local
+    Port          24240
+`)
+}
+
+func TestGenerateConfigWithoutDirectOutputs(t *testing.T) {
+	conf, err := generateConfig(fluentBitConfig{
+		KubernetesFilter: map[string]string{"Match": "kubernetes.*"},
+		SyslogNGOutput:   &syslogNGOutputConfig{Host: "test-syslog-ng.logging.svc.cluster.local", Port: 601},
+	})
+	require.NoError(t, err)
+	require.NotContains(t, conf, "direct")
+	require.Contains(t, conf, `
+[OUTPUT]
+    Name tcp
+    Match *
+    Host test-syslog-ng.logging.svc.cluster.local
+    Port 601
+`)
+}
+
+func TestConfigSecretNamespaceFilter(t *testing.T) {
+	flows := []v1beta1.SyslogNGFlow{
+		{ObjectMeta: metav1.ObjectMeta{Name: "flow", Namespace: "app"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "flow", Namespace: "audit"}},
+	}
+	tests := map[string]struct {
+		directOutputs []v1beta1.FluentbitDirectOutput
+		wantRegex     string
+	}{
+		"flows only": {
+			wantRegex: "^(app|audit)$",
+		},
+		"namespaces of the direct outputs": {
+			directOutputs: []v1beta1.FluentbitDirectOutput{
+				{Name: "audit", Namespaces: []string{"audit", "security"}, S3: &v1beta1.DirectOutputS3{Bucket: "audit", Region: "eu-west-1"}},
+			},
+			wantRegex: "^(app|audit|security)$",
+		},
+		"direct output selecting by labels": {
+			directOutputs: testDirectOutputs(),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := newTestReconciler(t, v1beta1.FluentbitSpec{
+				DropUnmatchedNamespaces: true,
+				DirectOutputs:           test.directOutputs,
+			})
+			r.Logging.Spec.SyslogNGSpec = &v1beta1.SyslogNGSpec{}
+			r.loggingResources = model.LoggingResources{
+				Logging:  *r.Logging,
+				SyslogNG: model.SyslogNGLoggingResources{Flows: flows},
+			}
+
+			_, _, err := r.configSecret()
+			require.NoError(t, err)
+			conf := string(r.configs[BaseConfigName])
+			if test.wantRegex == "" {
+				require.NotContains(t, conf, "Name        grep")
+				return
+			}
+			require.Contains(t, conf, `
+[FILTER]
+    Name        grep
+    Match       kubernetes.*
+    Regex       $kubernetes['namespace_name'] `+test.wantRegex+`
+`)
+		})
+	}
+}
+
+// The records of the direct outputs are re-emitted at the start of the pipeline with the direct.<name> tag,
+// the filters matching every record run after the routing, so they process each record once
+func TestConfigSecretDirectOutputRoutingBeforeFilters(t *testing.T) {
+	r := newTestReconciler(t, v1beta1.FluentbitSpec{
+		DirectOutputs: testDirectOutputs(),
+		FilterModify: []v1beta1.FilterModify{{
+			Rules: []v1beta1.FilterModifyRule{{Set: &v1beta1.FilterKeyValue{Key: "cluster", Value: "prod"}}},
+		}},
+		Filters: []v1beta1.FluentbitFilter{
+			{Grep: &v1beta1.FilterGrep{Exclude: []string{"log ^DEBUG"}}},
+		},
+	})
+	r.Logging.Spec.SyslogNGSpec = &v1beta1.SyslogNGSpec{}
+
+	_, _, err := r.configSecret()
+	require.NoError(t, err)
+	conf := string(r.configs[BaseConfigName])
+
+	routing := strings.Index(conf, "    Remove       _direct_output\n")
+	require.Positive(t, routing)
+	for _, filter := range []string{
+		"    Name modify\n    Match *\n    Set cluster prod\n",
+		"    Name         grep\n    Match  *\n    Exclude  log ^DEBUG\n",
+	} {
+		require.Equal(t, 1, strings.Count(conf, filter), filter)
+		require.Greater(t, strings.Index(conf, filter), routing, filter)
+	}
+}
@@ -15,11 +15,14 @@
 package fluentbit
 
 import (
+	"context"
+
 	"emperror.dev/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/kube-logging/logging-operator/pkg/resources/loggingdataprovider"
+	"github.com/kube-logging/logging-operator/pkg/resources/model"
 
 	"github.com/cisco-open/operator-tools/pkg/reconciler"
 	util "github.com/cisco-open/operator-tools/pkg/utils"
@@ -29,7 +32,9 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/kube-logging/logging-operator/pkg/resources"
 	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
@@ -45,6 +50,9 @@ const (
 	fluentbitServiceName           = "fluentbit"
 	containerName                  = "fluent-bit"
 	defaultBufferVolumeMetricsPort = 9200
+	defaultHTTPPort                = 2020
+	// LuaScriptChecksumAnnotation is set on the pod template to roll the pods when a lua script changes
+	LuaScriptChecksumAnnotation = "checksum/lua-scripts"
 )
 
 func generateLoggingRefLabels(loggingRef string) map[string]string {
@@ -89,8 +97,10 @@ type Reconciler struct {
 	Logging             *v1beta1.Logging
 	configs             map[string][]byte
 	fluentbitSpec       *v1beta1.FluentbitSpec
+	fluentbitAgent      *v1beta1.FluentbitAgent
 	loggingDataProvider loggingdataprovider.LoggingDataProvider
 	nameProvider        NameProvider
+	loggingResources    model.LoggingResources
 }
 
 // NewReconciler creates a new FluentbitAgent reconciler
@@ -100,7 +110,9 @@ func New(client client.Client,
 	opts reconciler.ReconcilerOpts,
 	fluentbitSpec *v1beta1.FluentbitSpec,
 	loggingDataProvider loggingdataprovider.LoggingDataProvider,
-	nameProvider NameProvider) *Reconciler {
+	nameProvider NameProvider,
+	loggingResources model.LoggingResources,
+	fluentbitAgent *v1beta1.FluentbitAgent) *Reconciler {
 	return &Reconciler{
 		Logging:             logging,
 		logger:              logger,
@@ -108,6 +120,8 @@ func New(client client.Client,
 		fluentbitSpec:       fluentbitSpec,
 		loggingDataProvider: loggingDataProvider,
 		nameProvider:        nameProvider,
+		loggingResources:    loggingResources,
+		fluentbitAgent:      fluentbitAgent,
 	}
 }
 
@@ -116,14 +130,30 @@ func (r *Reconciler) Reconcile() (*reconcile.Result, error) {
 	if err := v1beta1.FluentBitDefaults(r.fluentbitSpec); err != nil {
 		return nil, err
 	}
+	if r.Logging.Spec.ManagedTLS != nil && r.fluentbitSpec.TLS.SecretName == "" {
+		r.fluentbitSpec.TLS.Enabled = util.BoolPointer(true)
+		r.fluentbitSpec.TLS.SecretName = r.Logging.ManagedTLSAgentSecretName()
+	}
 
-	for _, factory := range []resources.Resource{
+	result, err := r.reconcileResources(
 		r.serviceAccount,
 		r.clusterRole,
 		r.clusterRoleBinding,
 		r.clusterPodSecurityPolicy,
 		r.pspClusterRole,
 		r.pspClusterRoleBinding,
+	)
+	if result != nil || err != nil {
+		return result, err
+	}
+
+	// Validate the config before it is rolled out to the DaemonSet
+	result, err = r.checkConfig(context.TODO())
+	if result != nil || err != nil {
+		return result, err
+	}
+
+	result, err = r.reconcileResources(
 		r.configSecret,
 		r.daemonSet,
 		r.serviceMetrics,
@@ -132,7 +162,20 @@ func (r *Reconciler) Reconcile() (*reconcile.Result, error) {
 		r.monitorBufferServiceMetrics,
 		r.prometheusRules,
 		r.bufferVolumePrometheusRules,
-	} {
+	)
+	if result != nil || err != nil {
+		return result, err
+	}
+
+	if err := r.updateStatus(context.TODO()); err != nil {
+		return nil, errors.WrapIf(err, "failed to update fluentbitagent status")
+	}
+
+	return nil, nil
+}
+
+func (r *Reconciler) reconcileResources(factories ...resources.Resource) (*reconcile.Result, error) {
+	for _, factory := range factories {
 		o, state, err := factory()
 		if err != nil {
 			return nil, errors.WrapIf(err, "failed to create desired object")
@@ -153,11 +196,14 @@ func (r *Reconciler) Reconcile() (*reconcile.Result, error) {
 	return nil, nil
 }
 
-func RegisterWatches(builder *builder.Builder) *builder.Builder {
-	return builder.
+func RegisterWatches(b *builder.Builder) *builder.Builder {
+	return b.
 		Owns(&corev1.ConfigMap{}).
 		Owns(&appsv1.DaemonSet{}).
 		Owns(&rbacv1.ClusterRole{}).
 		Owns(&rbacv1.ClusterRoleBinding{}).
-		Owns(&corev1.ServiceAccount{})
+		Owns(&corev1.ServiceAccount{}).
+		// refresh the FluentbitAgent status when the agents roll out or start crash-looping
+		Watches(&source.Kind{Type: &appsv1.DaemonSet{}}, handler.EnqueueRequestsFromMapFunc(agentRequestMapper), builder.WithPredicates(agentStatusChanged)).
+		Watches(&source.Kind{Type: &corev1.Pod{}}, handler.EnqueueRequestsFromMapFunc(agentRequestMapper), builder.WithPredicates(agentStatusChanged))
 }
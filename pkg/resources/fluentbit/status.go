@@ -0,0 +1,243 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentbit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"emperror.dev/errors"
+	util "github.com/cisco-open/operator-tools/pkg/utils"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+)
+
+const (
+	// storageMetricsTimeout bounds the scraping of all the agents, the reconcile is not blocked for longer
+	storageMetricsTimeout = 2 * time.Second
+	// storageMetricsConcurrency is the maximum number of agents scraped in parallel
+	storageMetricsConcurrency = 16
+)
+
+// storageMetrics is the response of the /api/v1/storage endpoint of fluent-bit
+type storageMetrics struct {
+	StorageLayer struct {
+		Chunks struct {
+			TotalChunks  int64 `json:"total_chunks"`
+			FsChunks     int64 `json:"fs_chunks"`
+			FsChunksDown int64 `json:"fs_chunks_down"`
+		} `json:"chunks"`
+	} `json:"storage_layer"`
+}
+
+// updateStatus records the rollout and health information of the agents in the FluentbitAgent status
+func (r *Reconciler) updateStatus(ctx context.Context) error {
+	if r.fluentbitAgent == nil {
+		return nil
+	}
+	c := r.resourceReconciler.Client
+
+	status := r.fluentbitAgent.Status.DeepCopy()
+
+	status.ConfigHash = ""
+	if r.configs != nil && r.fluentbitSpec.CustomConfigSecret == "" {
+		hash, err := r.configHash(r.configs)
+		if err != nil {
+			return err
+		}
+		status.ConfigHash = hash
+	}
+
+	daemonSet := &appsv1.DaemonSet{}
+	err := c.Get(ctx, types.NamespacedName{
+		Namespace: r.Logging.Spec.ControlNamespace,
+		Name:      r.nameProvider.ComponentName(fluentbitDaemonSetName),
+	}, daemonSet)
+	if client.IgnoreNotFound(err) != nil {
+		return errors.WrapIf(err, "failed to get fluentbit daemonset")
+	}
+	status.DesiredNumberScheduled = daemonSet.Status.DesiredNumberScheduled
+	status.NumberReady = daemonSet.Status.NumberReady
+	status.UpdatedNumberScheduled = daemonSet.Status.UpdatedNumberScheduled
+
+	pods := &corev1.PodList{}
+	if err := c.List(ctx, pods,
+		client.InNamespace(r.Logging.Spec.ControlNamespace),
+		client.MatchingLabels(util.MergeLabels(r.fluentbitSpec.Labels, r.getFluentBitLabels()))); err != nil {
+		return errors.WrapIf(err, "failed to list fluentbit pods")
+	}
+
+	status.CrashLoopingNodes = nil
+	for _, pod := range pods.Items {
+		if isCrashLooping(pod) {
+			status.CrashLoopingNodes = append(status.CrashLoopingNodes, pod.Spec.NodeName)
+		}
+	}
+	sort.Strings(status.CrashLoopingNodes)
+
+	status.StorageBacklog = nil
+	if r.fluentbitSpec.Metrics != nil {
+		status.StorageBacklog = r.storageBacklog(ctx, pods.Items)
+	}
+
+	// the chunk counts change on every scrape, they are only refreshed together with the other fields
+	if equality.Semantic.DeepEqual(withoutChunkCounts(status), withoutChunkCounts(&r.fluentbitAgent.Status)) {
+		return nil
+	}
+
+	patchBase := client.MergeFrom(r.fluentbitAgent.DeepCopy())
+	r.fluentbitAgent.Status = *status
+	if err := c.Status().Patch(ctx, r.fluentbitAgent, patchBase); err != nil {
+		return errors.WrapWithDetails(err, "failed to patch status", "fluentbitagent", r.fluentbitAgent.Name)
+	}
+	return nil
+}
+
+// withoutChunkCounts returns a copy of the status that only keeps the backlogged nodes of the storage backlog
+func withoutChunkCounts(status *v1beta1.FluentbitStatus) *v1beta1.FluentbitStatus {
+	status = status.DeepCopy()
+	if status.StorageBacklog != nil {
+		status.StorageBacklog = &v1beta1.FluentbitStorageBacklog{BackloggedNodes: status.StorageBacklog.BackloggedNodes}
+	}
+	return status
+}
+
+// agentRequestMapper maps the DaemonSets and pods of the agents to the Logging they belong to.
+// Standalone FluentbitAgents own their DaemonSet, so the ownership watches of the Logging miss the rollout of their agents.
+func agentRequestMapper(o client.Object) []reconcile.Request {
+	labels := o.GetLabels()
+	if labels["app.kubernetes.io/name"] != "fluentbit" || labels["app.kubernetes.io/managed-by"] == "" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: labels["app.kubernetes.io/managed-by"]}}}
+}
+
+// agentStatusChanged passes the changes of the agents that are recorded in the FluentbitAgent status:
+// the status of the DaemonSets and the crash-looping state of the pods
+var agentStatusChanged = predicate.Funcs{
+	CreateFunc: func(event.CreateEvent) bool { return false },
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		switch o := e.ObjectOld.(type) {
+		case *appsv1.DaemonSet:
+			n, ok := e.ObjectNew.(*appsv1.DaemonSet)
+			return ok && !equality.Semantic.DeepEqual(o.Status, n.Status)
+		case *corev1.Pod:
+			n, ok := e.ObjectNew.(*corev1.Pod)
+			return ok && isCrashLooping(*o) != isCrashLooping(*n)
+		}
+		return false
+	},
+	DeleteFunc: func(e event.DeleteEvent) bool {
+		pod, ok := e.Object.(*corev1.Pod)
+		return ok && isCrashLooping(*pod)
+	},
+	GenericFunc: func(event.GenericEvent) bool { return false },
+}
+
+func isCrashLooping(pod corev1.Pod) bool {
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.State.Waiting != nil && cs.State.Waiting.Reason == "CrashLoopBackOff" {
+			return true
+		}
+	}
+	return false
+}
+
+// storageBacklog sums the storage metrics of the ready agents, agents that cannot be scraped in time are skipped
+func (r *Reconciler) storageBacklog(ctx context.Context, pods []corev1.Pod) *v1beta1.FluentbitStorageBacklog {
+	ctx, cancel := context.WithTimeout(ctx, storageMetricsTimeout)
+	defer cancel()
+
+	backlog := &v1beta1.FluentbitStorageBacklog{}
+	httpClient := &http.Client{}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, storageMetricsConcurrency)
+	for _, pod := range pods {
+		if pod.Status.PodIP == "" || !isReady(pod) {
+			continue
+		}
+		pod := pod
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				return
+			}
+			metrics, err := scrapeStorageMetrics(ctx, httpClient, fmt.Sprintf("http://%s:%d/api/v1/storage", pod.Status.PodIP, r.fluentbitSpec.Metrics.Port))
+			if err != nil {
+				r.logger.V(1).Info("failed to scrape storage metrics", "pod", pod.Name, "error", err.Error())
+				return
+			}
+			chunks := metrics.StorageLayer.Chunks
+			mu.Lock()
+			defer mu.Unlock()
+			backlog.TotalChunks += chunks.TotalChunks
+			backlog.FsChunks += chunks.FsChunks
+			backlog.FsChunksDown += chunks.FsChunksDown
+			if chunks.FsChunksDown > 0 {
+				backlog.BackloggedNodes = append(backlog.BackloggedNodes, pod.Spec.NodeName)
+			}
+		}()
+	}
+	wg.Wait()
+	sort.Strings(backlog.BackloggedNodes)
+	return backlog
+}
+
+func isReady(pod corev1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func scrapeStorageMetrics(ctx context.Context, httpClient *http.Client, url string) (*storageMetrics, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	metrics := &storageMetrics{}
+	if err := json.NewDecoder(resp.Body).Decode(metrics); err != nil {
+		return nil, errors.WrapIf(err, "failed to decode storage metrics")
+	}
+	return metrics, nil
+}
@@ -0,0 +1,255 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentbit
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	util "github.com/cisco-open/operator-tools/pkg/utils"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+)
+
+func TestIsCrashLooping(t *testing.T) {
+	tests := map[string]struct {
+		statuses []corev1.ContainerStatus
+		want     bool
+	}{
+		"no containers": {},
+		"running": {
+			statuses: []corev1.ContainerStatus{
+				{State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+			},
+		},
+		"waiting for the image": {
+			statuses: []corev1.ContainerStatus{
+				{State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}}},
+			},
+		},
+		"crash looping": {
+			statuses: []corev1.ContainerStatus{
+				{State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}},
+			},
+			want: true,
+		},
+		"crash looping sidecar": {
+			statuses: []corev1.ContainerStatus{
+				{State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+				{State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}},
+			},
+			want: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pod := corev1.Pod{Status: corev1.PodStatus{ContainerStatuses: test.statuses}}
+			require.Equal(t, test.want, isCrashLooping(pod))
+		})
+	}
+}
+
+func testAgentPod(r *Reconciler, name, node string, ready bool, waitingReason string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "logging",
+			Labels:    util.MergeLabels(r.fluentbitSpec.Labels, r.getFluentBitLabels()),
+		},
+		Spec: corev1.PodSpec{NodeName: node},
+		Status: corev1.PodStatus{
+			PodIP: "127.0.0.1",
+		},
+	}
+	if ready {
+		pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	}
+	if waitingReason != "" {
+		pod.Status.ContainerStatuses = []corev1.ContainerStatus{
+			{State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: waitingReason}}},
+		}
+	}
+	return pod
+}
+
+func storageMetricsServer(t *testing.T, handler http.HandlerFunc) int32 {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	_, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	require.NoError(t, err)
+	p, err := strconv.Atoi(port)
+	require.NoError(t, err)
+	return int32(p)
+}
+
+func TestUpdateStatus(t *testing.T) {
+	port := storageMetricsServer(t, func(w http.ResponseWriter, req *http.Request) {
+		require.Equal(t, "/api/v1/storage", req.URL.Path)
+		fmt.Fprint(w, `{"storage_layer":{"chunks":{"total_chunks":5,"fs_chunks":3,"fs_chunks_down":2}}}`)
+	})
+	r := newTestAgentReconciler(t, v1beta1.FluentbitSpec{Metrics: &v1beta1.Metrics{Port: port}})
+	ctx := context.TODO()
+	c := r.resourceReconciler.Client
+
+	daemonSet := &appsv1.DaemonSet{
+		ObjectMeta: r.FluentbitObjectMeta(fluentbitDaemonSetName),
+		Status: appsv1.DaemonSetStatus{
+			DesiredNumberScheduled: 3,
+			NumberReady:            2,
+			UpdatedNumberScheduled: 1,
+		},
+	}
+	require.NoError(t, c.Create(ctx, daemonSet))
+	for _, pod := range []*corev1.Pod{
+		testAgentPod(r, "agent-a", "node-b", true, ""),
+		testAgentPod(r, "agent-b", "node-a", true, ""),
+		testAgentPod(r, "agent-c", "node-d", false, "CrashLoopBackOff"),
+		testAgentPod(r, "agent-d", "node-c", false, "CrashLoopBackOff"),
+	} {
+		require.NoError(t, c.Create(ctx, pod))
+	}
+	// pods of other agents are not counted
+	other := testAgentPod(r, "other", "node-e", true, "CrashLoopBackOff")
+	other.Labels = map[string]string{"app.kubernetes.io/name": "other"}
+	require.NoError(t, c.Create(ctx, other))
+
+	_, _, err := r.configSecret()
+	require.NoError(t, err)
+	hash, err := r.configHash(r.configs)
+	require.NoError(t, err)
+
+	require.NoError(t, r.updateStatus(ctx))
+
+	status := r.fluentbitAgent.Status
+	require.Equal(t, hash, status.ConfigHash)
+	require.Equal(t, int32(3), status.DesiredNumberScheduled)
+	require.Equal(t, int32(2), status.NumberReady)
+	require.Equal(t, int32(1), status.UpdatedNumberScheduled)
+	require.Equal(t, []string{"node-c", "node-d"}, status.CrashLoopingNodes)
+	// only the ready agents are scraped
+	require.Equal(t, &v1beta1.FluentbitStorageBacklog{
+		TotalChunks:     10,
+		FsChunks:        6,
+		FsChunksDown:    4,
+		BackloggedNodes: []string{"node-a", "node-b"},
+	}, status.StorageBacklog)
+
+	stored := &v1beta1.FluentbitAgent{}
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(r.fluentbitAgent), stored))
+	require.Equal(t, status, stored.Status)
+}
+
+func TestUpdateStatusWithoutMetrics(t *testing.T) {
+	r := newTestAgentReconciler(t, v1beta1.FluentbitSpec{})
+
+	require.NoError(t, r.updateStatus(context.TODO()))
+	require.Nil(t, r.fluentbitAgent.Status.StorageBacklog)
+	require.Empty(t, r.fluentbitAgent.Status.CrashLoopingNodes)
+}
+
+func TestStorageBacklogDeadline(t *testing.T) {
+	port := storageMetricsServer(t, func(w http.ResponseWriter, req *http.Request) {
+		// never answers, the scraping has to give up
+		<-req.Context().Done()
+	})
+	r := newTestAgentReconciler(t, v1beta1.FluentbitSpec{Metrics: &v1beta1.Metrics{Port: port}})
+
+	var pods []corev1.Pod
+	for i := 0; i < 2*storageMetricsConcurrency; i++ {
+		pods = append(pods, *testAgentPod(r, fmt.Sprintf("agent-%d", i), fmt.Sprintf("node-%d", i), true, ""))
+	}
+
+	start := time.Now()
+	backlog := r.storageBacklog(context.TODO(), pods)
+	require.Less(t, time.Since(start), 2*storageMetricsTimeout)
+	require.Equal(t, &v1beta1.FluentbitStorageBacklog{}, backlog)
+}
+
+func TestUpdateStatusIgnoresChunkCounts(t *testing.T) {
+	chunksDown := 2
+	port := storageMetricsServer(t, func(w http.ResponseWriter, req *http.Request) {
+		fmt.Fprintf(w, `{"storage_layer":{"chunks":{"total_chunks":5,"fs_chunks":3,"fs_chunks_down":%d}}}`, chunksDown)
+	})
+	r := newTestAgentReconciler(t, v1beta1.FluentbitSpec{Metrics: &v1beta1.Metrics{Port: port}})
+	ctx := context.TODO()
+	c := r.resourceReconciler.Client
+	require.NoError(t, c.Create(ctx, testAgentPod(r, "agent-a", "node-a", true, "")))
+
+	stored := func() *v1beta1.FluentbitStorageBacklog {
+		agent := &v1beta1.FluentbitAgent{}
+		require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(r.fluentbitAgent), agent))
+		return agent.Status.StorageBacklog
+	}
+
+	require.NoError(t, r.updateStatus(ctx))
+	require.Equal(t, &v1beta1.FluentbitStorageBacklog{TotalChunks: 5, FsChunks: 3, FsChunksDown: 2, BackloggedNodes: []string{"node-a"}}, stored())
+
+	// the status is not patched when only the chunk counts change
+	chunksDown = 1
+	require.NoError(t, r.updateStatus(ctx))
+	require.Equal(t, int64(2), stored().FsChunksDown)
+
+	// the counts are refreshed when the backlogged nodes change
+	chunksDown = 0
+	require.NoError(t, r.updateStatus(ctx))
+	require.Equal(t, &v1beta1.FluentbitStorageBacklog{TotalChunks: 5, FsChunks: 3}, stored())
+}
+
+func TestAgentRequestMapper(t *testing.T) {
+	r := newTestAgentReconciler(t, v1beta1.FluentbitSpec{})
+
+	pod := testAgentPod(r, "agent-a", "node-a", true, "")
+	require.Equal(t, []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "test"}}}, agentRequestMapper(pod))
+
+	daemonSet := &appsv1.DaemonSet{ObjectMeta: r.FluentbitObjectMeta(fluentbitDaemonSetName)}
+	require.Equal(t, []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "test"}}}, agentRequestMapper(daemonSet))
+
+	other := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "fluentd-0", Labels: map[string]string{"app.kubernetes.io/name": "fluentd", "app.kubernetes.io/managed-by": "test"}}}
+	require.Empty(t, agentRequestMapper(other))
+}
+
+func TestAgentStatusChanged(t *testing.T) {
+	running := corev1.Pod{}
+	crashLooping := corev1.Pod{Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{
+		{State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}},
+	}}}
+	relabeled := running
+	relabeled.Labels = map[string]string{"a": "b"}
+	rolledOut := appsv1.DaemonSet{Status: appsv1.DaemonSetStatus{NumberReady: 1}}
+	respecified := appsv1.DaemonSet{Spec: appsv1.DaemonSetSpec{MinReadySeconds: 1}}
+
+	require.True(t, agentStatusChanged.Update(event.UpdateEvent{ObjectOld: &running, ObjectNew: &crashLooping}))
+	require.True(t, agentStatusChanged.Update(event.UpdateEvent{ObjectOld: &crashLooping, ObjectNew: &running}))
+	require.False(t, agentStatusChanged.Update(event.UpdateEvent{ObjectOld: &running, ObjectNew: &relabeled}))
+	require.True(t, agentStatusChanged.Update(event.UpdateEvent{ObjectOld: &appsv1.DaemonSet{}, ObjectNew: &rolledOut}))
+	require.False(t, agentStatusChanged.Update(event.UpdateEvent{ObjectOld: &appsv1.DaemonSet{}, ObjectNew: &respecified}))
+	require.True(t, agentStatusChanged.Delete(event.DeleteEvent{Object: &crashLooping}))
+	require.False(t, agentStatusChanged.Delete(event.DeleteEvent{Object: &running}))
+	require.False(t, agentStatusChanged.Create(event.CreateEvent{Object: &crashLooping}))
+}
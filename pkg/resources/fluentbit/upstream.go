@@ -0,0 +1,287 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentbit
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/cisco-open/operator-tools/pkg/utils"
+	"github.com/go-logr/logr"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/kube-logging/logging-operator/pkg/resources/fluentd"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+)
+
+const (
+	fluentdForwardPortName = "tcp-fluentd"
+	fluentdForwardPort     = 24240
+)
+
+// upstreamNodes returns the aggregator nodes of the upstream config. The discovered nodes are used if there are any,
+// otherwise one node is listed for each aggregator replica.
+func (r *Reconciler) upstreamNodes(ctx context.Context, replicas int32) ([]upstreamNode, error) {
+	if r.fluentbitSpec.UpstreamDiscovery != nil {
+		var nodes []upstreamNode
+		var err error
+		switch r.fluentbitSpec.UpstreamDiscovery.Source {
+		case v1beta1.UpstreamDiscoveryDNS:
+			nodes, err = r.discoverUpstreamNodesDNS(ctx)
+		default:
+			nodes, err = r.discoverUpstreamNodesEndpointSlices(ctx)
+		}
+		if err != nil {
+			return nil, errors.WrapIf(err, "discovering aggregator pods")
+		}
+		if len(nodes) > 0 {
+			sortUpstreamNodes(nodes)
+			return nodes, nil
+		}
+		r.logger.Info("no ready aggregator pods discovered, listing the aggregator replicas in the upstream config")
+	}
+
+	var nodes []upstreamNode
+	for i := int32(0); i < replicas; i++ {
+		nodes = append(nodes, r.generateUpstreamNode(i))
+	}
+	return nodes, nil
+}
+
+func (r *Reconciler) aggregatorHeadlessServiceName() string {
+	return r.Logging.QualifiedName(fluentd.ServiceName + "-headless")
+}
+
+// aggregatorPodHost returns the DNS name of an aggregator pod through the headless service
+func (r *Reconciler) aggregatorPodHost(podName string) string {
+	return fmt.Sprintf("%s.%s.%s.svc%s",
+		podName,
+		r.aggregatorHeadlessServiceName(),
+		r.Logging.Spec.ControlNamespace,
+		r.Logging.ClusterDomainAsSuffix())
+}
+
+// discoverUpstreamNodesEndpointSlices lists the ready endpoints of the headless service of the aggregator
+func (r *Reconciler) discoverUpstreamNodesEndpointSlices(ctx context.Context) ([]upstreamNode, error) {
+	slices := &discoveryv1.EndpointSliceList{}
+	if err := r.resourceReconciler.Client.List(ctx, slices,
+		client.InNamespace(r.Logging.Spec.ControlNamespace),
+		client.MatchingLabels{discoveryv1.LabelServiceName: r.aggregatorHeadlessServiceName()},
+	); err != nil {
+		return nil, err
+	}
+
+	var nodes []upstreamNode
+	for _, slice := range slices.Items {
+		port := fluentdForwardPort
+		for _, p := range slice.Ports {
+			if p.Name != nil && *p.Name == fluentdForwardPortName && p.Port != nil {
+				port = int(*p.Port)
+			}
+		}
+		for _, endpoint := range slice.Endpoints {
+			// a nil condition means ready, see the EndpointConditions API
+			if endpoint.Conditions.Ready != nil && !*endpoint.Conditions.Ready {
+				continue
+			}
+			if utils.PointerToBool(endpoint.Conditions.Terminating) {
+				continue
+			}
+			switch {
+			case endpoint.Hostname != nil:
+				nodes = append(nodes, upstreamNode{Name: *endpoint.Hostname, Host: r.aggregatorPodHost(*endpoint.Hostname), Port: port})
+			case endpoint.TargetRef != nil && len(endpoint.Addresses) > 0:
+				nodes = append(nodes, upstreamNode{Name: endpoint.TargetRef.Name, Host: endpoint.Addresses[0], Port: port})
+			}
+		}
+	}
+	return nodes, nil
+}
+
+// discoverUpstreamNodesDNS looks up the SRV records of the headless service of the aggregator
+func (r *Reconciler) discoverUpstreamNodesDNS(ctx context.Context) ([]upstreamNode, error) {
+	return lookupUpstreamNodesDNS(ctx, r.Logging)
+}
+
+// lookupUpstreamNodesDNS looks up the SRV records of the headless service of the aggregator,
+// the records only contain the ready pods
+func lookupUpstreamNodesDNS(ctx context.Context, logging *v1beta1.Logging) ([]upstreamNode, error) {
+	service := fmt.Sprintf("%s.%s.svc%s", logging.QualifiedName(fluentd.ServiceName+"-headless"), logging.Spec.ControlNamespace, logging.ClusterDomainAsSuffix())
+	_, records, err := net.DefaultResolver.LookupSRV(ctx, fluentdForwardPortName, "tcp", service)
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes []upstreamNode
+	for _, record := range records {
+		host := strings.TrimSuffix(record.Target, ".")
+		nodes = append(nodes, upstreamNode{
+			Name: strings.SplitN(host, ".", 2)[0],
+			Host: host,
+			Port: int(record.Port),
+		})
+	}
+	sortUpstreamNodes(nodes)
+	return nodes, nil
+}
+
+func sortUpstreamNodes(nodes []upstreamNode) {
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Name < nodes[j].Name
+	})
+}
+
+// upstreamDNSPollPeriod is the resolution of the refresh intervals of the DNS upstream discovery
+const upstreamDNSPollPeriod = 5 * time.Second
+
+// UpstreamDNSSource is a watch source for the upstream discovery through DNS. It looks up the SRV records of the
+// aggregators periodically, and only triggers the reconcile of a logging when its aggregator pods have changed.
+type UpstreamDNSSource struct {
+	client client.Reader
+	logger logr.Logger
+	lookup func(ctx context.Context, logging *v1beta1.Logging) ([]upstreamNode, error)
+
+	nodes      map[string][]upstreamNode
+	lastLookup map[string]time.Time
+}
+
+var _ source.Source = &UpstreamDNSSource{}
+
+func NewUpstreamDNSSource(client client.Reader, logger logr.Logger) *UpstreamDNSSource {
+	return &UpstreamDNSSource{
+		client:     client,
+		logger:     logger,
+		lookup:     lookupUpstreamNodesDNS,
+		nodes:      make(map[string][]upstreamNode),
+		lastLookup: make(map[string]time.Time),
+	}
+}
+
+// Start implements source.Source
+func (s *UpstreamDNSSource) Start(ctx context.Context, h handler.EventHandler, queue workqueue.RateLimitingInterface, prct ...predicate.Predicate) error {
+	go wait.UntilWithContext(ctx, func(ctx context.Context) {
+	loggings:
+		for _, logging := range s.changedLoggings(ctx, time.Now()) {
+			e := event.GenericEvent{Object: logging}
+			for _, p := range prct {
+				if !p.Generic(e) {
+					continue loggings
+				}
+			}
+			h.Generic(e, queue)
+		}
+	}, upstreamDNSPollPeriod)
+	return nil
+}
+
+// changedLoggings looks up the aggregator pods of the loggings whose refresh interval has passed,
+// and returns the loggings where the pods differ from the previous lookup
+func (s *UpstreamDNSSource) changedLoggings(ctx context.Context, now time.Time) []*v1beta1.Logging {
+	loggings := &v1beta1.LoggingList{}
+	if err := s.client.List(ctx, loggings); err != nil {
+		s.logger.Error(err, "failed to list loggings for the upstream discovery")
+		return nil
+	}
+	agents := &v1beta1.FluentbitAgentList{}
+	if err := s.client.List(ctx, agents); err != nil {
+		s.logger.Error(err, "failed to list fluentbit agents for the upstream discovery")
+		return nil
+	}
+
+	var changed []*v1beta1.Logging
+	watched := make(map[string]bool)
+	for i := range loggings.Items {
+		logging := &loggings.Items[i]
+		interval := upstreamDNSRefreshInterval(logging, agents.Items)
+		if interval == 0 {
+			continue
+		}
+		watched[logging.Name] = true
+		if now.Sub(s.lastLookup[logging.Name]) < interval {
+			continue
+		}
+		s.lastLookup[logging.Name] = now
+
+		nodes, err := s.lookup(ctx, logging)
+		if err != nil {
+			s.logger.Error(err, "failed to look up the aggregator pods", "logging", logging.Name)
+			continue
+		}
+		previous, seen := s.nodes[logging.Name]
+		s.nodes[logging.Name] = nodes
+		// the first lookup only records the pods, the logging has been reconciled with them already
+		if seen && !reflect.DeepEqual(previous, nodes) {
+			changed = append(changed, logging)
+		}
+	}
+	for name := range s.nodes {
+		if !watched[name] {
+			delete(s.nodes, name)
+			delete(s.lastLookup, name)
+		}
+	}
+	return changed
+}
+
+// upstreamDNSRefreshInterval returns the shortest refresh interval of the fluent-bit specs of the logging that discover
+// the aggregator pods through DNS, zero if there are none
+func upstreamDNSRefreshInterval(logging *v1beta1.Logging, agents []v1beta1.FluentbitAgent) time.Duration {
+	if logging.Spec.FluentdSpec == nil {
+		return 0
+	}
+	var specs []*v1beta1.FluentbitSpec
+	for i := range agents {
+		if agents[i].Spec.LoggingRef == logging.Spec.LoggingRef {
+			specs = append(specs, &agents[i].Spec)
+		}
+	}
+	// the legacy definition is only used without fluentbit agents
+	if len(specs) == 0 && logging.Spec.FluentbitSpec != nil {
+		specs = append(specs, logging.Spec.FluentbitSpec)
+	}
+
+	var interval time.Duration
+	for _, spec := range specs {
+		d := spec.UpstreamDiscovery
+		if !spec.EnableUpstream || d == nil || d.Source != v1beta1.UpstreamDiscoveryDNS {
+			continue
+		}
+		refresh := v1beta1.DefaultFluentbitUpstreamRefreshInterval
+		if d.RefreshInterval != nil && d.RefreshInterval.Duration > 0 {
+			refresh = d.RefreshInterval.Duration
+		}
+		if interval == 0 || refresh < interval {
+			interval = refresh
+		}
+	}
+	return interval
+}
@@ -0,0 +1,258 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentbit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cisco-open/operator-tools/pkg/utils"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+)
+
+func upstreamSpec(source string) v1beta1.FluentbitSpec {
+	return v1beta1.FluentbitSpec{
+		EnableUpstream:    true,
+		UpstreamDiscovery: &v1beta1.FluentbitUpstreamDiscovery{Source: source},
+	}
+}
+
+func aggregatorEndpointSlice(name, namespace, service string, port *int32, endpoints ...discoveryv1.Endpoint) *discoveryv1.EndpointSlice {
+	return &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{discoveryv1.LabelServiceName: service},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Ports: []discoveryv1.EndpointPort{
+			{Name: utils.StringPointer("udp-fluentd"), Port: utils.IntPointer(24241)},
+			{Name: utils.StringPointer(fluentdForwardPortName), Port: port},
+		},
+		Endpoints: endpoints,
+	}
+}
+
+func TestUpstreamNodesEndpointSlices(t *testing.T) {
+	r := newTestReconciler(t, upstreamSpec(v1beta1.UpstreamDiscoveryEndpointSlices),
+		aggregatorEndpointSlice("test-fluentd-headless-a", "logging", "test-fluentd-headless", utils.IntPointer(24250),
+			// a nil ready condition means ready
+			discoveryv1.Endpoint{Addresses: []string{"10.0.0.2"}, Hostname: utils.StringPointer("test-fluentd-2")},
+			discoveryv1.Endpoint{Addresses: []string{"10.0.0.0"}, Hostname: utils.StringPointer("test-fluentd-0"),
+				Conditions: discoveryv1.EndpointConditions{Ready: utils.BoolPointer(true)}},
+			discoveryv1.Endpoint{Addresses: []string{"10.0.0.3"}, Hostname: utils.StringPointer("test-fluentd-3"),
+				Conditions: discoveryv1.EndpointConditions{Ready: utils.BoolPointer(false)}},
+			discoveryv1.Endpoint{Addresses: []string{"10.0.0.4"}, Hostname: utils.StringPointer("test-fluentd-4"),
+				Conditions: discoveryv1.EndpointConditions{Terminating: utils.BoolPointer(true)}},
+		),
+		aggregatorEndpointSlice("test-fluentd-headless-b", "logging", "test-fluentd-headless", nil,
+			// without a hostname the pod is addressed by its IP
+			discoveryv1.Endpoint{Addresses: []string{"10.0.0.1"}, TargetRef: &corev1.ObjectReference{Kind: "Pod", Name: "test-fluentd-1"}},
+			// neither a hostname nor a pod
+			discoveryv1.Endpoint{Addresses: []string{"10.0.0.5"}},
+		),
+		// endpoints of other services are ignored
+		aggregatorEndpointSlice("test-fluentd-a", "logging", "test-fluentd", nil,
+			discoveryv1.Endpoint{Addresses: []string{"10.0.0.6"}, Hostname: utils.StringPointer("test-fluentd-6")},
+		),
+		aggregatorEndpointSlice("test-fluentd-headless-c", "other", "test-fluentd-headless", nil,
+			discoveryv1.Endpoint{Addresses: []string{"10.0.0.7"}, Hostname: utils.StringPointer("test-fluentd-7")},
+		),
+	)
+
+	nodes, err := r.upstreamNodes(context.TODO(), 3)
+	require.NoError(t, err)
+	require.Equal(t, []upstreamNode{
+		{Name: "test-fluentd-0", Host: r.aggregatorPodHost("test-fluentd-0"), Port: 24250},
+		{Name: "test-fluentd-1", Host: "10.0.0.1", Port: fluentdForwardPort},
+		{Name: "test-fluentd-2", Host: r.aggregatorPodHost("test-fluentd-2"), Port: 24250},
+	}, nodes)
+}
+
+func TestUpstreamNodesFallback(t *testing.T) {
+	replicas := func(r *Reconciler) []upstreamNode {
+		return []upstreamNode{r.generateUpstreamNode(0), r.generateUpstreamNode(1)}
+	}
+
+	tests := map[string]struct {
+		spec v1beta1.FluentbitSpec
+		objs []client.Object
+	}{
+		"discovery not configured": {
+			spec: v1beta1.FluentbitSpec{EnableUpstream: true},
+			objs: []client.Object{
+				aggregatorEndpointSlice("test-fluentd-headless-a", "logging", "test-fluentd-headless", nil,
+					discoveryv1.Endpoint{Addresses: []string{"10.0.0.0"}, Hostname: utils.StringPointer("test-fluentd-0")},
+				),
+			},
+		},
+		"no endpoint slices": {
+			spec: upstreamSpec(v1beta1.UpstreamDiscoveryEndpointSlices),
+		},
+		"no ready endpoints": {
+			spec: upstreamSpec(v1beta1.UpstreamDiscoveryEndpointSlices),
+			objs: []client.Object{
+				aggregatorEndpointSlice("test-fluentd-headless-a", "logging", "test-fluentd-headless", nil,
+					discoveryv1.Endpoint{Addresses: []string{"10.0.0.0"}, Hostname: utils.StringPointer("test-fluentd-0"),
+						Conditions: discoveryv1.EndpointConditions{Ready: utils.BoolPointer(false)}},
+					discoveryv1.Endpoint{Addresses: []string{"10.0.0.1"}, Hostname: utils.StringPointer("test-fluentd-1"),
+						Conditions: discoveryv1.EndpointConditions{Terminating: utils.BoolPointer(true)}},
+				),
+			},
+		},
+	}
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			r := newTestReconciler(t, tt.spec, tt.objs...)
+			nodes, err := r.upstreamNodes(context.TODO(), 2)
+			require.NoError(t, err)
+			require.Equal(t, replicas(r), nodes)
+		})
+	}
+}
+
+func TestUpstreamDNSRefreshInterval(t *testing.T) {
+	dnsSpec := func(interval time.Duration) v1beta1.FluentbitSpec {
+		spec := upstreamSpec(v1beta1.UpstreamDiscoveryDNS)
+		if interval > 0 {
+			spec.UpstreamDiscovery.RefreshInterval = &metav1.Duration{Duration: interval}
+		}
+		return spec
+	}
+	agent := func(loggingRef string, spec v1beta1.FluentbitSpec) v1beta1.FluentbitAgent {
+		spec.LoggingRef = loggingRef
+		return v1beta1.FluentbitAgent{Spec: spec}
+	}
+	legacy := dnsSpec(time.Minute)
+
+	tests := map[string]struct {
+		logging v1beta1.LoggingSpec
+		agents  []v1beta1.FluentbitAgent
+		want    time.Duration
+	}{
+		"legacy spec": {
+			logging: v1beta1.LoggingSpec{FluentdSpec: &v1beta1.FluentdSpec{}, FluentbitSpec: &legacy},
+			want:    time.Minute,
+		},
+		"default interval": {
+			logging: v1beta1.LoggingSpec{FluentdSpec: &v1beta1.FluentdSpec{}},
+			agents:  []v1beta1.FluentbitAgent{agent("", dnsSpec(0))},
+			want:    v1beta1.DefaultFluentbitUpstreamRefreshInterval,
+		},
+		"shortest interval of the agents": {
+			logging: v1beta1.LoggingSpec{FluentdSpec: &v1beta1.FluentdSpec{}, LoggingRef: "infra"},
+			agents: []v1beta1.FluentbitAgent{
+				agent("infra", dnsSpec(time.Minute)),
+				agent("infra", dnsSpec(10*time.Second)),
+				agent("infra", upstreamSpec(v1beta1.UpstreamDiscoveryEndpointSlices)),
+				agent("", dnsSpec(time.Second)),
+			},
+			want: 10 * time.Second,
+		},
+		"agents override the legacy spec": {
+			logging: v1beta1.LoggingSpec{FluentdSpec: &v1beta1.FluentdSpec{}, FluentbitSpec: &legacy},
+			agents:  []v1beta1.FluentbitAgent{agent("", upstreamSpec(v1beta1.UpstreamDiscoveryEndpointSlices))},
+		},
+		"upstream disabled": {
+			logging: v1beta1.LoggingSpec{FluentdSpec: &v1beta1.FluentdSpec{}},
+			agents:  []v1beta1.FluentbitAgent{agent("", v1beta1.FluentbitSpec{UpstreamDiscovery: &v1beta1.FluentbitUpstreamDiscovery{Source: v1beta1.UpstreamDiscoveryDNS}})},
+		},
+		"no fluentd": {
+			agents: []v1beta1.FluentbitAgent{agent("", dnsSpec(time.Minute))},
+		},
+	}
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			logging := &v1beta1.Logging{ObjectMeta: metav1.ObjectMeta{Name: "test"}, Spec: tt.logging}
+			require.Equal(t, tt.want, upstreamDNSRefreshInterval(logging, tt.agents))
+		})
+	}
+}
+
+func TestUpstreamDNSSourceChangedLoggings(t *testing.T) {
+	spec := upstreamSpec(v1beta1.UpstreamDiscoveryDNS)
+	spec.UpstreamDiscovery.RefreshInterval = &metav1.Duration{Duration: 30 * time.Second}
+	logging := &v1beta1.Logging{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: v1beta1.LoggingSpec{
+			ControlNamespace: "logging",
+			FluentdSpec:      &v1beta1.FluentdSpec{},
+			FluentbitSpec:    &spec,
+		},
+	}
+	other := &v1beta1.Logging{
+		ObjectMeta: metav1.ObjectMeta{Name: "other"},
+		Spec: v1beta1.LoggingSpec{
+			ControlNamespace: "logging",
+			LoggingRef:       "other",
+			FluentdSpec:      &v1beta1.FluentdSpec{},
+		},
+	}
+
+	sch := runtime.NewScheme()
+	require.NoError(t, v1beta1.AddToScheme(sch))
+	c := fake.NewClientBuilder().WithScheme(sch).WithObjects(logging, other).Build()
+
+	nodes := []upstreamNode{{Name: "test-fluentd-0", Host: "test-fluentd-0.test-fluentd-headless.logging.svc", Port: fluentdForwardPort}}
+	var lookups []string
+	s := NewUpstreamDNSSource(c, logr.Discard())
+	s.lookup = func(_ context.Context, logging *v1beta1.Logging) ([]upstreamNode, error) {
+		lookups = append(lookups, logging.Name)
+		return nodes, nil
+	}
+	changedNames := func(now time.Time) []string {
+		var names []string
+		for _, l := range s.changedLoggings(context.TODO(), now) {
+			names = append(names, l.Name)
+		}
+		return names
+	}
+
+	now := time.Now()
+	// the first lookup only records the pods
+	require.Empty(t, changedNames(now))
+	require.Equal(t, []string{"test"}, lookups)
+
+	// no lookup before the refresh interval has passed
+	nodes = append(nodes, upstreamNode{Name: "test-fluentd-1", Host: "test-fluentd-1.test-fluentd-headless.logging.svc", Port: fluentdForwardPort})
+	require.Empty(t, changedNames(now.Add(10*time.Second)))
+	require.Equal(t, []string{"test"}, lookups)
+
+	require.Equal(t, []string{"test"}, changedNames(now.Add(30*time.Second)))
+	require.Equal(t, []string{"test", "test"}, lookups)
+
+	// unchanged pods do not trigger a reconcile
+	require.Empty(t, changedNames(now.Add(time.Minute)))
+	require.Equal(t, []string{"test", "test", "test"}, lookups)
+
+	// the state is dropped when the discovery is turned off
+	logging.Spec.FluentbitSpec.UpstreamDiscovery.Source = v1beta1.UpstreamDiscoveryEndpointSlices
+	require.NoError(t, c.Update(context.TODO(), logging))
+	require.Empty(t, changedNames(now.Add(2*time.Minute)))
+	require.Empty(t, s.nodes)
+	require.Empty(t, s.lastLookup)
+}
@@ -252,6 +252,8 @@ func (r *Reconciler) newCheckPod(hashKey string) *corev1.Pod {
 		}
 		pod.Spec.Containers[0].VolumeMounts = append(pod.Spec.Containers[0].VolumeMounts, volumeMount)
 	}
+	pod.Spec.Volumes = append(pod.Spec.Volumes, grokPatternsVolumes(r.Logging.Spec.FluentdSpec)...)
+	pod.Spec.Containers[0].VolumeMounts = append(pod.Spec.Containers[0].VolumeMounts, grokPatternsVolumeMounts(r.Logging.Spec.FluentdSpec)...)
 	for _, n := range r.Logging.Spec.FluentdSpec.ExtraVolumes {
 		if err := n.ApplyVolumeForPodSpec(&pod.Spec); err != nil {
 			r.Log.Error(err, "Fluentd Config check pod extraVolume attachment failed.")
@@ -54,6 +54,8 @@ const (
 	OutputSecretName      = "fluentd-output"
 	OutputSecretPath      = "/fluentd/secret"
 
+	GrokPatternsChecksumAnnotation = "checksum/grok-patterns"
+
 	bufferPath                     = "/buffers"
 	defaultServiceAccountName      = "fluentd"
 	roleBindingName                = "fluentd"
@@ -15,17 +15,27 @@
 package fluentd
 
 import (
+	"context"
+	"crypto/sha256"
 	"fmt"
+	"path"
+	"sort"
 	"strings"
 
+	"emperror.dev/errors"
 	"github.com/cisco-open/operator-tools/pkg/reconciler"
 	util "github.com/cisco-open/operator-tools/pkg/utils"
+	"github.com/kube-logging/logging-operator/pkg/resources/pki"
+	"github.com/kube-logging/logging-operator/pkg/resources/templates"
 	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/filter"
 	"github.com/spf13/cast"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func (r *Reconciler) statefulset() (runtime.Object, reconciler.DesiredState, error) {
@@ -61,6 +71,25 @@ func (r *Reconciler) statefulset() (runtime.Object, reconciler.DesiredState, err
 		}
 	}
 
+	if r.Logging.Spec.FluentdSpec.TLS.Enabled {
+		checksum, err := pki.SecretChecksum(context.TODO(), r.Client, r.Logging, r.Logging.Spec.FluentdSpec.TLS.SecretName)
+		if err != nil {
+			return nil, reconciler.StatePresent, err
+		}
+		if checksum != "" {
+			spec.Template.ObjectMeta = templates.Annotate(spec.Template.ObjectMeta, pki.ChecksumAnnotation, checksum)
+		}
+	}
+
+	if len(r.Logging.Spec.FluentdSpec.GrokPatternConfigMaps) > 0 {
+		// the parser filters load the grok patterns on startup, roll the pods when their content changes
+		checksum, err := r.grokPatternsChecksum(context.TODO())
+		if err != nil {
+			return nil, reconciler.StatePresent, err
+		}
+		spec.Template.ObjectMeta = templates.Annotate(spec.Template.ObjectMeta, GrokPatternsChecksumAnnotation, checksum)
+	}
+
 	desired := &appsv1.StatefulSet{
 		ObjectMeta: r.FluentdObjectMeta(StatefulSetName, ComponentFluentd),
 		Spec:       *spec,
@@ -170,7 +199,8 @@ func (r *Reconciler) generatePodMeta() metav1.ObjectMeta {
 		Labels: r.Logging.GetFluentdLabels(ComponentFluentd),
 	}
 	if r.Logging.Spec.FluentdSpec.Annotations != nil {
-		meta.Annotations = r.Logging.Spec.FluentdSpec.Annotations
+		// copied, the checksum annotations must not end up in the logging spec
+		meta.Annotations = util.MergeLabels(r.Logging.Spec.FluentdSpec.Annotations)
 	}
 	return meta
 }
@@ -283,9 +313,66 @@ func generateVolumeMounts(spec *v1beta1.FluentdSpec) []corev1.VolumeMount {
 			MountPath: "/fluentd/tls/",
 		})
 	}
+	if spec != nil {
+		res = append(res, grokPatternsVolumeMounts(spec)...)
+	}
 	return res
 }
 
+func grokPatternsVolumeName(configMap string) string {
+	return "grok-patterns-" + configMap
+}
+
+func grokPatternsVolumes(spec *v1beta1.FluentdSpec) (v []corev1.Volume) {
+	for _, configMap := range spec.GrokPatternConfigMaps {
+		v = append(v, corev1.Volume{
+			Name: grokPatternsVolumeName(configMap),
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: configMap,
+					},
+				},
+			},
+		})
+	}
+	return
+}
+
+// grokPatternsChecksum returns the checksum of the custom grok pattern configmaps mounted into fluentd
+func (r *Reconciler) grokPatternsChecksum(ctx context.Context) (string, error) {
+	h := sha256.New()
+	for _, name := range r.Logging.Spec.FluentdSpec.GrokPatternConfigMaps {
+		cm := &corev1.ConfigMap{}
+		err := r.Client.Get(ctx, types.NamespacedName{Namespace: r.Logging.Spec.ControlNamespace, Name: name}, cm)
+		if client.IgnoreNotFound(err) != nil {
+			return "", errors.WrapIff(err, "failed to get grok patterns configmap %s", name)
+		}
+		keys := make([]string, 0, len(cm.Data))
+		for key := range cm.Data {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		_, _ = h.Write([]byte(name))
+		for _, key := range keys {
+			_, _ = h.Write([]byte(key))
+			_, _ = h.Write([]byte(cm.Data[key]))
+		}
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
+
+func grokPatternsVolumeMounts(spec *v1beta1.FluentdSpec) (vm []corev1.VolumeMount) {
+	for _, configMap := range spec.GrokPatternConfigMaps {
+		vm = append(vm, corev1.VolumeMount{
+			Name:      grokPatternsVolumeName(configMap),
+			MountPath: path.Join(filter.GrokPatternsPath, configMap),
+			ReadOnly:  true,
+		})
+	}
+	return
+}
+
 func (r *Reconciler) generateVolume() (v []corev1.Volume) {
 	v = []corev1.Volume{
 		{
@@ -343,6 +430,7 @@ func (r *Reconciler) generateVolume() (v []corev1.Volume) {
 		}
 		v = append(v, tlsRelatedVolume)
 	}
+	v = append(v, grokPatternsVolumes(r.Logging.Spec.FluentdSpec)...)
 	return
 }
 
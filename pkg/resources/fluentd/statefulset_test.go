@@ -0,0 +1,72 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cisco-open/operator-tools/pkg/reconciler"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+)
+
+func TestStatefulSetGrokPatternsChecksum(t *testing.T) {
+	patterns := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "grok-custom", Namespace: "logging"},
+		Data:       map[string]string{"custom": "MY_ID [0-9a-f]{8}"},
+	}
+	logging := &v1beta1.Logging{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: v1beta1.LoggingSpec{
+			ControlNamespace: "logging",
+			FluentdSpec: &v1beta1.FluentdSpec{
+				GrokPatternConfigMaps: []string{"grok-custom"},
+			},
+		},
+	}
+	require.NoError(t, logging.SetDefaults())
+
+	sch := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(sch))
+	c := fake.NewClientBuilder().WithScheme(sch).WithObjects(patterns).Build()
+	r := New(c, logr.Discard(), logging, nil, nil, reconciler.ReconcilerOpts{})
+
+	checksum := func() string {
+		obj, _, err := r.statefulset()
+		require.NoError(t, err)
+		return obj.(*appsv1.StatefulSet).Spec.Template.Annotations[GrokPatternsChecksumAnnotation]
+	}
+
+	initial := checksum()
+	require.NotEmpty(t, initial)
+	require.Equal(t, initial, checksum())
+	require.NotContains(t, logging.Spec.FluentdSpec.Annotations, GrokPatternsChecksumAnnotation)
+
+	patterns.Data["custom"] = "MY_ID [0-9a-f]{16}"
+	require.NoError(t, c.Update(context.TODO(), patterns))
+	require.NotEqual(t, initial, checksum())
+
+	logging.Spec.FluentdSpec.GrokPatternConfigMaps = nil
+	require.Empty(t, checksum())
+}
@@ -86,6 +86,7 @@ func NewValidationReconciler(
 
 			output.Status.Problems = append(output.Status.Problems,
 				validateOutputSpec(output.Spec.SyslogNGOutputSpec, secrets.OutputSecretLoaderForNamespace(output.Namespace))...)
+			output.Status.Problems = append(output.Status.Problems, validateSyslogNGOutputSpec(output.Spec.SyslogNGOutputSpec)...)
 			output.Status.ProblemsCount = len(output.Status.Problems)
 		}
 
@@ -98,6 +99,7 @@ func NewValidationReconciler(
 
 			output.Status.Problems = append(output.Status.Problems,
 				validateOutputSpec(output.Spec, secrets.OutputSecretLoaderForNamespace(output.Namespace))...)
+			output.Status.Problems = append(output.Status.Problems, validateSyslogNGOutputSpec(output.Spec)...)
 			output.Status.ProblemsCount = len(output.Status.Problems)
 		}
 
@@ -169,6 +171,18 @@ func NewValidationReconciler(
 					flow.Status.Problems = append(flow.Status.Problems, fmt.Sprintf("dangling global output reference: %s", ref))
 				}
 			}
+
+			problems := danglingPatternDBRefs(resources.Logging.Spec.SyslogNGSpec, flow.Spec.Filters)
+			for _, ref := range flow.Spec.Sources {
+				if !hasSyslogNGNetworkSource(resources.Logging.Spec.SyslogNGSpec, ref) {
+					problems = append(problems, fmt.Sprintf("dangling network source reference: %s", ref))
+				}
+			}
+			if len(problems) > 0 {
+				// the flow is left out of the syslog-ng configuration
+				flow.Status.Active = utils.BoolPointer(false)
+				flow.Status.Problems = append(flow.Status.Problems, problems...)
+			}
 			flow.Status.ProblemsCount = len(flow.Status.Problems)
 		}
 
@@ -196,6 +210,11 @@ func NewValidationReconciler(
 					flow.Status.Problems = append(flow.Status.Problems, fmt.Sprintf("dangling local output reference: %s", ref))
 				}
 			}
+
+			if problems := danglingPatternDBRefs(resources.Logging.Spec.SyslogNGSpec, flow.Spec.Filters); len(problems) > 0 {
+				flow.Status.Active = utils.BoolPointer(false)
+				flow.Status.Problems = append(flow.Status.Problems, problems...)
+			}
 			flow.Status.ProblemsCount = len(flow.Status.Problems)
 		}
 
@@ -258,6 +277,51 @@ func validateOutputSpec(spec interface{}, secrets secret.SecretLoader) (problems
 	return
 }
 
+func hasSyslogNGNetworkSource(spec *loggingv1beta1.SyslogNGSpec, name string) bool {
+	if spec == nil {
+		return false
+	}
+	for _, source := range spec.NetworkSources {
+		if source.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func danglingPatternDBRefs(spec *loggingv1beta1.SyslogNGSpec, filters []loggingv1beta1.SyslogNGFilter) (problems []string) {
+	for _, ref := range loggingv1beta1.PatternDBRefs(filters) {
+		if !spec.HasPatternDB(ref) {
+			problems = append(problems, fmt.Sprintf("dangling pattern database reference: %s", ref))
+		}
+	}
+	return
+}
+
+func validateSyslogNGOutputSpec(spec loggingv1beta1.SyslogNGOutputSpec) (problems []string) {
+	if s3 := spec.S3; s3 != nil {
+		if (s3.AccessKey == nil) != (s3.SecretKey == nil) {
+			problems = append(problems, "s3: access_key and secret_key must be set together")
+		}
+	}
+	if kafka := spec.Kafka; kafka != nil && kafka.Security != nil {
+		files := []struct {
+			name   string
+			secret *secret.Secret
+		}{
+			{"ssl_ca_file", kafka.Security.SSLCaFile},
+			{"ssl_cert_file", kafka.Security.SSLCertFile},
+			{"ssl_key_file", kafka.Security.SSLKeyFile},
+		}
+		for _, file := range files {
+			if file.secret != nil && file.secret.MountFrom == nil {
+				problems = append(problems, fmt.Sprintf("kafka: %s must be set with mountFrom, librdkafka expects a file path", file.name))
+			}
+		}
+	}
+	return
+}
+
 func checkSecrets(v reflect.Value, secrets secret.SecretLoader) (problems []string) {
 	switch v.Kind() {
 	case reflect.Array, reflect.Slice:
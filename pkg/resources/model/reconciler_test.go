@@ -0,0 +1,162 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cisco-open/operator-tools/pkg/secret"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/syslogng/filter"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/syslogng/output"
+)
+
+func TestValidationReconciler_SyslogNGS3Credentials(t *testing.T) {
+	s3Output := func(name string, accessKey, secretKey *secret.Secret) v1beta1.SyslogNGOutput {
+		return v1beta1.SyslogNGOutput{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "app"},
+			Spec: v1beta1.SyslogNGOutputSpec{
+				S3: &output.S3Output{
+					Bucket:    "logs",
+					AccessKey: accessKey,
+					SecretKey: secretKey,
+				},
+			},
+		}
+	}
+	key := &secret.Secret{Value: "key"}
+
+	resources := LoggingResources{
+		SyslogNG: SyslogNGLoggingResources{
+			Outputs: []v1beta1.SyslogNGOutput{
+				s3Output("both", key, key),
+				s3Output("none", nil, nil),
+				s3Output("access-key-only", key, nil),
+				s3Output("secret-key-only", nil, key),
+			},
+		},
+	}
+
+	sch := runtime.NewScheme()
+	require.NoError(t, v1beta1.AddToScheme(sch))
+	builder := fake.NewClientBuilder().WithScheme(sch)
+	for i := range resources.SyslogNG.Outputs {
+		builder = builder.WithObjects(resources.SyslogNG.Outputs[i].DeepCopy())
+	}
+
+	_, err := NewValidationReconciler(context.TODO(), builder.Build(), resources, testSecretLoaderFactory{})()
+	require.NoError(t, err)
+
+	problems := map[string][]string{}
+	for _, o := range resources.SyslogNG.Outputs {
+		problems[o.Name] = o.Status.Problems
+	}
+	require.Equal(t, map[string][]string{
+		"both":            nil,
+		"none":            nil,
+		"access-key-only": {"s3: access_key and secret_key must be set together"},
+		"secret-key-only": {"s3: access_key and secret_key must be set together"},
+	}, problems)
+}
+
+func TestValidationReconciler_SyslogNGDanglingRefs(t *testing.T) {
+	dbParserFlow := func(name, patternDB string) v1beta1.SyslogNGFlow {
+		return v1beta1.SyslogNGFlow{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "app"},
+			Spec: v1beta1.SyslogNGFlowSpec{
+				Filters: []v1beta1.SyslogNGFilter{
+					{Parser: &filter.ParserConfig{DBParser: &filter.DBParser{PatternDB: filter.PatternDBRef(patternDB)}}},
+				},
+			},
+		}
+	}
+
+	resources := LoggingResources{
+		Logging: v1beta1.Logging{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec: v1beta1.LoggingSpec{
+				SyslogNGSpec: &v1beta1.SyslogNGSpec{
+					PatternDBs: []v1beta1.SyslogNGPatternDB{{Name: "sshd"}},
+				},
+			},
+		},
+		SyslogNG: SyslogNGLoggingResources{
+			Flows: []v1beta1.SyslogNGFlow{
+				dbParserFlow("known", "sshd"),
+				dbParserFlow("dangling", "missing"),
+			},
+			ClusterFlows: []v1beta1.SyslogNGClusterFlow{{
+				ObjectMeta: metav1.ObjectMeta{Name: "devices", Namespace: "logging"},
+				Spec:       v1beta1.SyslogNGClusterFlowSpec{Sources: []string{"switches"}},
+			}},
+		},
+	}
+
+	sch := runtime.NewScheme()
+	require.NoError(t, v1beta1.AddToScheme(sch))
+	builder := fake.NewClientBuilder().WithScheme(sch).WithObjects(resources.Logging.DeepCopy())
+	for i := range resources.SyslogNG.Flows {
+		builder = builder.WithObjects(resources.SyslogNG.Flows[i].DeepCopy())
+	}
+	builder = builder.WithObjects(resources.SyslogNG.ClusterFlows[0].DeepCopy())
+
+	_, err := NewValidationReconciler(context.TODO(), builder.Build(), resources, testSecretLoaderFactory{})()
+	require.NoError(t, err)
+
+	require.Empty(t, resources.SyslogNG.Flows[0].Status.Problems)
+	require.Equal(t, []string{"dangling pattern database reference: missing"}, resources.SyslogNG.Flows[1].Status.Problems)
+	require.False(t, *resources.SyslogNG.Flows[1].Status.Active)
+
+	require.Equal(t, []string{"dangling network source reference: switches"}, resources.SyslogNG.ClusterFlows[0].Status.Problems)
+	require.False(t, *resources.SyslogNG.ClusterFlows[0].Status.Active)
+}
+
+func TestValidateSyslogNGOutputSpec_KafkaSSLFiles(t *testing.T) {
+	mounted := &secret.Secret{MountFrom: &secret.ValueFrom{SecretKeyRef: &corev1.SecretKeySelector{Key: "ca.crt"}}}
+	inline := &secret.Secret{Value: "-----BEGIN CERTIFICATE-----"}
+
+	tests := map[string]struct {
+		security *output.KafkaSecurity
+		problems []string
+	}{
+		"no security": {},
+		"mounted files": {
+			security: &output.KafkaSecurity{Protocol: "SSL", SSLCaFile: mounted, SSLCertFile: mounted, SSLKeyFile: mounted},
+		},
+		"inline key password": {
+			security: &output.KafkaSecurity{Protocol: "SSL", SSLKeyPassword: inline},
+		},
+		"inline files": {
+			security: &output.KafkaSecurity{Protocol: "SSL", SSLCaFile: inline, SSLCertFile: mounted, SSLKeyFile: inline},
+			problems: []string{
+				"kafka: ssl_ca_file must be set with mountFrom, librdkafka expects a file path",
+				"kafka: ssl_key_file must be set with mountFrom, librdkafka expects a file path",
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			spec := v1beta1.SyslogNGOutputSpec{Kafka: &output.KafkaOutput{Topic: "logs", Security: test.security}}
+			require.Equal(t, test.problems, validateSyslogNGOutputSpec(spec))
+		})
+	}
+}
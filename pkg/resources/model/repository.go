@@ -59,6 +59,12 @@ func (r LoggingResourceRepository) LoggingResourcesFor(ctx context.Context, logg
 	res.NodeAgents, err = r.NodeAgentsFor(ctx, logging)
 	errs = errors.Append(errs, err)
 
+	// profiles are only needed to render node agents
+	if len(logging.Spec.NodeAgents) > 0 || len(res.NodeAgents) > 0 {
+		res.NodeAgentProfiles, err = r.NodeAgentProfiles(ctx)
+		errs = errors.Append(errs, err)
+	}
+
 	res.Fluentbits, err = r.FluentbitsFor(ctx, logging)
 	errs = errors.Append(errs, err)
 
@@ -281,6 +287,19 @@ func (r LoggingResourceRepository) NodeAgentsFor(ctx context.Context, logging v1
 	return res, nil
 }
 
+// NodeAgentProfiles returns the cluster-scoped NodeAgentProfiles, they are shared by all the logging resources
+func (r LoggingResourceRepository) NodeAgentProfiles(ctx context.Context) ([]v1beta1.NodeAgentProfile, error) {
+	var list v1beta1.NodeAgentProfileList
+	if err := r.Client.List(ctx, &list); err != nil {
+		return nil, errors.WrapIf(err, "listing node agent profiles")
+	}
+
+	sort.Slice(list.Items, func(i, j int) bool {
+		return list.Items[i].Name < list.Items[j].Name
+	})
+	return list.Items, nil
+}
+
 func (r LoggingResourceRepository) FluentbitsFor(ctx context.Context, logging v1beta1.Logging) ([]v1beta1.FluentbitAgent, error) {
 	var list v1beta1.FluentbitAgentList
 	if err := r.Client.List(ctx, &list); err != nil {
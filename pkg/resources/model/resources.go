@@ -15,15 +15,57 @@
 package model
 
 import (
+	"sort"
+
 	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
 )
 
 type LoggingResources struct {
-	Logging    v1beta1.Logging
-	Fluentd    FluentdLoggingResources
-	SyslogNG   SyslogNGLoggingResources
-	NodeAgents []v1beta1.NodeAgent
-	Fluentbits []v1beta1.FluentbitAgent
+	Logging           v1beta1.Logging
+	Fluentd           FluentdLoggingResources
+	SyslogNG          SyslogNGLoggingResources
+	NodeAgents        []v1beta1.NodeAgent
+	NodeAgentProfiles []v1beta1.NodeAgentProfile
+	Fluentbits        []v1beta1.FluentbitAgent
+}
+
+// RoutedNamespaces returns the sorted list of namespaces whose container logs can be matched by any of the flows.
+// The result is conservative: all is true if the flows may match logs from any namespace.
+func (r LoggingResources) RoutedNamespaces() (namespaces []string, all bool) {
+	if r.Logging.Spec.DefaultFlowSpec != nil || len(r.SyslogNG.ClusterFlows) > 0 {
+		return nil, true
+	}
+	set := make(map[string]bool)
+	for _, flow := range r.Fluentd.Flows {
+		set[flow.Namespace] = true
+	}
+	for _, flow := range r.SyslogNG.Flows {
+		set[flow.Namespace] = true
+	}
+	for _, flow := range r.Fluentd.ClusterFlows {
+		selectsNamespaces := false
+		for _, match := range flow.Spec.Match {
+			if match.ClusterSelect == nil {
+				continue
+			}
+			if len(match.ClusterSelect.Namespaces) == 0 {
+				return nil, true
+			}
+			selectsNamespaces = true
+			for _, ns := range match.ClusterSelect.Namespaces {
+				set[ns] = true
+			}
+		}
+		// legacy selectors and exclude-only matches are not limited to namespaces
+		if !selectsNamespaces {
+			return nil, true
+		}
+	}
+	for ns := range set {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+	return namespaces, false
 }
 
 type FluentdLoggingResources struct {
@@ -0,0 +1,112 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+)
+
+func TestLoggingResources_RoutedNamespaces(t *testing.T) {
+	flow := func(ns string) v1beta1.Flow {
+		return v1beta1.Flow{ObjectMeta: metav1.ObjectMeta{Namespace: ns}}
+	}
+	clusterFlow := func(match ...v1beta1.ClusterMatch) v1beta1.ClusterFlow {
+		return v1beta1.ClusterFlow{Spec: v1beta1.ClusterFlowSpec{Match: match}}
+	}
+	tests := []struct {
+		name           string
+		resources      LoggingResources
+		wantNamespaces []string
+		wantAll        bool
+	}{
+		{
+			name: "no flows",
+		},
+		{
+			name: "flows and cluster flows selecting namespaces",
+			resources: LoggingResources{
+				Fluentd: FluentdLoggingResources{
+					Flows: []v1beta1.Flow{flow("b"), flow("a"), flow("b")},
+					ClusterFlows: []v1beta1.ClusterFlow{
+						clusterFlow(
+							v1beta1.ClusterMatch{ClusterExclude: &v1beta1.ClusterExclude{Namespaces: []string{"a"}}},
+							v1beta1.ClusterMatch{ClusterSelect: &v1beta1.ClusterSelect{Namespaces: []string{"c"}}},
+						),
+					},
+				},
+				SyslogNG: SyslogNGLoggingResources{
+					Flows: []v1beta1.SyslogNGFlow{{ObjectMeta: metav1.ObjectMeta{Namespace: "d"}}},
+				},
+			},
+			wantNamespaces: []string{"a", "b", "c", "d"},
+		},
+		{
+			name: "cluster flow selecting all namespaces",
+			resources: LoggingResources{
+				Fluentd: FluentdLoggingResources{
+					Flows: []v1beta1.Flow{flow("a")},
+					ClusterFlows: []v1beta1.ClusterFlow{
+						clusterFlow(v1beta1.ClusterMatch{ClusterSelect: &v1beta1.ClusterSelect{Labels: map[string]string{"app": "x"}}}),
+					},
+				},
+			},
+			wantAll: true,
+		},
+		{
+			name: "cluster flow with legacy selectors",
+			resources: LoggingResources{
+				Fluentd: FluentdLoggingResources{
+					ClusterFlows: []v1beta1.ClusterFlow{clusterFlow()},
+				},
+			},
+			wantAll: true,
+		},
+		{
+			name: "default flow",
+			resources: LoggingResources{
+				Logging: v1beta1.Logging{Spec: v1beta1.LoggingSpec{DefaultFlowSpec: &v1beta1.DefaultFlowSpec{}}},
+				Fluentd: FluentdLoggingResources{
+					Flows: []v1beta1.Flow{flow("a")},
+				},
+			},
+			wantAll: true,
+		},
+		{
+			name: "syslog-ng cluster flow",
+			resources: LoggingResources{
+				SyslogNG: SyslogNGLoggingResources{
+					ClusterFlows: []v1beta1.SyslogNGClusterFlow{{}},
+				},
+			},
+			wantAll: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			namespaces, all := tt.resources.RoutedNamespaces()
+			if !reflect.DeepEqual(namespaces, tt.wantNamespaces) {
+				t.Errorf("RoutedNamespaces() namespaces = %v, want %v", namespaces, tt.wantNamespaces)
+			}
+			if all != tt.wantAll {
+				t.Errorf("RoutedNamespaces() all = %v, want %v", all, tt.wantAll)
+			}
+		})
+	}
+}
@@ -73,6 +73,12 @@ func CreateSystem(resources LoggingResources, secrets SecretLoaderFactory, logge
 	if err != nil {
 		return nil, err
 	}
+	for i, filter := range globalFilters {
+		// the re-emitted events would start over ahead of the global filters and loop forever
+		if _, ok := filter.(*types.ReEmitDirective); ok {
+			return nil, errors.Errorf("global filter with index %d re-emits the events, filters like rewrite_tag are only supported in flows", i)
+		}
+	}
 
 	builder := types.NewSystemBuilder(rootInput, globalFilters, router)
 
@@ -86,6 +92,10 @@ func CreateSystem(resources LoggingResources, secrets SecretLoaderFactory, logge
 				return nil, err
 			}
 		}
+		err = enforceFilters(flow, logging, secrets)
+		if err != nil {
+			return nil, err
+		}
 		err = builder.RegisterFlow(flow)
 		if err != nil {
 			return nil, err
@@ -101,6 +111,10 @@ func CreateSystem(resources LoggingResources, secrets SecretLoaderFactory, logge
 				return nil, err
 			}
 		}
+		err = enforceFilters(flow, logging, secrets)
+		if err != nil {
+			return nil, err
+		}
 		err = builder.RegisterFlow(flow)
 		if err != nil {
 			return nil, err
@@ -112,6 +126,10 @@ func CreateSystem(resources LoggingResources, secrets SecretLoaderFactory, logge
 			// TODO set flow status to error?
 			return nil, err
 		}
+		err = enforceFilters(flow, logging, secrets)
+		if err != nil {
+			return nil, err
+		}
 		err = builder.RegisterDefaultFlow(flow)
 		if err != nil {
 			return nil, err
@@ -125,6 +143,11 @@ func CreateSystem(resources LoggingResources, secrets SecretLoaderFactory, logge
 		if err != nil {
 			return nil, err
 		}
+		// the records of every flow may end up in the error output
+		err = enforceFilters(errorFlow, logging, secrets)
+		if err != nil {
+			return nil, err
+		}
 	} else {
 		errorFlow = &types.Flow{
 			PluginMeta: types.PluginMeta{
@@ -172,6 +195,27 @@ func unsetBufferPath(directive types.Directive) {
 	}
 }
 
+// enforceFilters prepends the filters enforced by the logging resource to the filters of the flow
+func enforceFilters(flow *types.Flow, logging v1beta1.Logging, secrets SecretLoaderFactory) error {
+	if len(logging.Spec.EnforcedFilters) == 0 {
+		return nil
+	}
+	flowID := flow.FlowID
+	if flowID == "" {
+		flowID = flow.FlowLabel
+	}
+	enforced, err := filtersForFilters(
+		flowID+":enforced",
+		flowID,
+		secrets.OutputSecretLoaderForNamespace(logging.Spec.ControlNamespace),
+		logging.Spec.EnforcedFilters)
+	if err != nil {
+		return err
+	}
+	flow.Filters = append(enforced, flow.Filters...)
+	return nil
+}
+
 type SecretLoaderFactory interface {
 	OutputSecretLoaderForNamespace(namespace string) secret.SecretLoader
 }
@@ -0,0 +1,134 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/cisco-open/operator-tools/pkg/secret"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/filter"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/output"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/types"
+)
+
+type testSecretLoaderFactory struct{}
+
+func (testSecretLoaderFactory) OutputSecretLoaderForNamespace(string) secret.SecretLoader {
+	return secret.NewSecretLoader(nil, "", "", nil)
+}
+
+func TestCreateSystem_EnforcedFilters(t *testing.T) {
+	ownFilters := []v1beta1.Filter{{StdOut: &filter.StdOutFilterConfig{}}}
+	resources := LoggingResources{
+		Logging: v1beta1.Logging{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec: v1beta1.LoggingSpec{
+				ControlNamespace: "logging",
+				FluentdSpec:      &v1beta1.FluentdSpec{},
+				EnforcedFilters: []v1beta1.Filter{
+					{Redact: &filter.Redact{Detectors: []string{"email"}}},
+				},
+				DefaultFlowSpec: &v1beta1.DefaultFlowSpec{Filters: ownFilters},
+			},
+		},
+		Fluentd: FluentdLoggingResources{
+			Flows: []v1beta1.Flow{{
+				ObjectMeta: metav1.ObjectMeta{Name: "flow", Namespace: "app"},
+				Spec:       v1beta1.FlowSpec{Filters: ownFilters},
+			}},
+			ClusterFlows: []v1beta1.ClusterFlow{{
+				ObjectMeta: metav1.ObjectMeta{Name: "clusterflow", Namespace: "logging"},
+				Spec:       v1beta1.ClusterFlowSpec{Filters: ownFilters},
+			}},
+		},
+	}
+
+	system, err := CreateSystem(resources, testSecretLoaderFactory{}, logr.Discard())
+	require.NoError(t, err)
+
+	// Flow, ClusterFlow, DefaultFlow and the error flow
+	require.Len(t, system.Flows, 4)
+	for _, flow := range system.Flows {
+		if flow.FlowLabel == "@ERROR" {
+			require.Empty(t, flow.Filters)
+			continue
+		}
+		require.Equal(t, []string{"record_modifier", "stdout"}, filterTypes(flow.Filters), "flow %s", flow.FlowID)
+	}
+}
+
+func filterTypes(filters []types.Filter) []string {
+	var result []string
+	for _, f := range filters {
+		result = append(result, f.GetPluginMeta().Type)
+	}
+	return result
+}
+
+func TestCreateSystem_EnforcedFiltersErrorOutput(t *testing.T) {
+	resources := LoggingResources{
+		Logging: v1beta1.Logging{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec: v1beta1.LoggingSpec{
+				ControlNamespace: "logging",
+				FluentdSpec:      &v1beta1.FluentdSpec{},
+				EnforcedFilters: []v1beta1.Filter{
+					{Redact: &filter.Redact{Detectors: []string{"email"}}},
+				},
+				ErrorOutputRef: "errors",
+			},
+		},
+		Fluentd: FluentdLoggingResources{
+			ClusterOutputs: ClusterOutputs{{
+				ObjectMeta: metav1.ObjectMeta{Name: "errors", Namespace: "logging"},
+				Spec: v1beta1.ClusterOutputSpec{
+					OutputSpec: v1beta1.OutputSpec{NullOutputConfig: output.NewNullOutputConfig()},
+				},
+			}},
+		},
+	}
+
+	system, err := CreateSystem(resources, testSecretLoaderFactory{}, logr.Discard())
+	require.NoError(t, err)
+
+	require.Len(t, system.Flows, 1)
+	require.Equal(t, "@ERROR", system.Flows[0].FlowLabel)
+	require.Equal(t, []string{"record_modifier"}, filterTypes(system.Flows[0].Filters))
+	require.Equal(t, "@ERROR:enforced:0", system.Flows[0].Filters[0].GetPluginMeta().Id)
+}
+
+func TestCreateSystem_GlobalReEmitFilter(t *testing.T) {
+	resources := LoggingResources{
+		Logging: v1beta1.Logging{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec: v1beta1.LoggingSpec{
+				ControlNamespace: "logging",
+				FluentdSpec:      &v1beta1.FluentdSpec{},
+				GlobalFilters: []v1beta1.Filter{
+					{StdOut: &filter.StdOutFilterConfig{}},
+					{RewriteTag: &filter.RewriteTag{Rules: []filter.RewriteTagRule{{Key: "log", Pattern: "/^(.+)$/", Tag: "app.$1"}}}},
+				},
+			},
+		},
+	}
+
+	_, err := CreateSystem(resources, testSecretLoaderFactory{}, logr.Discard())
+	require.ErrorContains(t, err, "global filter with index 1 re-emits the events")
+}
@@ -79,7 +79,12 @@ var fluentBitConfigTemplate = `
     {{- end }}
     {{ if .TLS.Enabled }}
     tls           On
+    {{- if .TLS.Verify }}
+    tls.verify    On
+    tls.vhost     {{ .TargetHost }}
+    {{- else }}
     tls.verify    Off
+    {{- end }}
     tls.ca_file   /fluent-bit/tls/ca.crt
     tls.crt_file  /fluent-bit/tls/tls.crt
     tls.key_file  /fluent-bit/tls/tls.key
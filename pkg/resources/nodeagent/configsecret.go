@@ -52,6 +52,7 @@ type fluentBitConfig struct {
 	TLS       struct {
 		Enabled   bool
 		SharedKey string
+		Verify    bool
 	}
 	Monitor struct {
 		Enabled bool
@@ -188,9 +189,12 @@ func (n *nodeAgentInstance) configSecret() (runtime.Object, reconciler.DesiredSt
 		input.TLS = struct {
 			Enabled   bool
 			SharedKey string
+			Verify    bool
 		}{
 			Enabled:   *n.nodeAgent.FluentbitSpec.TLS.Enabled,
 			SharedKey: n.nodeAgent.FluentbitSpec.TLS.SharedKey,
+			// the server certificate of fluentd is issued for the target host by the managed CA
+			Verify: n.logging.Spec.ManagedTLS != nil && n.nodeAgent.FluentbitSpec.TLS.SecretName == n.logging.ManagedTLSAgentSecretName(),
 		}
 	}
 	if n.logging.Spec.FluentdSpec != nil {
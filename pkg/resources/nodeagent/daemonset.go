@@ -15,6 +15,7 @@
 package nodeagent
 
 import (
+	"context"
 	"crypto/sha256"
 	"fmt"
 	"strconv"
@@ -23,6 +24,7 @@ import (
 	"github.com/cisco-open/operator-tools/pkg/merge"
 	"github.com/cisco-open/operator-tools/pkg/reconciler"
 	util "github.com/cisco-open/operator-tools/pkg/utils"
+	"github.com/kube-logging/logging-operator/pkg/resources/pki"
 	"github.com/kube-logging/logging-operator/pkg/resources/templates"
 	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
 	appsv1 "k8s.io/api/apps/v1"
@@ -60,6 +62,16 @@ func (n *nodeAgentInstance) daemonSet() (runtime.Object, reconciler.DesiredState
 		}
 	}
 
+	if n.nodeAgent.FluentbitSpec.TLS != nil && util.PointerToBool(n.nodeAgent.FluentbitSpec.TLS.Enabled) {
+		checksum, err := pki.SecretChecksum(context.TODO(), n.reconciler.Client, n.logging, n.nodeAgent.FluentbitSpec.TLS.SecretName)
+		if err != nil {
+			return nil, nil, err
+		}
+		if checksum != "" {
+			podMeta = templates.Annotate(podMeta, pki.ChecksumAnnotation, checksum)
+		}
+	}
+
 	desired := &appsv1.DaemonSet{
 		ObjectMeta: meta,
 		Spec: appsv1.DaemonSetSpec{
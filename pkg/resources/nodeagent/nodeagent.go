@@ -49,7 +49,7 @@ const (
 	containerName                  = "fluent-bit"
 )
 
-func NodeAgentFluentbitDefaults(userDefined v1beta1.NodeAgentConfig) (*v1beta1.NodeAgentConfig, error) {
+func NodeAgentFluentbitDefaults(userDefined v1beta1.NodeAgentConfig, windows bool) (*v1beta1.NodeAgentConfig, error) {
 	programDefault := &v1beta1.NodeAgentConfig{
 		FluentbitSpec: &v1beta1.NodeAgentFluentbit{
 			DaemonSetOverrides: &typeoverride.DaemonSet{
@@ -134,7 +134,7 @@ func NodeAgentFluentbitDefaults(userDefined v1beta1.NodeAgentConfig) (*v1beta1.N
 
 	}
 	if userDefined.FluentbitSpec.LivenessDefaultCheck == nil || *userDefined.FluentbitSpec.LivenessDefaultCheck {
-		if userDefined.Profile != "windows" {
+		if !windows {
 			programDefault.FluentbitSpec.Metrics = &v1beta1.Metrics{
 				Port: 2020,
 				Path: "/",
@@ -269,15 +269,17 @@ type Reconciler struct {
 	*reconciler.GenericResourceReconciler
 	configs             map[string][]byte
 	agents              map[string]v1beta1.NodeAgentConfig
+	profiles            map[string]v1beta1.NodeAgentProfileSpec
 	fluentdDataProvider loggingdataprovider.LoggingDataProvider
 }
 
 // New creates a new NodeAgent reconciler
-func New(client client.Client, logger logr.Logger, logging *v1beta1.Logging, agents map[string]v1beta1.NodeAgentConfig, opts reconciler.ReconcilerOpts, fluentdDataProvider loggingdataprovider.LoggingDataProvider) *Reconciler {
+func New(client client.Client, logger logr.Logger, logging *v1beta1.Logging, agents map[string]v1beta1.NodeAgentConfig, profiles map[string]v1beta1.NodeAgentProfileSpec, opts reconciler.ReconcilerOpts, fluentdDataProvider loggingdataprovider.LoggingDataProvider) *Reconciler {
 	return &Reconciler{
 		Logging:                   logging,
 		GenericResourceReconciler: reconciler.NewGenericReconciler(client, logger, opts),
 		agents:                    agents,
+		profiles:                  profiles,
 		fluentdDataProvider:       fluentdDataProvider,
 	}
 }
@@ -303,32 +305,39 @@ func (r *Reconciler) Reconcile() (*reconcile.Result, error) {
 
 func (r *Reconciler) processAgent(name string, userDefinedAgent v1beta1.NodeAgentConfig) (*reconcile.Result, error) {
 	var instance nodeAgentInstance
-	NodeAgentFluentbitDefaults, err := NodeAgentFluentbitDefaults(userDefinedAgent)
+	profileDefaults, windows, err := r.resolveProfile(userDefinedAgent.Profile)
+	if err != nil {
+		return nil, errors.WrapIff(err, "node agent %s", name)
+	}
+
+	NodeAgentFluentbitDefaults, err := NodeAgentFluentbitDefaults(userDefinedAgent, windows)
 	if err != nil {
 		return nil, err
 	}
 
-	switch userDefinedAgent.Profile {
-	case "windows":
-		err := merge.Merge(NodeAgentFluentbitDefaults, NodeAgentFluentbitWindowsDefaults)
+	for i, defaults := range profileDefaults {
+		err := merge.Merge(NodeAgentFluentbitDefaults, defaults)
 		if err != nil {
 			return nil, err
 		}
-
-		// Overwrite Kubernetes endpoint with a ClusterDomain templated value.
-		NodeAgentFluentbitDefaults.FluentbitSpec.FilterKubernetes.KubeURL = fmt.Sprintf("https://kubernetes.default.svc%s:443", r.Logging.ClusterDomainAsSuffix())
-
-	default:
-		err := merge.Merge(NodeAgentFluentbitDefaults, NodeAgentFluentbitLinuxDefaults)
-		if err != nil {
-			return nil, err
+		if i == 0 && windows {
+			// Overwrite Kubernetes endpoint with a ClusterDomain templated value.
+			NodeAgentFluentbitDefaults.FluentbitSpec.FilterKubernetes.KubeURL = fmt.Sprintf("https://kubernetes.default.svc%s:443", r.Logging.ClusterDomainAsSuffix())
 		}
-
 	}
 	err = merge.Merge(NodeAgentFluentbitDefaults, &userDefinedAgent)
 	if err != nil {
 		return nil, err
 	}
+	if r.Logging.Spec.ManagedTLS != nil {
+		if NodeAgentFluentbitDefaults.FluentbitSpec.TLS == nil {
+			NodeAgentFluentbitDefaults.FluentbitSpec.TLS = &v1beta1.FluentbitTLS{}
+		}
+		if NodeAgentFluentbitDefaults.FluentbitSpec.TLS.SecretName == "" {
+			NodeAgentFluentbitDefaults.FluentbitSpec.TLS.Enabled = util.BoolPointer(true)
+			NodeAgentFluentbitDefaults.FluentbitSpec.TLS.SecretName = r.Logging.ManagedTLSAgentSecretName()
+		}
+	}
 
 	instance = nodeAgentInstance{
 		name:                name,
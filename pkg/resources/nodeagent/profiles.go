@@ -0,0 +1,116 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodeagent
+
+import (
+	"emperror.dev/errors"
+	util "github.com/cisco-open/operator-tools/pkg/utils"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+)
+
+const (
+	ProfileLinux           = "linux"
+	ProfileLinuxDocker     = "linux-docker"
+	ProfileLinuxContainerd = "linux-containerd"
+	ProfileK3s             = "k3s"
+	ProfileOpenShift       = "openshift"
+	ProfileBottlerocket    = "bottlerocket"
+	ProfileWindows         = "windows"
+
+	// maximum depth of the NodeAgentProfiles based on each other
+	maxProfileDepth = 10
+)
+
+// Profile is a named set of NodeAgent defaults for a node OS, distribution or container runtime
+type Profile struct {
+	// Defaults are merged over the program defaults, before the user-defined profiles and the NodeAgent itself
+	Defaults *v1beta1.NodeAgentConfig
+	// Windows profiles don't have the default liveness check and the Kubernetes API url is templated with the cluster domain
+	Windows bool
+}
+
+// the container logs are symlinked from /var/log/containers to /var/log/pods with CRI runtimes
+var criDefaults = v1beta1.NodeAgentFluentbit{
+	InputTail: v1beta1.InputTail{
+		Parser: "cri",
+	},
+	ContainersPath: "/var/log/pods",
+}
+
+var profiles = map[string]Profile{
+	ProfileLinux: {Defaults: NodeAgentFluentbitLinuxDefaults},
+	ProfileLinuxDocker: {Defaults: &v1beta1.NodeAgentConfig{
+		FluentbitSpec: &v1beta1.NodeAgentFluentbit{
+			InputTail: v1beta1.InputTail{
+				Parser: "docker",
+			},
+			ContainersPath: "/var/lib/docker/containers",
+		},
+	}},
+	ProfileLinuxContainerd: {Defaults: &v1beta1.NodeAgentConfig{
+		FluentbitSpec: criDefaults.DeepCopy(),
+	}},
+	// k3s runs an embedded containerd
+	ProfileK3s: {Defaults: &v1beta1.NodeAgentConfig{
+		FluentbitSpec: criDefaults.DeepCopy(),
+	}},
+	// the SELinux policies of OpenShift and Bottlerocket only allow privileged containers to read the host logs
+	ProfileOpenShift: {Defaults: &v1beta1.NodeAgentConfig{
+		FluentbitSpec: privileged(criDefaults.DeepCopy()),
+	}},
+	ProfileBottlerocket: {Defaults: &v1beta1.NodeAgentConfig{
+		FluentbitSpec: privileged(criDefaults.DeepCopy()),
+	}},
+	ProfileWindows: {Defaults: NodeAgentFluentbitWindowsDefaults, Windows: true},
+}
+
+func privileged(spec *v1beta1.NodeAgentFluentbit) *v1beta1.NodeAgentFluentbit {
+	spec.Security = &v1beta1.Security{
+		SecurityContext: &v1.SecurityContext{
+			Privileged: util.BoolPointer(true),
+		},
+	}
+	return spec
+}
+
+// resolveProfile returns the defaults of the profile in merge order: the built-in profile at the root first,
+// followed by the NodeAgentProfiles based on each other. The built-in profiles take precedence over the
+// NodeAgentProfiles with the same name. Unknown profiles get the linux defaults, like before the profiles were introduced.
+func (r *Reconciler) resolveProfile(name string) (defaults []*v1beta1.NodeAgentConfig, windows bool, err error) {
+	for depth := 0; ; depth++ {
+		if name == "" {
+			name = ProfileLinux
+		}
+		if profile, ok := profiles[name]; ok {
+			return append([]*v1beta1.NodeAgentConfig{profile.Defaults}, defaults...), profile.Windows, nil
+		}
+		if depth >= maxProfileDepth {
+			return nil, false, errors.Errorf("node agent profile %s is based on too many profiles, possibly a cycle", name)
+		}
+		profile, ok := r.profiles[name]
+		if !ok {
+			r.Log.Info("unknown node agent profile, falling back to the linux defaults", "profile", name)
+			name = ProfileLinux
+			continue
+		}
+		defaults = append([]*v1beta1.NodeAgentConfig{{
+			Metadata:      profile.Metadata,
+			FluentbitSpec: profile.FluentbitSpec,
+		}}, defaults...)
+		name = profile.BaseProfile
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodeagent
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cisco-open/operator-tools/pkg/reconciler"
+	"github.com/cisco-open/operator-tools/pkg/types"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+)
+
+func profileSpec(base string, annotation string) v1beta1.NodeAgentProfileSpec {
+	return v1beta1.NodeAgentProfileSpec{
+		BaseProfile: base,
+		Metadata:    types.MetaBase{Annotations: map[string]string{"profile": annotation}},
+	}
+}
+
+func profileDefaults(spec v1beta1.NodeAgentProfileSpec) *v1beta1.NodeAgentConfig {
+	return &v1beta1.NodeAgentConfig{Metadata: spec.Metadata, FluentbitSpec: spec.FluentbitSpec}
+}
+
+func TestResolveProfile(t *testing.T) {
+	userProfiles := map[string]v1beta1.NodeAgentProfileSpec{
+		"team":       profileSpec("hardened", "team"),
+		"hardened":   profileSpec(ProfileOpenShift, "hardened"),
+		"win-team":   profileSpec(ProfileWindows, "win-team"),
+		"default":    profileSpec("", "default"),
+		"orphan":     profileSpec("missing", "orphan"),
+		ProfileK3s:   profileSpec(ProfileLinuxDocker, "shadowed"),
+		"cycle-a":    profileSpec("cycle-b", "cycle-a"),
+		"cycle-b":    profileSpec("cycle-a", "cycle-b"),
+		"self":       profileSpec("self", "self"),
+		"chain-0":    profileSpec(ProfileLinux, "chain-0"),
+		"too-deep-0": profileSpec(ProfileLinux, "too-deep-0"),
+	}
+	for i := 1; i < maxProfileDepth; i++ {
+		userProfiles[fmt.Sprintf("chain-%d", i)] = profileSpec(fmt.Sprintf("chain-%d", i-1), fmt.Sprintf("chain-%d", i))
+	}
+	for i := 1; i <= maxProfileDepth; i++ {
+		userProfiles[fmt.Sprintf("too-deep-%d", i)] = profileSpec(fmt.Sprintf("too-deep-%d", i-1), fmt.Sprintf("too-deep-%d", i))
+	}
+	deepChain := []*v1beta1.NodeAgentConfig{profiles[ProfileLinux].Defaults}
+	for i := 0; i < maxProfileDepth; i++ {
+		deepChain = append(deepChain, profileDefaults(userProfiles[fmt.Sprintf("chain-%d", i)]))
+	}
+
+	tests := map[string]struct {
+		profile     string
+		want        []*v1beta1.NodeAgentConfig
+		wantWindows bool
+		wantErr     string
+	}{
+		"default profile": {
+			want: []*v1beta1.NodeAgentConfig{profiles[ProfileLinux].Defaults},
+		},
+		"built-in profile": {
+			profile: ProfileLinuxContainerd,
+			want:    []*v1beta1.NodeAgentConfig{profiles[ProfileLinuxContainerd].Defaults},
+		},
+		"built-in windows profile": {
+			profile:     ProfileWindows,
+			want:        []*v1beta1.NodeAgentConfig{profiles[ProfileWindows].Defaults},
+			wantWindows: true,
+		},
+		"unknown profile falls back to linux": {
+			profile: "missing",
+			want:    []*v1beta1.NodeAgentConfig{profiles[ProfileLinux].Defaults},
+		},
+		"profile without base": {
+			profile: "default",
+			want: []*v1beta1.NodeAgentConfig{
+				profiles[ProfileLinux].Defaults,
+				profileDefaults(userProfiles["default"]),
+			},
+		},
+		"base chain in merge order": {
+			profile: "team",
+			want: []*v1beta1.NodeAgentConfig{
+				profiles[ProfileOpenShift].Defaults,
+				profileDefaults(userProfiles["hardened"]),
+				profileDefaults(userProfiles["team"]),
+			},
+		},
+		"windows base profile": {
+			profile: "win-team",
+			want: []*v1beta1.NodeAgentConfig{
+				profiles[ProfileWindows].Defaults,
+				profileDefaults(userProfiles["win-team"]),
+			},
+			wantWindows: true,
+		},
+		"unknown base profile falls back to linux": {
+			profile: "orphan",
+			want: []*v1beta1.NodeAgentConfig{
+				profiles[ProfileLinux].Defaults,
+				profileDefaults(userProfiles["orphan"]),
+			},
+		},
+		"built-in profile shadows the node agent profile": {
+			profile: ProfileK3s,
+			want:    []*v1beta1.NodeAgentConfig{profiles[ProfileK3s].Defaults},
+		},
+		"chain of the maximum depth": {
+			profile: fmt.Sprintf("chain-%d", maxProfileDepth-1),
+			want:    deepChain,
+		},
+		"chain too deep": {
+			profile: fmt.Sprintf("too-deep-%d", maxProfileDepth),
+			wantErr: "node agent profile too-deep-0 is based on too many profiles, possibly a cycle",
+		},
+		"cycle": {
+			profile: "cycle-a",
+			wantErr: "node agent profile cycle-a is based on too many profiles, possibly a cycle",
+		},
+		"self reference": {
+			profile: "self",
+			wantErr: "node agent profile self is based on too many profiles, possibly a cycle",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			logging := &v1beta1.Logging{ObjectMeta: metav1.ObjectMeta{Name: "test"}}
+			r := New(nil, logr.Discard(), logging, nil, userProfiles, reconciler.ReconcilerOpts{}, nil)
+
+			defaults, windows, err := r.resolveProfile(test.profile)
+			if test.wantErr != "" {
+				require.EqualError(t, err, test.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.want, defaults)
+			require.Equal(t, test.wantWindows, windows)
+		})
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pki
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	"emperror.dev/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var certificateGVK = schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"}
+
+var certManagerUsages = map[x509.ExtKeyUsage]string{
+	x509.ExtKeyUsageServerAuth: "server auth",
+	x509.ExtKeyUsageClientAuth: "client auth",
+}
+
+// reconcileCertificates creates the cert-manager Certificates of the managed secrets,
+// the renewal of the certificates is left to cert-manager. The issued secrets are annotated
+// for the Secret watch of the logging controller, so the pods are rolled with the renewed certificates.
+func (r *Reconciler) reconcileCertificates(ctx context.Context) error {
+	r.nextRenewal = time.Time{}
+	for _, leaf := range r.leafCertificates() {
+		if err := r.applyCertificate(ctx, r.certificate(leaf)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Reconciler) certificate(leaf leafCertificate) *unstructured.Unstructured {
+	spec := r.logging.Spec.ManagedTLS
+	usages := []interface{}{"digital signature", "key encipherment"}
+	for _, usage := range leaf.usages {
+		usages = append(usages, certManagerUsages[usage])
+	}
+	certSpec := map[string]interface{}{
+		"secretName":  leaf.secretName,
+		"commonName":  leaf.commonName,
+		"duration":    spec.Duration.Duration.String(),
+		"renewBefore": spec.RenewBefore.Duration.String(),
+		"usages":      usages,
+		"privateKey": map[string]interface{}{
+			"algorithm":      "ECDSA",
+			"size":           int64(256),
+			"rotationPolicy": "Always",
+		},
+		"issuerRef": map[string]interface{}{
+			"name":  spec.CertManager.IssuerName,
+			"kind":  spec.CertManager.IssuerKind,
+			"group": spec.CertManager.IssuerGroup,
+		},
+		"secretTemplate": map[string]interface{}{
+			"annotations": map[string]interface{}{
+				r.secretWatchAnnotation(): "watched",
+			},
+		},
+	}
+	if len(leaf.dnsNames) > 0 {
+		dnsNames := make([]interface{}, 0, len(leaf.dnsNames))
+		for _, name := range leaf.dnsNames {
+			dnsNames = append(dnsNames, name)
+		}
+		certSpec["dnsNames"] = dnsNames
+	}
+
+	certificate := &unstructured.Unstructured{Object: map[string]interface{}{"spec": certSpec}}
+	certificate.SetGroupVersionKind(certificateGVK)
+	meta := r.objectMeta(leaf.secretName)
+	certificate.SetName(meta.Name)
+	certificate.SetNamespace(meta.Namespace)
+	certificate.SetLabels(meta.Labels)
+	certificate.SetOwnerReferences(meta.OwnerReferences)
+	return certificate
+}
+
+// secretWatchAnnotation returns the annotation that maps the changes of a secret to the logging
+func (r *Reconciler) secretWatchAnnotation() string {
+	loggingRef := r.logging.Spec.LoggingRef
+	if loggingRef == "" {
+		// the empty loggingRef cannot be used in the annotation
+		loggingRef = "default"
+	}
+	return fmt.Sprintf("logging.banzaicloud.io/%s", loggingRef)
+}
+
+func (r *Reconciler) applyCertificate(ctx context.Context, desired *unstructured.Unstructured) error {
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(certificateGVK)
+	err := r.client.Get(ctx, types.NamespacedName{Namespace: desired.GetNamespace(), Name: desired.GetName()}, current)
+	if apierrors.IsNotFound(err) {
+		return errors.WrapIff(r.client.Create(ctx, desired), "failed to create certificate %s", desired.GetName())
+	}
+	if err != nil {
+		return errors.WrapIff(err, "failed to get certificate %s (is cert-manager installed?)", desired.GetName())
+	}
+	desired.SetResourceVersion(current.GetResourceVersion())
+	return errors.WrapIff(r.client.Update(ctx, desired), "failed to update certificate %s", desired.GetName())
+}
@@ -0,0 +1,174 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pki
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"time"
+
+	"emperror.dev/errors"
+)
+
+// keyPair is a certificate with its private key
+type keyPair struct {
+	Cert    *x509.Certificate
+	CertPEM []byte
+	KeyPEM  []byte
+}
+
+func newSerialNumber() (*big.Int, error) {
+	return rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+}
+
+func generateCA(commonName string, notBefore, notAfter time.Time) (*keyPair, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to generate CA key")
+	}
+	serial, err := newSerialNumber()
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to generate serial number")
+	}
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to create CA certificate")
+	}
+	return newKeyPair(der, key)
+}
+
+func issueCertificate(ca *keyPair, commonName string, dnsNames []string, usages []x509.ExtKeyUsage, notBefore, notAfter time.Time) (*keyPair, error) {
+	caKey, err := parsePrivateKey(ca.KeyPEM)
+	if err != nil {
+		return nil, err
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to generate key")
+	}
+	serial, err := newSerialNumber()
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to generate serial number")
+	}
+	if notAfter.After(ca.Cert.NotAfter) {
+		notAfter = ca.Cert.NotAfter
+	}
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     dnsNames,
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  usages,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.Cert, &key.PublicKey, caKey)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to create certificate")
+	}
+	return newKeyPair(der, key)
+}
+
+func newKeyPair(der []byte, key *ecdsa.PrivateKey) (*keyPair, error) {
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to parse the created certificate")
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to marshal key")
+	}
+	return &keyPair{
+		Cert:    cert,
+		CertPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		KeyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}, nil
+}
+
+func parseKeyPair(certPEM, keyPEM []byte) (*keyPair, error) {
+	cert, err := parseCertificate(certPEM)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := parsePrivateKey(keyPEM); err != nil {
+		return nil, err
+	}
+	return &keyPair{
+		Cert:    cert,
+		CertPEM: certPEM,
+		KeyPEM:  keyPEM,
+	}, nil
+}
+
+func parseCertificate(certPEM []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("failed to decode certificate PEM")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+func parsePrivateKey(keyPEM []byte) (*ecdsa.PrivateKey, error) {
+	block, _ := pem.Decode(keyPEM)
+	if block == nil || block.Type != "EC PRIVATE KEY" {
+		return nil, errors.New("failed to decode private key PEM")
+	}
+	return x509.ParseECPrivateKey(block.Bytes)
+}
+
+// needsRenewal checks whether the certificate has to be reissued by the CA
+func needsRenewal(cert *x509.Certificate, ca *x509.Certificate, dnsNames []string, renewAt time.Time) bool {
+	if cert.CheckSignatureFrom(ca) != nil {
+		return true
+	}
+	if !cert.NotAfter.After(renewAt) {
+		return true
+	}
+	if len(cert.DNSNames) != len(dnsNames) {
+		return true
+	}
+	for i := range dnsNames {
+		if cert.DNSNames[i] != dnsNames[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// bundle concatenates the PEM encoded certificates that are not expired yet
+func bundle(now time.Time, certs ...[]byte) []byte {
+	var b bytes.Buffer
+	for _, certPEM := range certs {
+		if cert, err := parseCertificate(certPEM); err == nil && cert.NotAfter.After(now) {
+			b.Write(certPEM)
+		}
+	}
+	return b.Bytes()
+}
@@ -0,0 +1,318 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pki
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"fmt"
+	"sort"
+	"time"
+
+	"emperror.dev/errors"
+	util "github.com/cisco-open/operator-tools/pkg/utils"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+)
+
+const (
+	// ChecksumAnnotation is added to the pod templates of the workloads mounting a managed TLS secret
+	ChecksumAnnotation = "checksum/managed-tls"
+
+	component         = "managed-tls"
+	caCertKey         = "ca.crt"
+	caKeyKey          = "ca.key"
+	previousCACertKey = "ca-previous.crt"
+	caDuration        = 10 * 365 * 24 * time.Hour
+	// backdate the certificates to tolerate clock skew between the nodes
+	clockSkew = 5 * time.Minute
+
+	// service names of the aggregators, see fluentd.ServiceName and syslogng.ServiceName
+	fluentdServiceName  = "fluentd"
+	syslogNGServiceName = "syslog-ng"
+)
+
+// Reconciler issues and rotates the certificates of the mutual TLS connections between the agents and the aggregators
+type Reconciler struct {
+	client      client.Client
+	logger      logr.Logger
+	logging     *v1beta1.Logging
+	now         func() time.Time
+	nextRenewal time.Time
+}
+
+type leafCertificate struct {
+	secretName string
+	commonName string
+	dnsNames   []string
+	usages     []x509.ExtKeyUsage
+}
+
+func New(client client.Client, logger logr.Logger, logging *v1beta1.Logging) *Reconciler {
+	return &Reconciler{
+		client:  client,
+		logger:  logger.WithName("managed-tls"),
+		logging: logging,
+		now:     time.Now,
+	}
+}
+
+// Reconcile issues the certificates with the CA generated by the operator or creates the cert-manager Certificates
+func (r *Reconciler) Reconcile() (*reconcile.Result, error) {
+	if r.logging.Spec.ManagedTLS == nil {
+		return nil, nil
+	}
+	ctx := context.TODO()
+	if r.logging.Spec.ManagedTLS.CertManager != nil {
+		return nil, r.reconcileCertificates(ctx)
+	}
+	return nil, r.reconcileSecrets(ctx)
+}
+
+// RequeueAfter returns the time until the next certificate issued by the operator has to be renewed, zero if there is none
+func (r *Reconciler) RequeueAfter() time.Duration {
+	if r.nextRenewal.IsZero() {
+		return 0
+	}
+	if d := r.nextRenewal.Sub(r.now()); d > 0 {
+		return d
+	}
+	return time.Second
+}
+
+func (r *Reconciler) leafCertificates() []leafCertificate {
+	return []leafCertificate{
+		{
+			secretName: r.logging.ManagedTLSAggregatorSecretName(),
+			commonName: r.logging.QualifiedName("aggregator"),
+			dnsNames:   r.aggregatorDNSNames(),
+			usages:     []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		},
+		{
+			secretName: r.logging.ManagedTLSAgentSecretName(),
+			commonName: r.logging.QualifiedName("agent"),
+			usages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		},
+	}
+}
+
+// aggregatorDNSNames returns the names of the services and the pods of the aggregator
+func (r *Reconciler) aggregatorDNSNames() (names []string) {
+	var services []string
+	if r.logging.Spec.FluentdSpec != nil {
+		services = append(services, r.logging.QualifiedName(fluentdServiceName))
+	}
+	if r.logging.Spec.SyslogNGSpec != nil {
+		services = append(services, r.logging.QualifiedName(syslogNGServiceName))
+	}
+	namespace := r.logging.Spec.ControlNamespace
+	for _, service := range services {
+		names = append(names,
+			service,
+			fmt.Sprintf("%s.%s", service, namespace),
+			fmt.Sprintf("%s.%s.svc", service, namespace),
+			fmt.Sprintf("%s.%s.svc%s", service, namespace, r.logging.ClusterDomainAsSuffix()),
+			fmt.Sprintf("*.%s-headless.%s.svc%s", service, namespace, r.logging.ClusterDomainAsSuffix()),
+		)
+	}
+	return
+}
+
+func (r *Reconciler) reconcileSecrets(ctx context.Context) error {
+	now := r.now()
+	duration := r.logging.Spec.ManagedTLS.Duration.Duration
+	renewBefore := r.logging.Spec.ManagedTLS.RenewBefore.Duration
+	r.nextRenewal = time.Time{}
+
+	var ca *keyPair
+	var previousCA []byte
+	caSecret, err := r.getSecret(ctx, r.logging.ManagedTLSCASecretName())
+	if err != nil {
+		return err
+	}
+	if caSecret != nil {
+		previousCA = caSecret.Data[previousCACertKey]
+		if ca, err = parseKeyPair(caSecret.Data[caCertKey], caSecret.Data[caKeyKey]); err != nil {
+			r.logger.Error(err, "invalid managed CA, generating a new one")
+			ca = nil
+		}
+	}
+
+	// the CA has to outlive the certificates issued now, the previous CA is trusted until it expires
+	if ca == nil || ca.Cert.NotAfter.Before(now.Add(duration)) {
+		if ca != nil {
+			previousCA = ca.CertPEM
+		}
+		ca, err = generateCA(r.logging.QualifiedName("managed-tls-ca"), now.Add(-clockSkew), now.Add(caDuration))
+		if err != nil {
+			return err
+		}
+		r.logger.Info("generated managed CA", "notAfter", ca.Cert.NotAfter)
+	}
+	previousCA = bundle(now, previousCA)
+	if err := r.writeSecret(ctx, r.logging.ManagedTLSCASecretName(), corev1.SecretTypeOpaque, map[string][]byte{
+		caCertKey:         ca.CertPEM,
+		caKeyKey:          ca.KeyPEM,
+		previousCACertKey: previousCA,
+	}); err != nil {
+		return err
+	}
+
+	trustBundle := bundle(now, ca.CertPEM, previousCA)
+	for _, leaf := range r.leafCertificates() {
+		if err := r.reconcileLeaf(ctx, leaf, ca, trustBundle, now, duration, renewBefore); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Reconciler) reconcileLeaf(ctx context.Context, leaf leafCertificate, ca *keyPair, trustBundle []byte, now time.Time, duration, renewBefore time.Duration) error {
+	secret, err := r.getSecret(ctx, leaf.secretName)
+	if err != nil {
+		return err
+	}
+
+	var current *keyPair
+	if secret != nil {
+		if current, err = parseKeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey]); err != nil {
+			r.logger.Info("invalid managed certificate, issuing a new one", "secret", leaf.secretName, "error", err.Error())
+			current = nil
+		}
+	}
+
+	if current == nil || needsRenewal(current.Cert, ca.Cert, leaf.dnsNames, now.Add(renewBefore)) {
+		current, err = issueCertificate(ca, leaf.commonName, leaf.dnsNames, leaf.usages, now.Add(-clockSkew), now.Add(duration))
+		if err != nil {
+			return errors.WrapIff(err, "failed to issue the certificate of %s", leaf.secretName)
+		}
+		r.logger.Info("issued managed certificate", "secret", leaf.secretName, "notAfter", current.Cert.NotAfter)
+	}
+
+	renewAt := current.Cert.NotAfter.Add(-renewBefore)
+	if r.nextRenewal.IsZero() || renewAt.Before(r.nextRenewal) {
+		r.nextRenewal = renewAt
+	}
+
+	return r.writeSecret(ctx, leaf.secretName, corev1.SecretTypeTLS, map[string][]byte{
+		caCertKey:               trustBundle,
+		corev1.TLSCertKey:       current.CertPEM,
+		corev1.TLSPrivateKeyKey: current.KeyPEM,
+	})
+}
+
+func (r *Reconciler) getSecret(ctx context.Context, name string) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	err := r.client.Get(ctx, types.NamespacedName{Namespace: r.logging.Spec.ControlNamespace, Name: name}, secret)
+	if client.IgnoreNotFound(err) != nil {
+		return nil, errors.WrapIff(err, "failed to get secret %s", name)
+	}
+	if err != nil {
+		return nil, nil
+	}
+	return secret, nil
+}
+
+// writeSecret creates the secret or updates it if the data has changed
+func (r *Reconciler) writeSecret(ctx context.Context, name string, secretType corev1.SecretType, data map[string][]byte) error {
+	existing, err := r.getSecret(ctx, name)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		secret := &corev1.Secret{
+			ObjectMeta: r.objectMeta(name),
+			Type:       secretType,
+			Data:       data,
+		}
+		return errors.WrapIff(r.client.Create(ctx, secret), "failed to create secret %s", name)
+	}
+	if equalData(existing.Data, data) {
+		return nil
+	}
+	existing.Data = data
+	return errors.WrapIff(r.client.Update(ctx, existing), "failed to update secret %s", name)
+}
+
+func (r *Reconciler) objectMeta(name string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      name,
+		Namespace: r.logging.Spec.ControlNamespace,
+		Labels: map[string]string{
+			"app.kubernetes.io/managed-by": r.logging.Name,
+			"app.kubernetes.io/component":  component,
+		},
+		OwnerReferences: []metav1.OwnerReference{
+			{
+				APIVersion: r.logging.APIVersion,
+				Kind:       r.logging.Kind,
+				Name:       r.logging.Name,
+				UID:        r.logging.UID,
+				Controller: util.BoolPointer(true),
+			},
+		},
+	}
+}
+
+func equalData(a, b map[string][]byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, value := range a {
+		if !bytes.Equal(value, b[key]) {
+			return false
+		}
+	}
+	return true
+}
+
+// SecretChecksum returns the checksum of a managed TLS secret, it is added to the pod templates of the workloads
+// mounting the secret, so the pods are restarted when the certificates are rotated.
+// It returns an empty string for the secrets that are not managed.
+func SecretChecksum(ctx context.Context, c client.Reader, logging *v1beta1.Logging, secretName string) (string, error) {
+	if logging.Spec.ManagedTLS == nil {
+		return "", nil
+	}
+	if secretName != logging.ManagedTLSAggregatorSecretName() && secretName != logging.ManagedTLSAgentSecretName() {
+		return "", nil
+	}
+	secret := &corev1.Secret{}
+	err := c.Get(ctx, types.NamespacedName{Namespace: logging.Spec.ControlNamespace, Name: secretName}, secret)
+	if client.IgnoreNotFound(err) != nil {
+		return "", errors.WrapIff(err, "failed to get secret %s", secretName)
+	}
+	if err != nil {
+		return "", nil
+	}
+	keys := make([]string, 0, len(secret.Data))
+	for key := range secret.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	h := sha256.New()
+	for _, key := range keys {
+		_, _ = h.Write([]byte(key))
+		_, _ = h.Write(secret.Data[key])
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
@@ -0,0 +1,234 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pki
+
+import (
+	"context"
+	"crypto/x509"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+)
+
+func TestNeedsRenewal(t *testing.T) {
+	now := time.Now()
+	ca, err := generateCA("ca", now, now.Add(time.Hour*24*365))
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherCA, err := generateCA("other", now, now.Add(time.Hour*24*365))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := issueCertificate(ca, "leaf", []string{"a", "b"}, []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}, now, now.Add(time.Hour*24*30))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		ca       *keyPair
+		dnsNames []string
+		renewAt  time.Time
+		want     bool
+	}{
+		{name: "valid", ca: ca, dnsNames: []string{"a", "b"}, renewAt: now.Add(time.Hour * 24 * 20)},
+		{name: "expiring", ca: ca, dnsNames: []string{"a", "b"}, renewAt: now.Add(time.Hour * 24 * 40), want: true},
+		{name: "dns names changed", ca: ca, dnsNames: []string{"a"}, renewAt: now, want: true},
+		{name: "issued by another CA", ca: otherCA, dnsNames: []string{"a", "b"}, renewAt: now, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := needsRenewal(cert.Cert, tt.ca.Cert, tt.dnsNames, tt.renewAt); got != tt.want {
+				t.Errorf("needsRenewal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReconcileSecrets(t *testing.T) {
+	sch := runtime.NewScheme()
+	if err := scheme.AddToScheme(sch); err != nil {
+		t.Fatal(err)
+	}
+	c := fake.NewClientBuilder().WithScheme(sch).Build()
+	logging := &v1beta1.Logging{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: v1beta1.LoggingSpec{
+			ControlNamespace: "logging",
+			FluentdSpec:      &v1beta1.FluentdSpec{},
+			ManagedTLS:       &v1beta1.ManagedTLS{},
+		},
+	}
+	if err := logging.SetDefaults(); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	r := New(c, log.Log, logging)
+	r.now = func() time.Time { return now }
+	if _, err := r.Reconcile(); err != nil {
+		t.Fatal(err)
+	}
+
+	getSecret := func(name string) *corev1.Secret {
+		secret := &corev1.Secret{}
+		if err := c.Get(context.TODO(), types.NamespacedName{Namespace: "logging", Name: name}, secret); err != nil {
+			t.Fatal(err)
+		}
+		return secret
+	}
+	verify := func(secretName string, usage x509.ExtKeyUsage, dnsName string) *x509.Certificate {
+		secret := getSecret(secretName)
+		cert, err := parseCertificate(secret.Data[corev1.TLSCertKey])
+		if err != nil {
+			t.Fatal(err)
+		}
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(secret.Data[caCertKey]) {
+			t.Fatal("invalid CA bundle")
+		}
+		if _, err := cert.Verify(x509.VerifyOptions{Roots: roots, DNSName: dnsName, KeyUsages: []x509.ExtKeyUsage{usage}, CurrentTime: now}); err != nil {
+			t.Errorf("%s: %v", secretName, err)
+		}
+		return cert
+	}
+	aggregator := verify(logging.ManagedTLSAggregatorSecretName(), x509.ExtKeyUsageServerAuth, "test-fluentd.logging.svc.cluster.local")
+	verify(logging.ManagedTLSAgentSecretName(), x509.ExtKeyUsageClientAuth, "")
+
+	if want := aggregator.NotAfter.Add(-v1beta1.DefaultManagedTLSRenewBefore).Sub(now); r.RequeueAfter() != want {
+		t.Errorf("RequeueAfter() = %v, want %v", r.RequeueAfter(), want)
+	}
+
+	checksum, err := SecretChecksum(context.TODO(), c, logging, logging.ManagedTLSAggregatorSecretName())
+	if err != nil || checksum == "" {
+		t.Fatalf("SecretChecksum() = %q, %v", checksum, err)
+	}
+
+	// nothing changes until the renewal time
+	if _, err := r.Reconcile(); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := SecretChecksum(context.TODO(), c, logging, logging.ManagedTLSAggregatorSecretName()); got != checksum {
+		t.Error("certificate reissued before the renewal time")
+	}
+
+	now = now.Add(r.RequeueAfter())
+	if _, err := r.Reconcile(); err != nil {
+		t.Fatal(err)
+	}
+	renewed := verify(logging.ManagedTLSAggregatorSecretName(), x509.ExtKeyUsageServerAuth, "test-fluentd.logging.svc.cluster.local")
+	if !renewed.NotAfter.After(aggregator.NotAfter) {
+		t.Error("certificate not renewed")
+	}
+}
+
+func TestReconcileCertificates(t *testing.T) {
+	sch := runtime.NewScheme()
+	if err := scheme.AddToScheme(sch); err != nil {
+		t.Fatal(err)
+	}
+	c := fake.NewClientBuilder().WithScheme(sch).Build()
+	logging := &v1beta1.Logging{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: v1beta1.LoggingSpec{
+			LoggingRef:       "infra",
+			ControlNamespace: "logging",
+			SyslogNGSpec:     &v1beta1.SyslogNGSpec{},
+			ManagedTLS: &v1beta1.ManagedTLS{
+				CertManager: &v1beta1.ManagedTLSCertManager{IssuerName: "ca", IssuerKind: "ClusterIssuer", IssuerGroup: "cert-manager.io"},
+			},
+		},
+	}
+	if err := logging.SetDefaults(); err != nil {
+		t.Fatal(err)
+	}
+
+	r := New(c, log.Log, logging)
+	// reconciling again updates the existing certificates
+	for i := 0; i < 2; i++ {
+		if _, err := r.Reconcile(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// the renewal is left to cert-manager
+	if r.RequeueAfter() != 0 {
+		t.Errorf("RequeueAfter() = %v, want 0", r.RequeueAfter())
+	}
+
+	getCertificate := func(name string) *unstructured.Unstructured {
+		certificate := &unstructured.Unstructured{}
+		certificate.SetGroupVersionKind(certificateGVK)
+		if err := c.Get(context.TODO(), types.NamespacedName{Namespace: "logging", Name: name}, certificate); err != nil {
+			t.Fatal(err)
+		}
+		return certificate
+	}
+	tests := []struct {
+		secretName string
+		usages     []string
+		dnsNames   []string
+	}{
+		{
+			secretName: logging.ManagedTLSAggregatorSecretName(),
+			usages:     []string{"digital signature", "key encipherment", "server auth"},
+			dnsNames: []string{
+				"test-syslog-ng",
+				"test-syslog-ng.logging",
+				"test-syslog-ng.logging.svc",
+				"test-syslog-ng.logging.svc.cluster.local",
+				"*.test-syslog-ng-headless.logging.svc.cluster.local",
+			},
+		},
+		{
+			secretName: logging.ManagedTLSAgentSecretName(),
+			usages:     []string{"digital signature", "key encipherment", "client auth"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.secretName, func(t *testing.T) {
+			certificate := getCertificate(tt.secretName)
+			if secretName, _, _ := unstructured.NestedString(certificate.Object, "spec", "secretName"); secretName != tt.secretName {
+				t.Errorf("secretName = %v, want %v", secretName, tt.secretName)
+			}
+			if usages, _, _ := unstructured.NestedStringSlice(certificate.Object, "spec", "usages"); !reflect.DeepEqual(usages, tt.usages) {
+				t.Errorf("usages = %v, want %v", usages, tt.usages)
+			}
+			if dnsNames, _, _ := unstructured.NestedStringSlice(certificate.Object, "spec", "dnsNames"); !reflect.DeepEqual(dnsNames, tt.dnsNames) {
+				t.Errorf("dnsNames = %v, want %v", dnsNames, tt.dnsNames)
+			}
+			issuer, _, _ := unstructured.NestedStringMap(certificate.Object, "spec", "issuerRef")
+			if want := map[string]string{"name": "ca", "kind": "ClusterIssuer", "group": "cert-manager.io"}; !reflect.DeepEqual(issuer, want) {
+				t.Errorf("issuerRef = %v, want %v", issuer, want)
+			}
+			// the renewed secrets have to trigger the reconcile of the logging to roll the pods
+			annotations, _, _ := unstructured.NestedStringMap(certificate.Object, "spec", "secretTemplate", "annotations")
+			if want := map[string]string{"logging.banzaicloud.io/infra": "watched"}; !reflect.DeepEqual(annotations, want) {
+				t.Errorf("secretTemplate annotations = %v, want %v", annotations, want)
+			}
+		})
+	}
+}
@@ -18,4 +18,5 @@ const (
 	ComponentSyslogNG    = "syslog-ng"
 	ComponentConfigCheck = "syslog-ng-configcheck"
 	ComponentPlaceholder = "syslog-ng-placeholder"
+	ComponentDrainer     = "syslog-ng-drainer"
 )
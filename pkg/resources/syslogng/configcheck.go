@@ -229,10 +229,14 @@ func (r *Reconciler) newCheckPod(hashKey string) (*corev1.Pod, error) {
 		pod.Spec.Volumes = append(pod.Spec.Volumes, tlsVolume)
 		volumeMount := corev1.VolumeMount{
 			Name:      "syslog-ng-tls",
-			MountPath: "/syslog-ng/tls/",
+			MountPath: v1beta1.SyslogNGTLSDir,
 		}
 		pod.Spec.Containers[0].VolumeMounts = append(pod.Spec.Containers[0].VolumeMounts, volumeMount)
 	}
+	pod.Spec.Volumes = append(pod.Spec.Volumes, patternDBVolumes(r.Logging.Spec.SyslogNGSpec)...)
+	pod.Spec.Containers[0].VolumeMounts = append(pod.Spec.Containers[0].VolumeMounts, patternDBVolumeMounts(r.Logging.Spec.SyslogNGSpec)...)
+	pod.Spec.Volumes = append(pod.Spec.Volumes, networkSourceTLSVolumes(r.Logging.Spec.SyslogNGSpec)...)
+	pod.Spec.Containers[0].VolumeMounts = append(pod.Spec.Containers[0].VolumeMounts, networkSourceTLSVolumeMounts(r.Logging.Spec.SyslogNGSpec)...)
 
 	err := merge.Merge(&pod.Spec, r.Logging.Spec.SyslogNGSpec.ConfigCheckPodOverrides)
 
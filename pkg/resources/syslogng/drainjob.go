@@ -0,0 +1,106 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogng
+
+import (
+	"strings"
+
+	"emperror.dev/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kube-logging/logging-operator/pkg/resources/kubetool"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+)
+
+// drainWatchScript waits until the destinations have no queued messages left and stops syslog-ng
+const drainWatchScript = `ctl="syslog-ng-ctl --control=` + socketPath + `"
+until $ctl stats >/dev/null 2>&1; do sleep 1; done
+idle=0
+while [ "$idle" -lt 2 ]; do
+  sleep 5
+  queued=$($ctl stats | awk -F';' '$5 == "queued" { sum += $6 } END { print sum + 0 }')
+  if [ "$queued" -eq 0 ]; then idle=$((idle + 1)); else idle=0; fi
+done
+$ctl stop
+`
+
+func (r *Reconciler) drainerJobFor(pvc corev1.PersistentVolumeClaim) (*batchv1.Job, error) {
+	obj, _, err := r.statefulset()
+	if err != nil {
+		return nil, err
+	}
+	podSpec := obj.(*appsv1.StatefulSet).Spec.Template.Spec.DeepCopy()
+
+	bufVolName := r.bufferVolumeName()
+	syslogngContainer := kubetool.FindContainerByName(podSpec.Containers, ContainerName)
+	if syslogngContainer == nil {
+		return nil, errors.New("syslog-ng container not found")
+	}
+	if kubetool.FindVolumeMountByName(syslogngContainer.VolumeMounts, bufVolName) == nil {
+		return nil, errors.NewWithDetails("buffer volume is not mounted into the syslog-ng container", "volume", bufVolName)
+	}
+	// the drainer only flushes the buffers, it does not receive new messages
+	syslogngContainer.LivenessProbe = nil
+	syslogngContainer.ReadinessProbe = nil
+
+	podSpec.Containers = []corev1.Container{
+		*syslogngContainer,
+		drainWatchContainer(&r.Logging.Spec.SyslogNGSpec.Scaling.Drain),
+	}
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: bufVolName,
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: pvc.Name,
+			},
+		},
+	})
+	podSpec.RestartPolicy = corev1.RestartPolicyNever
+
+	return &batchv1.Job{
+		ObjectMeta: r.SyslogNGObjectMeta(StatefulSetName+pvc.Name[strings.LastIndex(pvc.Name, "-"):]+"-drainer", ComponentDrainer),
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      r.Logging.GetSyslogNGLabels(ComponentDrainer),
+					Annotations: r.Logging.Spec.SyslogNGSpec.Scaling.Drain.Annotations,
+				},
+				Spec: *podSpec,
+			},
+		},
+	}, nil
+}
+
+func drainWatchContainer(cfg *v1beta1.SyslogNGDrainConfig) corev1.Container {
+	image := v1beta1.RepositoryWithTag(syslogngImageRepository, syslogngImageTag)
+	if cfg.Image.Repository != "" {
+		image = cfg.Image.RepositoryWithTag()
+	}
+	return corev1.Container{
+		Name:            "drain-watch",
+		Image:           image,
+		ImagePullPolicy: corev1.PullPolicy(cfg.Image.PullPolicy),
+		Command:         []string{"/bin/sh", "-c", drainWatchScript},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      socketVolumeName,
+				MountPath: "/tmp/syslog-ng",
+			},
+		},
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogng
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cisco-open/operator-tools/pkg/reconciler"
+	"github.com/cisco-open/operator-tools/pkg/volume"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+)
+
+func newTestReconciler(t *testing.T, spec v1beta1.SyslogNGSpec, objs ...client.Object) *Reconciler {
+	logging := &v1beta1.Logging{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: v1beta1.LoggingSpec{
+			ControlNamespace: "logging",
+			SyslogNGSpec:     &spec,
+		},
+	}
+	require.NoError(t, logging.SetDefaults())
+
+	sch := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(sch))
+	require.NoError(t, v1beta1.AddToScheme(sch))
+	c := fake.NewClientBuilder().WithScheme(sch).WithObjects(objs...).Build()
+
+	return New(c, logr.Discard(), logging, "", nil, reconciler.ReconcilerOpts{})
+}
+
+func drainSpec() v1beta1.SyslogNGSpec {
+	return v1beta1.SyslogNGSpec{
+		Scaling: &v1beta1.SyslogNGScaling{
+			Drain: v1beta1.SyslogNGDrainConfig{Enabled: true},
+		},
+		BufferStorageVolume: volume.KubernetesVolume{
+			PersistentVolumeClaim: &volume.PersistentVolumeClaim{},
+		},
+	}
+}
+
+func bufferPVC(name string, labels map[string]string) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "logging", Labels: labels},
+	}
+}
+
+func TestDrainerJobFor(t *testing.T) {
+	r := newTestReconciler(t, drainSpec())
+
+	job, err := r.drainerJobFor(*bufferPVC("test-syslog-ng-buffer-test-syslog-ng-2", nil))
+	require.NoError(t, err)
+
+	require.Equal(t, "test-syslog-ng-2-drainer", job.Name)
+	require.Equal(t, "logging", job.Namespace)
+	require.Equal(t, r.Logging.GetSyslogNGLabels(ComponentDrainer), job.Spec.Template.Labels)
+
+	podSpec := job.Spec.Template.Spec
+	require.Equal(t, corev1.RestartPolicyNever, podSpec.RestartPolicy)
+	require.Len(t, podSpec.Containers, 2)
+	require.Equal(t, ContainerName, podSpec.Containers[0].Name)
+	require.Nil(t, podSpec.Containers[0].LivenessProbe)
+	require.Nil(t, podSpec.Containers[0].ReadinessProbe)
+	require.Equal(t, "drain-watch", podSpec.Containers[1].Name)
+
+	var claims []string
+	for _, v := range podSpec.Volumes {
+		if v.Name == r.bufferVolumeName() && v.PersistentVolumeClaim != nil {
+			claims = append(claims, v.PersistentVolumeClaim.ClaimName)
+		}
+	}
+	require.Equal(t, []string{"test-syslog-ng-buffer-test-syslog-ng-2"}, claims)
+}
+
+func TestPlaceholderPodFor(t *testing.T) {
+	r := newTestReconciler(t, drainSpec())
+
+	pod := r.placeholderPodFor(*bufferPVC("test-syslog-ng-buffer-test-syslog-ng-2", nil))
+
+	require.Equal(t, "test-syslog-ng-2", pod.Name)
+	require.Equal(t, r.Logging.GetSyslogNGLabels(ComponentPlaceholder), pod.Labels)
+	require.Len(t, pod.Spec.Containers, 1)
+	require.Equal(t, v1beta1.DefaultSyslogNGDrainPauseImageRepository+":"+v1beta1.DefaultSyslogNGDrainPauseImageTag, pod.Spec.Containers[0].Image)
+	require.Equal(t, corev1.RestartPolicyNever, pod.Spec.RestartPolicy)
+}
+
+func TestReconcileDrain_PVCMatching(t *testing.T) {
+	r := newTestReconciler(t, drainSpec(),
+		// left behind by a scale down, no labels at all
+		bufferPVC("test-syslog-ng-buffer-test-syslog-ng-1", nil),
+		// already drained
+		bufferPVC("test-syslog-ng-buffer-test-syslog-ng-2", map[string]string{drainStatusLabelKey: drainStatusLabelValue}),
+		// opted out of draining
+		bufferPVC("test-syslog-ng-buffer-test-syslog-ng-3", map[string]string{"logging.banzaicloud.io/drain": "no"}),
+		// not a buffer volume of the statefulset
+		bufferPVC("test-fluentd-buffer-test-fluentd-1", nil),
+	)
+
+	_, err := r.reconcileDrain(context.TODO())
+	require.NoError(t, err)
+
+	var jobs batchv1.JobList
+	require.NoError(t, r.Client.List(context.TODO(), &jobs, client.InNamespace("logging")))
+	var jobNames []string
+	for _, job := range jobs.Items {
+		jobNames = append(jobNames, job.Name)
+	}
+	require.Equal(t, []string{"test-syslog-ng-1-drainer"}, jobNames)
+
+	var pods corev1.PodList
+	require.NoError(t, r.Client.List(context.TODO(), &pods, client.InNamespace("logging")))
+	require.Len(t, pods.Items, 1)
+	require.Equal(t, "test-syslog-ng-1", pods.Items[0].Name)
+}
+
+func TestReconcileDrain_MarksDrainedPVC(t *testing.T) {
+	r := newTestReconciler(t, drainSpec())
+	pvc := bufferPVC("test-syslog-ng-buffer-test-syslog-ng-1", nil)
+	job, err := r.drainerJobFor(*pvc)
+	require.NoError(t, err)
+	now := metav1.Now()
+	job.Status.CompletionTime = &now
+	job.Status.Succeeded = 1
+	require.NoError(t, r.Client.Create(context.TODO(), pvc))
+	require.NoError(t, r.Client.Create(context.TODO(), job))
+
+	_, err = r.reconcileDrain(context.TODO())
+	require.NoError(t, err)
+
+	require.NoError(t, r.Client.Get(context.TODO(), client.ObjectKeyFromObject(pvc), pvc))
+	require.Equal(t, drainStatusLabelValue, pvc.Labels[drainStatusLabelKey])
+}
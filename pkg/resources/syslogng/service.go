@@ -46,6 +46,26 @@ func (r *Reconciler) service() (runtime.Object, reconciler.DesiredState, error)
 			Type:     corev1.ServiceTypeClusterIP,
 		},
 	}
+	if otlp := r.Logging.Spec.SyslogNGSpec.OTLPSource; otlp != nil {
+		desired.Spec.Ports = append(desired.Spec.Ports, corev1.ServicePort{
+			Name:       OTLPPortName,
+			Protocol:   corev1.ProtocolTCP,
+			Port:       otlp.Port,
+			TargetPort: intstr.IntOrString{IntVal: otlp.Port},
+		})
+	}
+	for _, source := range r.Logging.Spec.SyslogNGSpec.NetworkSources {
+		if hasServicePort(desired.Spec.Ports, source.Port, networkSourceProtocol(source)) {
+			// the default udp port already forwards to the listener, other clashing ports are rejected when the config is rendered
+			continue
+		}
+		desired.Spec.Ports = append(desired.Spec.Ports, corev1.ServicePort{
+			Name:       "syslog-" + source.Name,
+			Protocol:   networkSourceProtocol(source),
+			Port:       source.Port,
+			TargetPort: intstr.IntOrString{IntVal: source.Port},
+		})
+	}
 
 	beforeUpdateHook := reconciler.DesiredStateHook(func(current runtime.Object) error {
 		if s, ok := current.(*corev1.Service); ok {
@@ -59,6 +79,15 @@ func (r *Reconciler) service() (runtime.Object, reconciler.DesiredState, error)
 	return desired, beforeUpdateHook, nil
 }
 
+func hasServicePort(ports []corev1.ServicePort, port int32, protocol corev1.Protocol) bool {
+	for _, p := range ports {
+		if p.Port == port && p.Protocol == protocol {
+			return true
+		}
+	}
+	return false
+}
+
 func (r *Reconciler) serviceMetrics() (runtime.Object, reconciler.DesiredState, error) {
 	if r.Logging.Spec.SyslogNGSpec.Metrics != nil {
 		return &corev1.Service{
@@ -15,7 +15,10 @@
 package syslogng
 
 import (
+	"context"
+	"crypto/sha256"
 	"fmt"
+	"path"
 	"path/filepath"
 	"strings"
 
@@ -28,9 +31,14 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kube-logging/logging-operator/pkg/resources/kubetool"
+	"github.com/kube-logging/logging-operator/pkg/resources/pki"
+	"github.com/kube-logging/logging-operator/pkg/resources/templates"
 	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/syslogng/filter"
 )
 
 func (r *Reconciler) statefulset() (runtime.Object, reconciler.DesiredState, error) {
@@ -70,20 +78,50 @@ func (r *Reconciler) statefulset() (runtime.Object, reconciler.DesiredState, err
 	if !r.Logging.Spec.SyslogNGSpec.SkipRBACCreate {
 		desired.Spec.Template.Spec.ServiceAccountName = r.getServiceAccountName()
 	}
+	// the buffer storage volume is opt-in, volume claim templates of existing statefulsets are immutable
+	if r.Logging.Spec.SyslogNGSpec.BufferStorageVolumeConfigured() && r.overridesBufferMount() == nil {
+		if r.Logging.Spec.SyslogNGSpec.BufferStorageVolume.PersistentVolumeClaim != nil {
+			err := r.Logging.Spec.SyslogNGSpec.BufferStorageVolume.ApplyPVCForStatefulSet(ContainerName, BufferPath, &desired.Spec, func(name string) metav1.ObjectMeta {
+				return r.SyslogNGObjectMeta(name, ComponentSyslogNG)
+			})
+			if err != nil {
+				return nil, reconciler.StatePresent, err
+			}
+		} else {
+			err := r.Logging.Spec.SyslogNGSpec.BufferStorageVolume.ApplyVolumeForPodSpec(r.bufferVolumeName(), ContainerName, BufferPath, &desired.Spec.Template.Spec)
+			if err != nil {
+				return nil, reconciler.StatePresent, err
+			}
+		}
+	}
+	if r.Logging.Spec.SyslogNGSpec.TLS.Enabled {
+		checksum, err := pki.SecretChecksum(context.TODO(), r.Client, r.Logging, r.Logging.Spec.SyslogNGSpec.TLS.SecretName)
+		if err != nil {
+			return nil, reconciler.StatePresent, err
+		}
+		if checksum != "" {
+			desired.Spec.Template.ObjectMeta = templates.Annotate(desired.Spec.Template.ObjectMeta, pki.ChecksumAnnotation, checksum)
+		}
+	}
+	if len(r.Logging.Spec.SyslogNGSpec.PatternDBs) > 0 {
+		// syslog-ng reads the pattern databases on startup, roll the pods when their content changes
+		checksum, err := r.patternDBChecksum(context.TODO())
+		if err != nil {
+			return nil, reconciler.StatePresent, err
+		}
+		desired.Spec.Template.ObjectMeta = templates.Annotate(desired.Spec.Template.ObjectMeta, PatternDBChecksumAnnotation, checksum)
+	}
+	if scaling := r.Logging.Spec.SyslogNGSpec.Scaling; scaling != nil && scaling.Replicas > 0 {
+		desired.Spec.Replicas = util.IntPointer(int32(scaling.Replicas))
+	}
 	err := merge.Merge(desired, r.Logging.Spec.SyslogNGSpec.StatefulSetOverrides)
 	if err != nil {
 		return desired, reconciler.StatePresent, errors.WrapIf(err, "unable to merge overrides to base object")
 	}
 
-	// HACK: try to _guess_ if user has configured a persistent volume for buffers and move syslog-ng's persist file there
-	buffersVolumeName := "buffers"
-	if r.Logging.Spec.SyslogNGSpec.BufferVolumeMetrics != nil {
-		if name := r.Logging.Spec.SyslogNGSpec.BufferVolumeMetrics.MountName; name != "" {
-			buffersVolumeName = name
-		}
-	}
+	// move syslog-ng's persist file to the buffer storage volume, the mount may have been renamed by the overrides
 	syslogngContainer := kubetool.FindContainerByName(desired.Spec.Template.Spec.Containers, ContainerName)
-	if mnt := kubetool.FindVolumeMountByName(syslogngContainer.VolumeMounts, buffersVolumeName); mnt != nil {
+	if mnt := findVolumeMountByPath(syslogngContainer.VolumeMounts, BufferPath); mnt != nil {
 		if !sliceAny(syslogngContainer.Args, func(arg string) bool { return strings.Contains(arg, "--persist-file") }) {
 			syslogngContainer.Args = append(syslogngContainer.Args,
 				"--persist-file", filepath.Join(mnt.MountPath, "/syslog-ng.persist"))
@@ -93,16 +131,54 @@ func (r *Reconciler) statefulset() (runtime.Object, reconciler.DesiredState, err
 	return desired, reconciler.StatePresent, nil
 }
 
+// bufferVolumeName returns the name of the volume that holds the disk buffers
+func (r *Reconciler) bufferVolumeName() string {
+	if r.Logging.Spec.SyslogNGSpec.BufferVolumeMetrics != nil {
+		if name := r.Logging.Spec.SyslogNGSpec.BufferVolumeMetrics.MountName; name != "" {
+			return name
+		}
+	}
+	if mnt := r.overridesBufferMount(); mnt != nil {
+		return mnt.Name
+	}
+	if r.Logging.Spec.SyslogNGSpec.BufferStorageVolumeConfigured() {
+		if pvc := r.Logging.Spec.SyslogNGSpec.BufferStorageVolume.PersistentVolumeClaim; pvc != nil {
+			return r.Logging.QualifiedName(pvc.PersistentVolumeSource.ClaimName)
+		}
+		return r.Logging.QualifiedName(v1beta1.DefaultSyslogNGBufferStorageVolumeName)
+	}
+	return "buffers"
+}
+
+// overridesBufferMount returns the volume mount of the buffer path in the syslog-ng container of the statefulset overrides
+func (r *Reconciler) overridesBufferMount() *corev1.VolumeMount {
+	overrides := r.Logging.Spec.SyslogNGSpec.StatefulSetOverrides
+	if overrides == nil {
+		return nil
+	}
+	for _, c := range overrides.Spec.Template.Spec.Containers {
+		if c.Name == ContainerName {
+			return findVolumeMountByPath(c.VolumeMounts, BufferPath)
+		}
+	}
+	return nil
+}
+
+func findVolumeMountByPath(mounts []corev1.VolumeMount, path string) *corev1.VolumeMount {
+	for i := range mounts {
+		if mounts[i].MountPath == path {
+			return &mounts[i]
+		}
+	}
+	return nil
+}
+
 func syslogNGContainer(spec *v1beta1.SyslogNGSpec) corev1.Container {
 	return corev1.Container{
 		Name:            ContainerName,
 		Image:           v1beta1.RepositoryWithTag(syslogngImageRepository, syslogngImageTag),
 		ImagePullPolicy: corev1.PullIfNotPresent,
-		Ports: []corev1.ContainerPort{{
-			Name:          "syslog-ng-tcp",
-			ContainerPort: ServicePort,
-			Protocol:      corev1.ProtocolTCP,
-		}},
+		Ports:           generateSyslogNGPorts(spec),
 		Args: []string{
 			"--cfgfile=" + configDir + "/" + configKey,
 			"--control=" + socketPath,
@@ -137,6 +213,35 @@ func syslogNGContainer(spec *v1beta1.SyslogNGSpec) corev1.Container {
 	}
 }
 
+func generateSyslogNGPorts(spec *v1beta1.SyslogNGSpec) []corev1.ContainerPort {
+	ports := []corev1.ContainerPort{{
+		Name:          "syslog-ng-tcp",
+		ContainerPort: ServicePort,
+		Protocol:      corev1.ProtocolTCP,
+	}}
+	if spec.OTLPSource != nil {
+		ports = append(ports, corev1.ContainerPort{
+			Name:          OTLPPortName,
+			ContainerPort: spec.OTLPSource.Port,
+			Protocol:      corev1.ProtocolTCP,
+		})
+	}
+	for _, source := range spec.NetworkSources {
+		ports = append(ports, corev1.ContainerPort{
+			ContainerPort: source.Port,
+			Protocol:      networkSourceProtocol(source),
+		})
+	}
+	return ports
+}
+
+func networkSourceProtocol(source v1beta1.SyslogNGNetworkSource) corev1.Protocol {
+	if source.Transport == "udp" {
+		return corev1.ProtocolUDP
+	}
+	return corev1.ProtocolTCP
+}
+
 func generatePortsBufferVolumeMetrics(spec *v1beta1.SyslogNGSpec) []corev1.ContainerPort {
 	port := int32(defaultBufferVolumeMetricsPort)
 	if spec.BufferVolumeMetrics.Port != 0 {
@@ -161,7 +266,7 @@ func generateVolumeMounts(spec *v1beta1.SyslogNGSpec) []corev1.VolumeMount {
 	if spec != nil && spec.TLS.Enabled {
 		res = append(res, corev1.VolumeMount{
 			Name:      tlsVolumeName,
-			MountPath: "/syslog-ng/tls/",
+			MountPath: v1beta1.SyslogNGTLSDir,
 		})
 	}
 	if spec != nil {
@@ -173,11 +278,127 @@ func generateVolumeMounts(spec *v1beta1.SyslogNGSpec) []corev1.VolumeMount {
 			Name:      outputSecretName,
 			MountPath: OutputSecretPath,
 		})
+		res = append(res, patternDBVolumeMounts(spec)...)
+		res = append(res, networkSourceTLSVolumeMounts(spec)...)
 	}
 
 	return res
 }
 
+func patternDBVolumeName(name string) string {
+	return "patterndb-" + name
+}
+
+func patternDBVolumes(spec *v1beta1.SyslogNGSpec) (v []corev1.Volume) {
+	for _, db := range spec.PatternDBs {
+		volume := corev1.Volume{
+			Name: patternDBVolumeName(db.Name),
+		}
+		switch {
+		case db.ConfigMap != nil:
+			volume.VolumeSource.ConfigMap = &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: db.ConfigMap.LocalObjectReference,
+				Items: []corev1.KeyToPath{
+					{
+						Key:  db.ConfigMap.Key,
+						Path: filter.PatternDBFileName,
+					},
+				},
+			}
+		case db.Secret != nil:
+			volume.VolumeSource.Secret = &corev1.SecretVolumeSource{
+				SecretName: db.Secret.Name,
+				Items: []corev1.KeyToPath{
+					{
+						Key:  db.Secret.Key,
+						Path: filter.PatternDBFileName,
+					},
+				},
+			}
+		default:
+			continue
+		}
+		v = append(v, volume)
+	}
+	return
+}
+
+// patternDBChecksum returns the checksum of the pattern database XMLs referenced in the syslog-ng spec
+func (r *Reconciler) patternDBChecksum(ctx context.Context) (string, error) {
+	h := sha256.New()
+	for _, db := range r.Logging.Spec.SyslogNGSpec.PatternDBs {
+		var data []byte
+		switch {
+		case db.ConfigMap != nil:
+			cm := &corev1.ConfigMap{}
+			err := r.Client.Get(ctx, types.NamespacedName{Namespace: r.Logging.Spec.ControlNamespace, Name: db.ConfigMap.Name}, cm)
+			if client.IgnoreNotFound(err) != nil {
+				return "", errors.WrapIff(err, "failed to get pattern database configmap %s", db.ConfigMap.Name)
+			}
+			data = []byte(cm.Data[db.ConfigMap.Key])
+		case db.Secret != nil:
+			secret := &corev1.Secret{}
+			err := r.Client.Get(ctx, types.NamespacedName{Namespace: r.Logging.Spec.ControlNamespace, Name: db.Secret.Name}, secret)
+			if client.IgnoreNotFound(err) != nil {
+				return "", errors.WrapIff(err, "failed to get pattern database secret %s", db.Secret.Name)
+			}
+			data = secret.Data[db.Secret.Key]
+		}
+		_, _ = h.Write([]byte(db.Name))
+		_, _ = h.Write(data)
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
+
+func patternDBVolumeMounts(spec *v1beta1.SyslogNGSpec) (vm []corev1.VolumeMount) {
+	for _, db := range spec.PatternDBs {
+		if db.ConfigMap == nil && db.Secret == nil {
+			continue
+		}
+		vm = append(vm, corev1.VolumeMount{
+			Name:      patternDBVolumeName(db.Name),
+			MountPath: path.Join(filter.PatternDBPath, db.Name),
+			ReadOnly:  true,
+		})
+	}
+	return
+}
+
+func networkSourceTLSVolumeName(name string) string {
+	return "source-tls-" + name
+}
+
+func networkSourceTLSVolumes(spec *v1beta1.SyslogNGSpec) (v []corev1.Volume) {
+	for _, source := range spec.NetworkSources {
+		if source.TLS == nil {
+			continue
+		}
+		v = append(v, corev1.Volume{
+			Name: networkSourceTLSVolumeName(source.Name),
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: source.TLS.SecretName,
+				},
+			},
+		})
+	}
+	return
+}
+
+func networkSourceTLSVolumeMounts(spec *v1beta1.SyslogNGSpec) (vm []corev1.VolumeMount) {
+	for _, source := range spec.NetworkSources {
+		if source.TLS == nil {
+			continue
+		}
+		vm = append(vm, corev1.VolumeMount{
+			Name:      networkSourceTLSVolumeName(source.Name),
+			MountPath: source.TLSDir(),
+			ReadOnly:  true,
+		})
+	}
+	return
+}
+
 func (r *Reconciler) generateVolume() (v []corev1.Volume) {
 	v = []corev1.Volume{
 		{
@@ -219,6 +440,8 @@ func (r *Reconciler) generateVolume() (v []corev1.Volume) {
 		},
 	}
 	v = append(v, outputSecretVolume)
+	v = append(v, patternDBVolumes(r.Logging.Spec.SyslogNGSpec)...)
+	v = append(v, networkSourceTLSVolumes(r.Logging.Spec.SyslogNGSpec)...)
 	return
 }
 
@@ -268,7 +491,7 @@ func (r *Reconciler) bufferMetricsSidecarContainer() *corev1.Container {
 			Ports:           generatePortsBufferVolumeMetrics(r.Logging.Spec.SyslogNGSpec),
 			VolumeMounts: []corev1.VolumeMount{
 				{
-					Name:      r.Logging.Spec.SyslogNGSpec.BufferVolumeMetrics.MountName,
+					Name:      r.bufferVolumeName(),
 					MountPath: BufferPath,
 				},
 			},
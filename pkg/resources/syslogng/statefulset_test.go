@@ -0,0 +1,129 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogng
+
+import (
+	"testing"
+
+	"github.com/cisco-open/operator-tools/pkg/typeoverride"
+	"github.com/cisco-open/operator-tools/pkg/volume"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kube-logging/logging-operator/pkg/resources/kubetool"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+)
+
+func TestStatefulSetBufferStorageVolume(t *testing.T) {
+	overrides := &typeoverride.StatefulSet{
+		Spec: typeoverride.StatefulSetSpec{
+			Template: typeoverride.PodTemplateSpec{
+				Spec: typeoverride.PodSpec{
+					Containers: []corev1.Container{{
+						Name:         ContainerName,
+						VolumeMounts: []corev1.VolumeMount{{Name: "buffer", MountPath: BufferPath}},
+					}},
+				},
+			},
+			VolumeClaimTemplates: []typeoverride.PersistentVolumeClaim{{
+				EmbeddedPersistentVolumeClaimObjectMeta: typeoverride.EmbeddedPersistentVolumeClaimObjectMeta{Name: "buffer"},
+				Spec: corev1.PersistentVolumeClaimSpec{
+					AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				},
+			}},
+		},
+	}
+
+	testCases := map[string]struct {
+		spec               v1beta1.SyslogNGSpec
+		wantClaimTemplates []string
+		wantVolume         *corev1.Volume
+		wantMount          string
+	}{
+		"not configured": {
+			spec: v1beta1.SyslogNGSpec{},
+		},
+		"pvc": {
+			spec: v1beta1.SyslogNGSpec{
+				BufferStorageVolume: volume.KubernetesVolume{
+					PersistentVolumeClaim: &volume.PersistentVolumeClaim{},
+				},
+			},
+			wantClaimTemplates: []string{"test-syslog-ng-buffer"},
+			wantMount:          "test-syslog-ng-buffer",
+		},
+		"host path": {
+			spec: v1beta1.SyslogNGSpec{
+				BufferStorageVolume: volume.KubernetesVolume{
+					HostPath: &corev1.HostPathVolumeSource{},
+				},
+			},
+			wantVolume: &corev1.Volume{
+				Name: "test-syslog-ng-buffer",
+				VolumeSource: corev1.VolumeSource{
+					HostPath: &corev1.HostPathVolumeSource{Path: "/opt/logging-operator/test/test-syslog-ng-buffer"},
+				},
+			},
+			wantMount: "test-syslog-ng-buffer",
+		},
+		"overrides mount the buffer path": {
+			spec: v1beta1.SyslogNGSpec{
+				StatefulSetOverrides: overrides,
+				BufferStorageVolume: volume.KubernetesVolume{
+					PersistentVolumeClaim: &volume.PersistentVolumeClaim{},
+				},
+			},
+			wantClaimTemplates: []string{"buffer"},
+			wantMount:          "buffer",
+		},
+	}
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			r := newTestReconciler(t, testCase.spec)
+
+			obj, _, err := r.statefulset()
+			require.NoError(t, err)
+			sts := obj.(*appsv1.StatefulSet)
+
+			var claimTemplates []string
+			for _, pvc := range sts.Spec.VolumeClaimTemplates {
+				claimTemplates = append(claimTemplates, pvc.Name)
+			}
+			require.Equal(t, testCase.wantClaimTemplates, claimTemplates)
+
+			if testCase.wantVolume != nil {
+				require.Equal(t, testCase.wantVolume, kubetool.FindVolumeByName(sts.Spec.Template.Spec.Volumes, testCase.wantVolume.Name))
+			}
+
+			container := kubetool.FindContainerByName(sts.Spec.Template.Spec.Containers, ContainerName)
+			var bufferMounts []string
+			for _, mnt := range container.VolumeMounts {
+				if mnt.MountPath == BufferPath {
+					bufferMounts = append(bufferMounts, mnt.Name)
+				}
+			}
+			if testCase.wantMount == "" {
+				require.Empty(t, bufferMounts)
+				require.NotContains(t, container.Args, "--persist-file")
+				return
+			}
+			require.Equal(t, []string{testCase.wantMount}, bufferMounts)
+			require.Equal(t, testCase.wantMount, r.bufferVolumeName())
+			require.Contains(t, container.Args, "--persist-file")
+		})
+	}
+}
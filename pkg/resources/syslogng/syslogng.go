@@ -16,35 +16,44 @@ package syslogng
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"emperror.dev/errors"
 	"github.com/cisco-open/operator-tools/pkg/reconciler"
 	"github.com/cisco-open/operator-tools/pkg/secret"
+	"github.com/cisco-open/operator-tools/pkg/utils"
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/kube-logging/logging-operator/pkg/resources"
 	"github.com/kube-logging/logging-operator/pkg/resources/configcheck"
+	"github.com/kube-logging/logging-operator/pkg/resources/kubetool"
 	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
 )
 
 const (
 	ServiceName                       = "syslog-ng"
 	ServicePort                       = 601
+	OTLPPortName                      = "grpc-otlp"
+	PatternDBChecksumAnnotation       = "checksum/patterndb"
 	configSecretName                  = "syslog-ng"
 	configKey                         = "syslog-ng.conf"
 	StatefulSetName                   = "syslog-ng"
 	outputSecretName                  = "syslog-ng-output"
 	OutputSecretPath                  = "/etc/syslog-ng/secret"
-	BufferPath                        = "/buffers"
+	BufferPath                        = v1beta1.SyslogNGBufferStoragePath
 	serviceAccountName                = "syslog-ng"
 	roleBindingName                   = "syslog-ng"
 	roleName                          = "syslog-ng"
@@ -106,6 +115,12 @@ func (r *Reconciler) Reconcile() (*reconcile.Result, error) {
 	ctx := context.Background()
 	patchBase := client.MergeFrom(r.Logging.DeepCopy())
 
+	for _, db := range r.Logging.Spec.SyslogNGSpec.PatternDBs {
+		if err := db.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
 	for _, res := range []resources.Resource{
 		r.serviceAccount,
 		r.role,
@@ -243,9 +258,170 @@ func (r *Reconciler) Reconcile() (*reconcile.Result, error) {
 		}
 	}
 
+	if res, err := r.reconcileDrain(ctx); res != nil || err != nil {
+		return res, err
+	}
+
 	return nil, nil
 }
 
+func (r *Reconciler) reconcileDrain(ctx context.Context) (*reconcile.Result, error) {
+	if r.Logging.Spec.SyslogNGSpec.Scaling == nil || !r.Logging.Spec.SyslogNGSpec.Scaling.Drain.Enabled {
+		r.Log.V(1).Info("syslog-ng buffer draining is disabled")
+		return nil, nil
+	}
+
+	nsOpt := client.InNamespace(r.Logging.Spec.ControlNamespace)
+	syslogNGLabelSet := r.Logging.GetSyslogNGLabels(ComponentSyslogNG)
+
+	bufVolName := r.bufferVolumeName()
+	pvcNamePrefix := fmt.Sprintf("%s-%s-", bufVolName, r.Logging.QualifiedName(StatefulSetName))
+
+	// buffer PVCs may come from the volume claim templates of the statefulset overrides,
+	// so they are matched by name instead of the syslog-ng labels
+	var pvcList corev1.PersistentVolumeClaimList
+	if err := r.Client.List(ctx, &pvcList, nsOpt,
+		client.MatchingLabelsSelector{
+			Selector: labels.NewSelector().Add(drainableRequirement),
+		}); err != nil {
+		return nil, errors.WrapIf(err, "listing PVC resources")
+	}
+
+	var stsPods corev1.PodList
+	if err := r.Client.List(ctx, &stsPods, nsOpt, client.MatchingLabels(syslogNGLabelSet)); err != nil {
+		return nil, errors.WrapIf(err, "listing StatefulSet pods")
+	}
+
+	pvcsInUse := make(map[string]bool)
+	for _, pod := range stsPods.Items {
+		if bufVol := kubetool.FindVolumeByName(pod.Spec.Volumes, bufVolName); bufVol != nil && bufVol.PersistentVolumeClaim != nil {
+			pvcsInUse[bufVol.PersistentVolumeClaim.ClaimName] = true
+		}
+	}
+
+	replicaCount, err := NewDataProvider(r.Client, r.Logging).GetReplicaCount(ctx)
+	if err != nil {
+		return nil, errors.WrapIf(err, "get replica count for syslog-ng")
+	}
+
+	// mark PVCs required for upscaling as in-use
+	for i := int32(0); i < utils.PointerToInt32(replicaCount); i++ {
+		pvcsInUse[fmt.Sprintf("%s%d", pvcNamePrefix, i)] = true
+	}
+
+	var jobList batchv1.JobList
+	if err := r.Client.List(ctx, &jobList, nsOpt, client.MatchingLabels(r.Logging.GetSyslogNGLabels(ComponentDrainer))); err != nil {
+		return nil, errors.WrapIf(err, "listing buffer drainer jobs")
+	}
+
+	jobOfPVC := make(map[string]batchv1.Job)
+	for _, job := range jobList.Items {
+		if bufVol := kubetool.FindVolumeByName(job.Spec.Template.Spec.Volumes, bufVolName); bufVol != nil && bufVol.PersistentVolumeClaim != nil {
+			jobOfPVC[bufVol.PersistentVolumeClaim.ClaimName] = job
+		}
+	}
+
+	var cr reconciler.CombinedResult
+	for _, pvc := range pvcList.Items {
+		if !strings.HasPrefix(pvc.Name, pvcNamePrefix) {
+			continue
+		}
+		pvcLog := r.Log.WithValues("pvc", pvc.Name)
+
+		drained := markedAsDrained(pvc)
+		inUse := pvcsInUse[pvc.Name]
+		if drained && inUse {
+			pvcLog.Info("removing drained label from PVC as it has a matching statefulset pod")
+
+			patch := client.MergeFrom(pvc.DeepCopy())
+			delete(pvc.Labels, drainStatusLabelKey)
+			if err := client.IgnoreNotFound(r.Client.Patch(ctx, pvc.DeepCopy(), patch)); err != nil {
+				cr.CombineErr(errors.WrapIf(err, "removing drained label from pvc"))
+			}
+			continue
+		}
+
+		job, hasJob := jobOfPVC[pvc.Name]
+		if hasJob && kubetool.JobSuccessfullyCompleted(&job) {
+			pvcLog.Info("drainer job for PVC has completed, adding drained label and deleting job")
+
+			patch := client.MergeFrom(pvc.DeepCopy())
+			if pvc.Labels == nil {
+				pvc.Labels = make(map[string]string)
+			}
+			pvc.Labels[drainStatusLabelKey] = drainStatusLabelValue
+			if err := client.IgnoreNotFound(r.Client.Patch(ctx, pvc.DeepCopy(), patch)); err != nil {
+				cr.CombineErr(errors.WrapIf(err, "marking pvc as drained"))
+				continue
+			}
+
+			if err := client.IgnoreNotFound(r.Client.Delete(ctx, &job, client.PropagationPolicy(metav1.DeletePropagationBackground))); err != nil {
+				cr.CombineErr(errors.WrapIf(err, "deleting completed drainer job"))
+				continue
+			}
+
+			if r.Logging.Spec.SyslogNGSpec.Scaling.Drain.DeleteVolume {
+				if err := client.IgnoreNotFound(r.Client.Delete(ctx, &pvc, client.PropagationPolicy(metav1.DeletePropagationBackground))); err != nil {
+					cr.CombineErr(errors.WrapIfWithDetails(err, "deleting drained PVC", "pvc", pvc.Name))
+					continue
+				}
+			}
+
+			if res, err := r.ReconcileResource(r.placeholderPodFor(pvc), reconciler.StateAbsent); err != nil {
+				cr.Combine(res, errors.WrapIfWithDetails(err, "removing placeholder pod for pvc", "pvc", pvc.Name))
+				continue
+			}
+
+			continue
+		}
+
+		if inUse && hasJob {
+			pvcLog.Info("deleting drainer job early as PVC is now in use")
+
+			if err := client.IgnoreNotFound(r.Client.Delete(ctx, &job, client.PropagationPolicy(metav1.DeletePropagationForeground))); err != nil {
+				cr.CombineErr(errors.WrapIf(err, "deleting unnecessary drainer job"))
+				continue
+			}
+
+			if res, err := r.ReconcileResource(r.placeholderPodFor(pvc), reconciler.StateAbsent); err != nil {
+				cr.Combine(res, errors.WrapIfWithDetails(err, "removing placeholder pod for pvc", "pvc", pvc.Name))
+				continue
+			}
+			continue
+		}
+
+		if hasJob && !kubetool.JobSuccessfullyCompleted(&job) {
+			if job.Status.Failed > 0 {
+				cr.CombineErr(errors.NewWithDetails("draining PVC failed", "pvc", pvc.Name, "attempts", job.Status.Failed))
+			} else {
+				pvcLog.Info("drainer job for PVC has not yet been completed")
+			}
+			continue
+		}
+
+		if !drained && !inUse && !hasJob {
+			pvcLog.Info("creating drainer job for PVC")
+
+			if res, err := r.ReconcileResource(r.placeholderPodFor(pvc), reconciler.StatePresent); err != nil {
+				cr.Combine(res, errors.WrapIfWithDetails(err, "ensuring placeholder pod is present for pvc", "pvc", pvc.Name))
+				continue
+			}
+
+			if job, err := r.drainerJobFor(pvc); err != nil {
+				cr.CombineErr(errors.WrapIf(err, "assembling drainer job"))
+			} else {
+				cr.Combine(r.ReconcileResource(job, reconciler.StatePresent))
+			}
+			continue
+		}
+	}
+	var res *reconcile.Result
+	if !cr.Result.IsZero() {
+		res = &cr.Result
+	}
+	return res, cr.Err
+}
+
 func (r *Reconciler) getServiceAccountName() string {
 	return r.Logging.QualifiedName(serviceAccountName)
 }
@@ -261,3 +437,22 @@ func RegisterWatches(builder *builder.Builder) *builder.Builder {
 		Owns(&batchv1.Job{}).
 		Owns(&corev1.PersistentVolumeClaim{})
 }
+
+var drainableRequirement = requirementMust(labels.NewRequirement("logging.banzaicloud.io/drain", selection.NotEquals, []string{"no"}))
+
+func requirementMust(req *labels.Requirement, err error) labels.Requirement {
+	if err != nil {
+		panic(err)
+	}
+	if req == nil {
+		panic("requirement is nil")
+	}
+	return *req
+}
+
+const drainStatusLabelKey = "logging.banzaicloud.io/drain-status"
+const drainStatusLabelValue = "drained"
+
+func markedAsDrained(pvc corev1.PersistentVolumeClaim) bool {
+	return pvc.Labels[drainStatusLabelKey] == drainStatusLabelValue
+}
@@ -78,6 +78,9 @@ type Filter struct {
 	SumoLogic           *filter.SumoLogic                 `json:"sumologic,omitempty"`
 	EnhanceK8s          *filter.EnhanceK8s                `json:"enhanceK8s,omitempty"`
 	KubeEventsTimestamp *filter.KubeEventsTimestampConfig `json:"kube_events_timestamp,omitempty"`
+	Redact              *filter.Redact                    `json:"redact,omitempty"`
+	RewriteTag          *filter.RewriteTag                `json:"rewrite_tag,omitempty"`
+	Dedup               *filter.Dedup                     `json:"dedup,omitempty"`
 }
 
 // FlowStatus defines the observed state of Flow
@@ -19,6 +19,7 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/cisco-open/operator-tools/pkg/typeoverride"
 	util "github.com/cisco-open/operator-tools/pkg/utils"
@@ -40,6 +41,11 @@ type _metaFluentbitSpec interface{} //nolint:deadcode,unused
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:path=fluentbitagents,scope=Cluster,categories=logging-all
+// +kubebuilder:printcolumn:name="Desired",type="integer",JSONPath=".status.desiredNumberScheduled",description="Number of nodes that should run the agent"
+// +kubebuilder:printcolumn:name="Ready",type="integer",JSONPath=".status.numberReady",description="Number of nodes with a ready agent"
+// +kubebuilder:printcolumn:name="Up-To-Date",type="integer",JSONPath=".status.updatedNumberScheduled",description="Number of nodes running the current agent pod spec"
+// +kubebuilder:printcolumn:name="Config",type="string",JSONPath=".status.configHash",description="Hash of the current config",priority=1
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:storageversion
 
 // FluentbitAgent is the Schema for the loggings API
@@ -96,28 +102,46 @@ type FluentbitSpec struct {
 	MountPath         string                   `json:"mountPath,omitempty"`
 	ExtraVolumeMounts []*VolumeMount           `json:"extraVolumeMounts,omitempty"`
 	InputTail         InputTail                `json:"inputTail,omitempty"`
-	FilterAws         *FilterAws               `json:"filterAws,omitempty"`
-	FilterModify      []FilterModify           `json:"filterModify,omitempty"`
+	// Additional inputs to collect host-level logs, for example the systemd journal of the kubelet and the container runtime.
+	// The records of each input are tagged with its own tag prefix, so they bypass the Kubernetes metadata filter and have no kubernetes metadata.
+	// Flows select records by their kubernetes metadata and not by tag, so host records only reach the cluster flows that select no namespaces or labels,
+	// and the default flow of the Logging. Separate them in the filters of those flows, for example with a grep filter on the _SYSTEMD_UNIT field.
+	AdditionalInputs []FluentbitAdditionalInput `json:"additionalInputs,omitempty"`
+	FilterAws        *FilterAws                 `json:"filterAws,omitempty"`
+	FilterModify     []FilterModify             `json:"filterModify,omitempty"`
+	// Ordered list of additional filters, rendered after the Kubernetes, AWS and modify filters
+	Filters []FluentbitFilter `json:"filters,omitempty"`
+	// Outputs that receive the matching container logs directly from the agents, bypassing the aggregator.
+	// The matching records are excluded from the aggregator output. Requires the Kubernetes metadata filter.
+	// The matching records are re-tagged to direct.<name> before the AWS, modify and additional filters run,
+	// so additional filters with an explicit Match have to match that tag to process them.
+	DirectOutputs []FluentbitDirectOutput `json:"directOutputs,omitempty"`
 	// Deprecated, use inputTail.parser
 	Parser string `json:"parser,omitempty"`
 	// Parameters for Kubernetes metadata filter
 	FilterKubernetes FilterKubernetes `json:"filterKubernetes,omitempty"`
 	// Disable Kubernetes metadata filter
-	DisableKubernetesFilter *bool         `json:"disableKubernetesFilter,omitempty"`
+	DisableKubernetesFilter *bool `json:"disableKubernetesFilter,omitempty"`
+	// Drop the container logs of the namespaces that none of the flows and direct outputs can match on the node, instead of forwarding them to the aggregator.
+	// Requires the Kubernetes metadata filter. Logs of a namespace are dropped until its first flow is created and the agent config is updated.
+	DropUnmatchedNamespaces bool          `json:"dropUnmatchedNamespaces,omitempty"`
 	BufferStorage           BufferStorage `json:"bufferStorage,omitempty"`
 	// +docLink:"volume.KubernetesVolume,https://github.com/cisco-open/operator-tools/tree/master/docs/types"
-	BufferStorageVolume     volume.KubernetesVolume        `json:"bufferStorageVolume,omitempty"`
-	BufferVolumeMetrics     *Metrics                       `json:"bufferVolumeMetrics,omitempty"`
-	BufferVolumeImage       ImageSpec                      `json:"bufferVolumeImage,omitempty"`
-	BufferVolumeArgs        []string                       `json:"bufferVolumeArgs,omitempty"`
-	CustomConfigSecret      string                         `json:"customConfigSecret,omitempty"`
-	PodPriorityClassName    string                         `json:"podPriorityClassName,omitempty"`
-	LivenessProbe           *corev1.Probe                  `json:"livenessProbe,omitempty"`
-	LivenessDefaultCheck    bool                           `json:"livenessDefaultCheck,omitempty"`
-	ReadinessProbe          *corev1.Probe                  `json:"readinessProbe,omitempty"`
-	Network                 *FluentbitNetwork              `json:"network,omitempty"`
-	ForwardOptions          *ForwardOptions                `json:"forwardOptions,omitempty"`
-	EnableUpstream          bool                           `json:"enableUpstream,omitempty"`
+	BufferStorageVolume  volume.KubernetesVolume `json:"bufferStorageVolume,omitempty"`
+	BufferVolumeMetrics  *Metrics                `json:"bufferVolumeMetrics,omitempty"`
+	BufferVolumeImage    ImageSpec               `json:"bufferVolumeImage,omitempty"`
+	BufferVolumeArgs     []string                `json:"bufferVolumeArgs,omitempty"`
+	CustomConfigSecret   string                  `json:"customConfigSecret,omitempty"`
+	PodPriorityClassName string                  `json:"podPriorityClassName,omitempty"`
+	LivenessProbe        *corev1.Probe           `json:"livenessProbe,omitempty"`
+	LivenessDefaultCheck bool                    `json:"livenessDefaultCheck,omitempty"`
+	ReadinessProbe       *corev1.Probe           `json:"readinessProbe,omitempty"`
+	Network              *FluentbitNetwork       `json:"network,omitempty"`
+	ForwardOptions       *ForwardOptions         `json:"forwardOptions,omitempty"`
+	EnableUpstream       bool                    `json:"enableUpstream,omitempty"`
+	// Discover the aggregator pods of the upstream config dynamically instead of listing one node per replica.
+	// Requires enableUpstream, and enables the config hot reload, so the agents follow the scaling of the aggregator without restarting.
+	UpstreamDiscovery       *FluentbitUpstreamDiscovery    `json:"upstreamDiscovery,omitempty"`
 	ServiceAccountOverrides *typeoverride.ServiceAccount   `json:"serviceAccount,omitempty"`
 	DNSPolicy               corev1.DNSPolicy               `json:"dnsPolicy,omitempty"`
 	DNSConfig               *corev1.PodDNSConfig           `json:"dnsConfig,omitempty"`
@@ -127,10 +151,73 @@ type FluentbitSpec struct {
 	// Specify a custom parser file to load in addition to the default parsers file.
 	// It must be a valid key in the configmap specified by customConfig
 	CustomParsers string `json:"customParsers,omitempty"`
+	// Disable validating the generated config with a fluent-bit dry-run before it is rolled out to the DaemonSet
+	ConfigCheckDisabled bool `json:"configCheckDisabled,omitempty"`
+	// Overrides of the config check pod
+	ConfigCheckPodOverrides *typeoverride.PodSpec `json:"configCheckPod,omitempty"`
+	// Reload config changes in place with a config-reloader sidecar instead of rolling the DaemonSet (requires fluent-bit 2.1+).
+	// The pods are still restarted when the pod spec changes.
+	ConfigHotReload *HotReload `json:"configHotReload,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
+// HotReload configures the config-reloader sidecar that triggers the hot reload of fluent-bit through its HTTP API
+type HotReload struct {
+	Image     ImageSpec                   `json:"image,omitempty"`
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+const (
+	UpstreamDiscoveryEndpointSlices = "endpointSlices"
+	UpstreamDiscoveryDNS            = "dns"
+
+	DefaultFluentbitUpstreamRefreshInterval = 30 * time.Second
+)
+
+// +kubebuilder:object:generate=true
+
+// FluentbitUpstreamDiscovery defines how the aggregator pods of the upstream are discovered.
+// Only the ready aggregator pods are listed, fluent-bit balances the load between them in a round-robin fashion.
+type FluentbitUpstreamDiscovery struct {
+	// Source of the aggregator pods: the EndpointSlices or the DNS SRV records of the headless service of the aggregator (default: endpointSlices)
+	// +kubebuilder:validation:Enum=endpointSlices;dns
+	Source string `json:"source,omitempty"`
+	// Interval of the DNS SRV lookups, the EndpointSlices are watched (default: 30s)
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
 }
 
 // FluentbitStatus defines the resource status for FluentbitAgent
 type FluentbitStatus struct {
+	// Results of the config checks by config hash, the DaemonSet is only updated with a valid config
+	ConfigCheckResults map[string]bool `json:"configCheckResults,omitempty"`
+	// Hash of the current config generated by the operator
+	ConfigHash string `json:"configHash,omitempty"`
+	// Number of nodes that should run the agent
+	DesiredNumberScheduled int32 `json:"desiredNumberScheduled,omitempty"`
+	// Number of nodes that have the agent running and ready
+	NumberReady int32 `json:"numberReady,omitempty"`
+	// Number of nodes that are running the current agent pod spec
+	UpdatedNumberScheduled int32 `json:"updatedNumberScheduled,omitempty"`
+	// Nodes where the agent is crash-looping
+	CrashLoopingNodes []string `json:"crashLoopingNodes,omitempty"`
+	// Storage backlog of the agents, scraped from the metrics endpoint when metrics are enabled.
+	// The chunk counts are not updated on their own, only when the backlogged nodes or the other status fields change.
+	StorageBacklog *FluentbitStorageBacklog `json:"storageBacklog,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
+// FluentbitStorageBacklog summarizes the chunks buffered by the agents
+type FluentbitStorageBacklog struct {
+	// Number of chunks buffered by all agents
+	TotalChunks int64 `json:"totalChunks"`
+	// Number of chunks buffered on the filesystem by all agents
+	FsChunks int64 `json:"fsChunks"`
+	// Number of filesystem chunks that are not loaded in memory, a growing number indicates a backlog
+	FsChunksDown int64 `json:"fsChunksDown"`
+	// Nodes with filesystem chunks waiting to be loaded
+	BackloggedNodes []string `json:"backloggedNodes,omitempty"`
 }
 
 // +kubebuilder:object:generate=true
@@ -257,6 +344,85 @@ type InputTail struct {
 	MultilineParser []string `json:"multiline.parser,omitempty"`
 }
 
+// +kubebuilder:object:generate=true
+
+// FluentbitAdditionalInput defines an additional input of the FluentbitAgent, exactly one of the input types has to be set
+type FluentbitAdditionalInput struct {
+	// Tag prefix of the records read by the input, must be unique and must not overlap with the tag of the container log input (default: host.<input type>)
+	TagPrefix string `json:"tagPrefix,omitempty"`
+	// Read the systemd journal of the node, e.g. the logs of the kubelet and the container runtime
+	Systemd *InputSystemd `json:"systemd,omitempty"`
+	// Tail arbitrary files of the node. Files outside of /var/log have to be mounted with extraVolumeMounts.
+	Tail *InputHostTail `json:"tail,omitempty"`
+	// Read the kernel log buffer of the node from /dev/kmsg. Reading it usually requires the CAP_SYSLOG capability or a privileged container.
+	Kmsg *InputKmsg `json:"kmsg,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
+// InputSystemd defines the FluentbitAgent systemd input configuration, the records are tagged with <tagPrefix>.<systemd unit>
+type InputSystemd struct {
+	// Journal directory, the volatile journal in /run/log/journal has to be mounted with extraVolumeMounts (default: /var/log/journal)
+	Path string `json:"Path,omitempty"`
+	// Filter the journal entries, e.g. _SYSTEMD_UNIT=kubelet.service
+	SystemdFilter []string `json:"Systemd_Filter,omitempty"`
+	// Combine the filters with AND or OR (default: Or)
+	// +kubebuilder:validation:Enum=And;Or
+	SystemdFilterType string `json:"Systemd_Filter_Type,omitempty"`
+	// Start reading new entries, skipping the entries already stored in the journal (default: Off)
+	ReadFromTail string `json:"Read_From_Tail,omitempty"`
+	// Remove the leading underscore of the journal field names (default: Off)
+	StripUnderscores string `json:"Strip_Underscores,omitempty"`
+	// Lowercase the journal field names (default: Off)
+	Lowercase string `json:"Lowercase,omitempty"`
+	// Database file to keep track of the journal cursor, e.g. /tail-db/systemd.db
+	DB string `json:"DB,omitempty"`
+	// Synchronization (I/O) method of the database: Extra, Full, Normal, Off (default: Full)
+	DBSync string `json:"DB.Sync,omitempty"`
+	// Maximum number of fields of a single entry (default: 8000)
+	MaxFields string `json:"Max_Fields,omitempty"`
+	// Maximum number of entries processed in one round (default: 5000)
+	MaxEntries string `json:"Max_Entries,omitempty"`
+	// Specify the buffering mechanism to use. It can be memory or filesystem. (default:memory)
+	StorageType string `json:"storage.type,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
+// InputHostTail defines the FluentbitAgent tail input configuration for node-level files, the records are tagged with <tagPrefix>.<file path>
+type InputHostTail struct {
+	// Pattern specifying a specific log files or multiple ones through the use of common wildcards.
+	Path string `json:"Path"`
+	// If enabled, it appends the name of the monitored file as part of the record. The value assigned becomes the key in the map.
+	PathKey string `json:"Path_Key,omitempty"`
+	// Set one or multiple shell patterns separated by commas to exclude files matching a certain criteria, e.g: exclude_path=*.gz,*.zip
+	ExcludePath string `json:"Exclude_Path,omitempty"`
+	// For new discovered files on start (without a database offset/position), read the content from the head of the file, not tail.
+	ReadFromHead bool `json:"Read_From_Head,omitempty"`
+	// The interval of refreshing the list of watched files in seconds. (default:60)
+	RefreshInterval string `json:"Refresh_Interval,omitempty"`
+	// Specify the database file to keep track of monitored files and offsets, e.g. /tail-db/host-tail.db
+	DB string `json:"DB,omitempty"`
+	// Set a limit of memory that Tail plugin can use when appending data to the Engine. If the limit is reach, it will be paused; when the data is flushed it resumes.
+	MemBufLimit string `json:"Mem_Buf_Limit,omitempty"`
+	// Specify the name of a parser to interpret the entry as a structured message.
+	Parser string `json:"Parser,omitempty"`
+	// Specify one or multiple parser definitions to apply to the content.
+	MultilineParser string `json:"multiline.parser,omitempty"`
+	// Specify the buffering mechanism to use. It can be memory or filesystem. (default:memory)
+	StorageType string `json:"storage.type,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
+// InputKmsg defines the FluentbitAgent kmsg input configuration
+type InputKmsg struct {
+	// Only read the messages with a priority less than or equal to this level (default: 8)
+	PrioLevel string `json:"Prio_Level,omitempty"`
+	// Specify the buffering mechanism to use. It can be memory or filesystem. (default:memory)
+	StorageType string `json:"storage.type,omitempty"`
+}
+
 // FilterKubernetes Fluent Bit Kubernetes Filter allows to enrich your log files with Kubernetes metadata.
 type FilterKubernetes struct {
 	// Match filtered records (default:kube.*)
@@ -343,6 +509,287 @@ type FilterAws struct {
 	Match string `json:"Match,omitempty" plugin:"default:*"`
 }
 
+// +kubebuilder:object:generate=true
+
+// FluentbitFilter defines an additional filter of the FluentbitAgent, exactly one of the filter types has to be set
+type FluentbitFilter struct {
+	Grep       *FilterGrep       `json:"grep,omitempty"`
+	Lua        *FilterLua        `json:"lua,omitempty"`
+	Nest       *FilterNest       `json:"nest,omitempty"`
+	RewriteTag *FilterRewriteTag `json:"rewriteTag,omitempty"`
+	Throttle   *FilterThrottle   `json:"throttle,omitempty"`
+	Multiline  *FilterMultiline  `json:"multiline,omitempty"`
+}
+
+// FilterGrep The Grep Filter plugin allows you to match or exclude specific records based on regular expression patterns for values or nested values.
+type FilterGrep struct {
+	// Match filtered records (default:*)
+	Match string `json:"Match,omitempty" plugin:"default:*"`
+	// Keep records which field matches the regular expression, in the format of "KEY REGEX"
+	Regex []string `json:"Regex,omitempty"`
+	// Exclude records which field matches the regular expression, in the format of "KEY REGEX"
+	Exclude []string `json:"Exclude,omitempty"`
+	// Combine the Regex and Exclude rules with AND or OR instead of evaluating them one by one (default: legacy)
+	// +kubebuilder:validation:Enum=legacy;AND;OR
+	LogicalOp string `json:"Logical_Op,omitempty"`
+}
+
+// FilterLua The Lua Filter allows you to modify the incoming records using custom Lua scripts.
+type FilterLua struct {
+	// Match filtered records (default:*)
+	Match string `json:"Match,omitempty" plugin:"default:*"`
+	// ConfigMap key holding the Lua script, it is mounted into the fluent-bit pods
+	Script corev1.ConfigMapKeySelector `json:"script"`
+	// Lua function name that will be triggered to do filtering
+	Call string `json:"call"`
+	// If enabled, Lua script will be executed in protected mode. It prevents to crash when invalid Lua script is executed. (default:true)
+	ProtectedMode *bool `json:"protected_mode,omitempty"`
+	// By default when the Lua script is invoked, the record timestamp is passed as a Floating number which might lead to loss precision when the data is converted back. If you desire timestamp precision enabling this option will pass the timestamp as a Lua table with keys sec for seconds since epoch and nsec for nanoseconds. (default:false)
+	TimeAsTable *bool `json:"time_as_table,omitempty"`
+	// If these keys are matched, the fields are converted to integer
+	TypeIntKey []string `json:"type_int_key,omitempty"`
+	// If these keys are matched, the fields are handled as array
+	TypeArrayKey []string `json:"type_array_key,omitempty"`
+}
+
+// FilterNest The Nest Filter plugin allows you to operate on or with nested data.
+type FilterNest struct {
+	// Match filtered records (default:*)
+	Match string `json:"Match,omitempty" plugin:"default:*"`
+	// Select the operation nest or lift
+	// +kubebuilder:validation:Enum=nest;lift
+	Operation string `json:"Operation"`
+	// Nest records which field matches the wildcard, only used by the nest operation
+	Wildcard []string `json:"Wildcard,omitempty"`
+	// Nest records matching the Wildcard under this key, only used by the nest operation
+	NestUnder string `json:"Nest_under,omitempty"`
+	// Lift records nested under this key, only used by the lift operation
+	NestedUnder string `json:"Nested_under,omitempty"`
+	// Prefix affected keys with this string
+	AddPrefix string `json:"Add_prefix,omitempty"`
+	// Remove prefix from affected keys if it matches this string
+	RemovePrefix string `json:"Remove_prefix,omitempty"`
+}
+
+// FilterRewriteTag The Rewrite Tag filter allows to re-emit a record under a new Tag.
+type FilterRewriteTag struct {
+	// Match filtered records, required. The new tags of the rules must not match it, otherwise the re-emitted records would be rewritten again in a loop.
+	Match string `json:"Match,omitempty"`
+	// Rewrite rules, evaluated in order
+	Rules []FilterRewriteTagRule `json:"Rule"`
+	// When the filter emits a record under the new Tag, there is an internal emitter plugin that takes care of the job. Since this emitter expose metrics as any other component of the pipeline, you can use this property to configure an optional name for it.
+	EmitterName string `json:"Emitter_Name,omitempty"`
+	// Define a buffering mechanism for the new records created. Note these records are part of the emitter plugin. This option support the values memory or filesystem. (default:memory)
+	EmitterStorageType string `json:"Emitter_Storage.type,omitempty"`
+	// Set a limit on the amount of memory in records that the emitter can consume if the outputs provide backpressure. (default:10M)
+	EmitterMemBufLimit string `json:"Emitter_Mem_Buf_Limit,omitempty"`
+}
+
+// FilterRewriteTagRule defines a rule of the rewrite_tag filter
+type FilterRewriteTagRule struct {
+	// Record key to evaluate, e.g. $kubernetes['labels']['app']
+	Key string `json:"key"`
+	// Regular expression to match the value of the key, it must not contain spaces
+	Regex string `json:"regex"`
+	// New tag of the matching records, supports $TAG, $TAG[n] and $0..$9 placeholders
+	NewTag string `json:"newTag"`
+	// Keep the original record with the original tag
+	Keep bool `json:"keep,omitempty"`
+}
+
+// FilterThrottle The Throttle Filter plugin sets the average Rate of messages per Interval, based on leaky bucket and sliding window algorithm.
+type FilterThrottle struct {
+	// Match filtered records (default:*)
+	Match string `json:"Match,omitempty" plugin:"default:*"`
+	// Amount of messages for the time
+	Rate string `json:"Rate"`
+	// Amount of intervals to calculate average over. (default:5)
+	Window string `json:"Window,omitempty"`
+	// Time interval, expressed in "sleep" format. e.g 3s, 1.5m, 0.5h etc. (default:1)
+	Interval string `json:"Interval,omitempty"`
+	// Whether to print status messages with current rate and the limits to information logs. (default:false)
+	PrintStatus string `json:"Print_Status,omitempty"`
+}
+
+// FilterMultiline The Multiline Filter helps to concatenate messages that originally belong to one context but were split across multiple records or log lines.
+type FilterMultiline struct {
+	// Match filtered records (default:*)
+	Match string `json:"Match,omitempty" plugin:"default:*"`
+	// Specify one or multiple multiline parsers, e.g. go, java, python
+	Parser []string `json:"multiline.parser"`
+	// Key name that holds the content to process
+	KeyContent string `json:"multiline.key_content,omitempty"`
+	// Mode can be parser for regex concat, or partial_message to concat split docker logs. (default:parser)
+	// +kubebuilder:validation:Enum=parser;partial_message
+	Mode string `json:"mode,omitempty"`
+	// Enable buffered mode. In buffered mode, the filter can concatenate multilines from inputs that ingest records one by one. (default:true)
+	Buffer *bool `json:"buffer,omitempty"`
+	// Flush time for pending multiline records. (default:2000)
+	FlushMS string `json:"flush_ms,omitempty"`
+	// Name of the internal emitter, used when buffered mode is enabled
+	EmitterName string `json:"emitter_name,omitempty"`
+	// Define a buffering mechanism for the emitted records. This option support the values memory or filesystem. (default:memory)
+	EmitterStorageType string `json:"emitter_storage.type,omitempty"`
+	// Set a limit on the amount of memory the emitter can consume if the outputs provide backpressure. (default:10M)
+	EmitterMemBufLimit string `json:"emitter_mem_buf_limit,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
+// FluentbitDirectOutput sends the container logs matching the namespaces and labels directly to the destination,
+// exactly one of the output types has to be set
+type FluentbitDirectOutput struct {
+	// Name of the output, the matching records are tagged with direct.<name>
+	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	Name string `json:"name"`
+	// Namespaces of the container logs to send to the output, any of them matches
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Pod labels the container logs must all have to be sent to the output
+	Labels map[string]string `json:"labels,omitempty"`
+	// Number of retries for the failed chunks, the value False means no limit (default: 1)
+	RetryLimit string `json:"retryLimit,omitempty"`
+	// Number of workers of the output
+	Workers *int `json:"workers,omitempty"`
+	// TLS settings of the connection
+	TLS           *FluentbitDirectOutputTLS  `json:"tls,omitempty"`
+	Loki          *DirectOutputLoki          `json:"loki,omitempty"`
+	S3            *DirectOutputS3            `json:"s3,omitempty"`
+	Elasticsearch *DirectOutputElasticsearch `json:"elasticsearch,omitempty"`
+	HTTP          *DirectOutputHTTP          `json:"http,omitempty"`
+	Kafka         *DirectOutputKafka         `json:"kafka,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
+// FluentbitDirectOutputTLS defines the TLS settings of a direct output
+type FluentbitDirectOutputTLS struct {
+	// Verify the certificate of the destination (default: true)
+	Verify *bool `json:"verify,omitempty"`
+	// Name of the Secret in the control namespace containing the CA certificate (ca.crt), it is mounted into the fluent-bit pods
+	SecretName string `json:"secretName,omitempty"`
+	// Authenticate with the client certificate (tls.crt) and key (tls.key) of the Secret
+	ClientAuth bool `json:"clientAuth,omitempty"`
+}
+
+// DirectOutputLoki sends the records to Grafana Loki
+type DirectOutputLoki struct {
+	// Loki hostname or IP address
+	Host string `json:"host"`
+	// Loki TCP port (default: 3100)
+	Port int `json:"port,omitempty"`
+	// Specify a custom HTTP URI. It must start with forward slash. (default: /loki/api/v1/push)
+	URI string `json:"uri,omitempty"`
+	// Tenant ID used by default to push logs to Loki
+	TenantID string `json:"tenant_id,omitempty"`
+	// Stream labels of the records, e.g. job=fluent-bit, $kubernetes['namespace_name']
+	Labels []string `json:"labels,omitempty" plugin:"hidden"`
+	// Record keys used as stream labels
+	LabelKeys []string `json:"label_keys,omitempty" plugin:"hidden"`
+	// Record keys removed from the records
+	RemoveKeys []string `json:"remove_keys,omitempty" plugin:"hidden"`
+	// Format of the log line: json or key_value (default: json)
+	// +kubebuilder:validation:Enum=json;key_value
+	LineFormat string `json:"line_format,omitempty"`
+	// Add the Kubernetes labels of the pods as stream labels
+	AutoKubernetesLabels string `json:"auto_kubernetes_labels,omitempty"`
+	// Basic auth username
+	HTTPUser string `json:"http_user,omitempty"`
+	// Secret key holding the basic auth password
+	HTTPPasswd *corev1.SecretKeySelector `json:"http_passwd,omitempty" plugin:"hidden"`
+}
+
+// DirectOutputS3 uploads the records to Amazon S3. The credentials are taken from the environment of the pods, for example IRSA, or assumed with role_arn.
+type DirectOutputS3 struct {
+	// S3 bucket name
+	Bucket string `json:"bucket"`
+	// The AWS region of the S3 bucket
+	Region string `json:"region"`
+	// ARN of an IAM role to assume
+	RoleARN string `json:"role_arn,omitempty"`
+	// Custom endpoint for the S3 API
+	Endpoint string `json:"endpoint,omitempty"`
+	// Size of the files in S3 (default: 100M)
+	TotalFileSize string `json:"total_file_size,omitempty"`
+	// Upload the file after this timeout even if the total_file_size is not reached (default: 10m)
+	UploadTimeout string `json:"upload_timeout,omitempty"`
+	// Format string of the keys in S3 (default: /fluent-bit-logs/$TAG/%Y/%m/%d/%H/%M/%S)
+	S3KeyFormat string `json:"s3_key_format,omitempty"`
+	// Compression type of the uploaded files: gzip or arrow
+	Compression string `json:"compression,omitempty"`
+	// Directory to buffer the files locally before the upload (default: /tmp/fluent-bit/s3)
+	StoreDir string `json:"store_dir,omitempty"`
+}
+
+// DirectOutputElasticsearch sends the records to Elasticsearch or OpenSearch
+type DirectOutputElasticsearch struct {
+	// IP address or hostname of the target Elasticsearch instance
+	Host string `json:"Host"`
+	// TCP port of the target Elasticsearch instance (default: 9200)
+	Port int `json:"Port,omitempty"`
+	// Elasticsearch accepts new data on HTTP query path /_bulk, this option defines such path prefix
+	Path string `json:"Path,omitempty"`
+	// Index name (default: fluent-bit)
+	Index string `json:"Index,omitempty"`
+	// Enable Logstash format compatibility
+	LogstashFormat string `json:"Logstash_Format,omitempty"`
+	// Prefix of the Logstash index names (default: logstash)
+	LogstashPrefix string `json:"Logstash_Prefix,omitempty"`
+	// Omit the type name, required by Elasticsearch 8
+	SuppressTypeName string `json:"Suppress_Type_Name,omitempty"`
+	// Replace the dots in the field names with underscores
+	ReplaceDots string `json:"Replace_Dots,omitempty"`
+	// Print the Elasticsearch API errors
+	TraceError string `json:"Trace_Error,omitempty"`
+	// Basic auth username
+	HTTPUser string `json:"HTTP_User,omitempty"`
+	// Secret key holding the basic auth password
+	HTTPPasswd *corev1.SecretKeySelector `json:"HTTP_Passwd,omitempty" plugin:"hidden"`
+}
+
+// DirectOutputHTTP sends the records to an HTTP endpoint
+type DirectOutputHTTP struct {
+	// IP address or hostname of the target HTTP server
+	Host string `json:"host"`
+	// TCP port of the target HTTP server (default: 80)
+	Port int `json:"port,omitempty"`
+	// Specify an optional HTTP URI for the target web server (default: /)
+	URI string `json:"uri,omitempty"`
+	// Data format: msgpack, json, json_stream, json_lines or gelf (default: msgpack)
+	Format string `json:"format,omitempty"`
+	// Additional HTTP headers in the "Name value" format
+	Header []string `json:"header,omitempty" plugin:"hidden"`
+	// Name of the date key in the JSON formats (default: date)
+	JSONDateKey string `json:"json_date_key,omitempty"`
+	// Format of the date in the JSON formats: double, epoch or iso8601 (default: double)
+	JSONDateFormat string `json:"json_date_format,omitempty"`
+	// Basic auth username
+	HTTPUser string `json:"http_user,omitempty"`
+	// Secret key holding the basic auth password
+	HTTPPasswd *corev1.SecretKeySelector `json:"http_passwd,omitempty" plugin:"hidden"`
+}
+
+// DirectOutputKafka sends the records to Apache Kafka
+type DirectOutputKafka struct {
+	// Comma separated list of the Kafka brokers
+	Brokers string `json:"brokers"`
+	// Comma separated list of the topics, the first one is used unless topic_key is set
+	Topics string `json:"topics"`
+	// Record key to select the topic of the record
+	TopicKey string `json:"topic_key,omitempty"`
+	// Serialization format of the messages: json, msgpack or gelf (default: json)
+	Format string `json:"format,omitempty"`
+	// Optional key to store the message
+	MessageKey string `json:"message_key,omitempty"`
+	// Name of the timestamp key in the messages (default: @timestamp)
+	TimestampKey string `json:"timestamp_key,omitempty"`
+	// Additional librdkafka properties without the rdkafka. prefix, e.g. security.protocol: SASL_SSL
+	RDKafkaOptions map[string]string `json:"rdkafkaOptions,omitempty" plugin:"hidden"`
+	// SASL username
+	SASLUsername string `json:"saslUsername,omitempty" plugin:"hidden"`
+	// Secret key holding the SASL password
+	SASLPassword *corev1.SecretKeySelector `json:"saslPassword,omitempty" plugin:"hidden"`
+}
+
 // FilterModify The Modify Filter plugin allows you to change records using rules and conditions.
 type FilterModify struct {
 	// FluentbitAgent Filter Modification Rule
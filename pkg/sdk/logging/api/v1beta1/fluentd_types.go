@@ -46,6 +46,9 @@ type FluentdSpec struct {
 	// +docLink:"volume.KubernetesVolume,https://github.com/cisco-open/operator-tools/tree/master/docs/types"
 	BufferStorageVolume volume.KubernetesVolume `json:"bufferStorageVolume,omitempty"`
 	ExtraVolumes        []ExtraVolume           `json:"extraVolumes,omitempty"`
+	// ConfigMaps with custom grok patterns, mounted under /fluentd/grok-patterns/<name>.
+	// Parser filters can load them with the custom_patterns parameter.
+	GrokPatternConfigMaps []string `json:"grokPatternConfigMaps,omitempty"`
 	// Deprecated, use bufferStorageVolume
 	FluentdPvcSpec            *volume.KubernetesVolume          `json:"fluentdPvcSpec,omitempty"`
 	VolumeMountChmod          bool                              `json:"volumeMountChmod,omitempty"`
@@ -62,7 +62,11 @@ type LoggingSpec struct {
 	// GlobalOutput name to flush ERROR events to
 	ErrorOutputRef string `json:"errorOutputRef,omitempty"`
 	// Global filters to apply on logs before any match or filter mechanism.
+	// Filters re-emitting the events, like rewrite_tag, are only supported in flows.
 	GlobalFilters []Filter `json:"globalFilters,omitempty"`
+	// Filters prepended to the filters of every Flow, ClusterFlow and the DefaultFlow.
+	// Use it to enforce processing, like a redact filter, that flow authors cannot opt out of.
+	EnforcedFilters []Filter `json:"enforcedFilters,omitempty"`
 	// Limit namespaces to watch Flow and Output custom resources.
 	WatchNamespaces []string `json:"watchNamespaces,omitempty"`
 	// Cluster domain name to be used when templating URLs to services (default: "cluster.local").
@@ -81,6 +85,8 @@ type LoggingSpec struct {
 	// in case there is a change in an immutable field
 	// that otherwise couldn't be managed with a simple update.
 	EnableRecreateWorkloadOnImmutableFieldChange bool `json:"enableRecreateWorkloadOnImmutableFieldChange,omitempty"`
+	// Operator-managed certificates for the TLS connections between the agents and the aggregators
+	ManagedTLS *ManagedTLS `json:"managedTLS,omitempty"`
 }
 
 // LoggingStatus defines the observed state of Logging
@@ -125,24 +131,29 @@ type DefaultFlowSpec struct {
 }
 
 const (
-	DefaultFluentbitImageRepository             = "fluent/fluent-bit"
-	DefaultFluentbitImageTag                    = "2.1.4"
-	DefaultFluentbitBufferVolumeImageRepository = "ghcr.io/kube-logging/node-exporter"
-	DefaultFluentbitBufferVolumeImageTag        = "v0.6.1"
-	DefaultFluentbitBufferStorageVolumeName     = "fluentbit-buffer"
-	DefaultFluentdImageRepository               = "ghcr.io/kube-logging/fluentd"
-	DefaultFluentdImageTag                      = "v1.15-ruby3"
-	DefaultFluentdBufferStorageVolumeName       = "fluentd-buffer"
-	DefaultFluentdDrainWatchImageRepository     = "ghcr.io/kube-logging/fluentd-drain-watch"
-	DefaultFluentdDrainWatchImageTag            = "v0.2.0"
-	DefaultFluentdDrainPauseImageRepository     = "k8s.gcr.io/pause"
-	DefaultFluentdDrainPauseImageTag            = "3.2"
-	DefaultFluentdVolumeModeImageRepository     = "busybox"
-	DefaultFluentdVolumeModeImageTag            = "latest"
-	DefaultFluentdConfigReloaderImageRepository = "ghcr.io/kube-logging/config-reloader"
-	DefaultFluentdConfigReloaderImageTag        = "v0.0.5"
-	DefaultFluentdBufferVolumeImageRepository   = "ghcr.io/kube-logging/node-exporter"
-	DefaultFluentdBufferVolumeImageTag          = "v0.6.1"
+	DefaultFluentbitImageRepository               = "fluent/fluent-bit"
+	DefaultFluentbitImageTag                      = "2.1.4"
+	DefaultFluentbitBufferVolumeImageRepository   = "ghcr.io/kube-logging/node-exporter"
+	DefaultFluentbitBufferVolumeImageTag          = "v0.6.1"
+	DefaultFluentbitBufferStorageVolumeName       = "fluentbit-buffer"
+	DefaultFluentbitConfigReloaderImageRepository = "ghcr.io/kube-logging/config-reloader"
+	DefaultFluentbitConfigReloaderImageTag        = "v0.0.5"
+	DefaultFluentdImageRepository                 = "ghcr.io/kube-logging/fluentd"
+	DefaultFluentdImageTag                        = "v1.15-ruby3"
+	DefaultFluentdBufferStorageVolumeName         = "fluentd-buffer"
+	DefaultFluentdDrainWatchImageRepository       = "ghcr.io/kube-logging/fluentd-drain-watch"
+	DefaultFluentdDrainWatchImageTag              = "v0.2.0"
+	DefaultFluentdDrainPauseImageRepository       = "k8s.gcr.io/pause"
+	DefaultFluentdDrainPauseImageTag              = "3.2"
+	DefaultFluentdVolumeModeImageRepository       = "busybox"
+	DefaultFluentdVolumeModeImageTag              = "latest"
+	DefaultFluentdConfigReloaderImageRepository   = "ghcr.io/kube-logging/config-reloader"
+	DefaultFluentdConfigReloaderImageTag          = "v0.0.5"
+	DefaultFluentdBufferVolumeImageRepository     = "ghcr.io/kube-logging/node-exporter"
+	DefaultFluentdBufferVolumeImageTag            = "v0.6.1"
+	DefaultSyslogNGBufferStorageVolumeName        = "syslog-ng-buffer"
+	DefaultSyslogNGDrainPauseImageRepository      = "k8s.gcr.io/pause"
+	DefaultSyslogNGDrainPauseImageTag             = "3.2"
 )
 
 // SetDefaults fills empty attributes
@@ -153,6 +164,30 @@ func (l *Logging) SetDefaults() error {
 	if !l.Spec.FlowConfigCheckDisabled && l.Status.ConfigCheckResults == nil {
 		l.Status.ConfigCheckResults = make(map[string]bool)
 	}
+	if l.Spec.ManagedTLS != nil {
+		if l.Spec.ManagedTLS.Duration == nil {
+			l.Spec.ManagedTLS.Duration = &metav1.Duration{Duration: DefaultManagedTLSDuration}
+		}
+		if l.Spec.ManagedTLS.RenewBefore == nil {
+			l.Spec.ManagedTLS.RenewBefore = &metav1.Duration{Duration: DefaultManagedTLSRenewBefore}
+		}
+		if cm := l.Spec.ManagedTLS.CertManager; cm != nil {
+			if cm.IssuerKind == "" {
+				cm.IssuerKind = "Issuer"
+			}
+			if cm.IssuerGroup == "" {
+				cm.IssuerGroup = "cert-manager.io"
+			}
+		}
+		if l.Spec.FluentdSpec != nil && l.Spec.FluentdSpec.TLS.SecretName == "" {
+			l.Spec.FluentdSpec.TLS.Enabled = true
+			l.Spec.FluentdSpec.TLS.SecretName = l.ManagedTLSAggregatorSecretName()
+		}
+		if l.Spec.SyslogNGSpec != nil && l.Spec.SyslogNGSpec.TLS.SecretName == "" {
+			l.Spec.SyslogNGSpec.TLS.Enabled = true
+			l.Spec.SyslogNGSpec.TLS.SecretName = l.ManagedTLSAggregatorSecretName()
+		}
+	}
 	if l.Spec.FluentdSpec != nil { // nolint:nestif
 		if l.Spec.FluentdSpec.FluentdPvcSpec != nil {
 			return errors.New("`fluentdPvcSpec` field is deprecated, use: `bufferStorageVolume`")
@@ -384,6 +419,55 @@ func (l *Logging) SetDefaults() error {
 				l.Spec.SyslogNGSpec.Metrics.Interval = "15s"
 			}
 		}
+		if l.Spec.SyslogNGSpec.OTLPSource != nil {
+			if l.Spec.SyslogNGSpec.OTLPSource.Port == 0 {
+				l.Spec.SyslogNGSpec.OTLPSource.Port = 4317
+			}
+		}
+		l.Spec.SyslogNGSpec.BufferStorageVolume.WithDefaultHostPath(
+			fmt.Sprintf(HostPath, l.Name, l.QualifiedName(DefaultSyslogNGBufferStorageVolumeName)),
+		)
+		if l.Spec.SyslogNGSpec.BufferStorageVolume.PersistentVolumeClaim != nil {
+			if l.Spec.SyslogNGSpec.BufferStorageVolume.PersistentVolumeClaim.PersistentVolumeClaimSpec.AccessModes == nil {
+				l.Spec.SyslogNGSpec.BufferStorageVolume.PersistentVolumeClaim.PersistentVolumeClaimSpec.AccessModes = []v1.PersistentVolumeAccessMode{
+					v1.ReadWriteOnce,
+				}
+			}
+			if l.Spec.SyslogNGSpec.BufferStorageVolume.PersistentVolumeClaim.PersistentVolumeClaimSpec.Resources.Requests == nil {
+				l.Spec.SyslogNGSpec.BufferStorageVolume.PersistentVolumeClaim.PersistentVolumeClaimSpec.Resources.Requests = map[v1.ResourceName]resource.Quantity{
+					"storage": resource.MustParse("20Gi"),
+				}
+			}
+			if l.Spec.SyslogNGSpec.BufferStorageVolume.PersistentVolumeClaim.PersistentVolumeClaimSpec.VolumeMode == nil {
+				l.Spec.SyslogNGSpec.BufferStorageVolume.PersistentVolumeClaim.PersistentVolumeClaimSpec.VolumeMode = persistentVolumeModePointer(v1.PersistentVolumeFilesystem)
+			}
+			if l.Spec.SyslogNGSpec.BufferStorageVolume.PersistentVolumeClaim.PersistentVolumeSource.ClaimName == "" {
+				l.Spec.SyslogNGSpec.BufferStorageVolume.PersistentVolumeClaim.PersistentVolumeSource.ClaimName = DefaultSyslogNGBufferStorageVolumeName
+			}
+		}
+		if l.Spec.SyslogNGSpec.Scaling != nil {
+			if l.Spec.SyslogNGSpec.Scaling.Drain.Image.PullPolicy == "" {
+				l.Spec.SyslogNGSpec.Scaling.Drain.Image.PullPolicy = "IfNotPresent"
+			}
+			if l.Spec.SyslogNGSpec.Scaling.Drain.PauseImage.Repository == "" {
+				l.Spec.SyslogNGSpec.Scaling.Drain.PauseImage.Repository = DefaultSyslogNGDrainPauseImageRepository
+			}
+			if l.Spec.SyslogNGSpec.Scaling.Drain.PauseImage.Tag == "" {
+				l.Spec.SyslogNGSpec.Scaling.Drain.PauseImage.Tag = DefaultSyslogNGDrainPauseImageTag
+			}
+			if l.Spec.SyslogNGSpec.Scaling.Drain.PauseImage.PullPolicy == "" {
+				l.Spec.SyslogNGSpec.Scaling.Drain.PauseImage.PullPolicy = "IfNotPresent"
+			}
+		}
+		for i := range l.Spec.SyslogNGSpec.NetworkSources {
+			source := &l.Spec.SyslogNGSpec.NetworkSources[i]
+			if source.Transport == "" {
+				source.Transport = "tcp"
+			}
+			if source.Protocol == "" {
+				source.Protocol = "rfc3164"
+			}
+		}
 	}
 
 	return nil
@@ -469,6 +553,29 @@ func FluentBitDefaults(fluentbitSpec *FluentbitSpec) error {
 		if fluentbitSpec.BufferVolumeImage.PullPolicy == "" {
 			fluentbitSpec.BufferVolumeImage.PullPolicy = "IfNotPresent"
 		}
+		if fluentbitSpec.EnableUpstream && fluentbitSpec.UpstreamDiscovery != nil {
+			if fluentbitSpec.UpstreamDiscovery.Source == "" {
+				fluentbitSpec.UpstreamDiscovery.Source = UpstreamDiscoveryEndpointSlices
+			}
+			if fluentbitSpec.UpstreamDiscovery.RefreshInterval == nil {
+				fluentbitSpec.UpstreamDiscovery.RefreshInterval = &metav1.Duration{Duration: DefaultFluentbitUpstreamRefreshInterval}
+			}
+			// the discovered nodes are picked up by a hot reload
+			if fluentbitSpec.ConfigHotReload == nil {
+				fluentbitSpec.ConfigHotReload = &HotReload{}
+			}
+		}
+		if fluentbitSpec.ConfigHotReload != nil {
+			if fluentbitSpec.ConfigHotReload.Image.Repository == "" {
+				fluentbitSpec.ConfigHotReload.Image.Repository = DefaultFluentbitConfigReloaderImageRepository
+			}
+			if fluentbitSpec.ConfigHotReload.Image.Tag == "" {
+				fluentbitSpec.ConfigHotReload.Image.Tag = DefaultFluentbitConfigReloaderImageTag
+			}
+			if fluentbitSpec.ConfigHotReload.Image.PullPolicy == "" {
+				fluentbitSpec.ConfigHotReload.Image.PullPolicy = "IfNotPresent"
+			}
+		}
 		if fluentbitSpec.Security.SecurityContext == nil {
 			fluentbitSpec.Security.SecurityContext = &v1.SecurityContext{}
 		}
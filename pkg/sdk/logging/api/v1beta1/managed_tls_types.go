@@ -0,0 +1,69 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	DefaultManagedTLSDuration    = 90 * 24 * time.Hour
+	DefaultManagedTLSRenewBefore = 30 * 24 * time.Hour
+)
+
+// +kubebuilder:object:generate=true
+
+// ManagedTLS configures the operator-managed certificates of the mutual TLS connections between the agents and the aggregators.
+// The aggregators get a server certificate for their service names, the agents get a client certificate signed by the same CA.
+// TLS is enabled on the fluentd, syslog-ng, fluentbit and node agent specs that don't set a secretName,
+// and the pods are restarted when the certificates are rotated.
+type ManagedTLS struct {
+	// Issue the certificates with cert-manager instead of the CA generated by the operator
+	CertManager *ManagedTLSCertManager `json:"certManager,omitempty"`
+	// Validity of the issued certificates (default: 2160h)
+	Duration *metav1.Duration `json:"duration,omitempty"`
+	// Renew the certificates this long before they expire (default: 720h)
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
+// ManagedTLSCertManager selects the cert-manager issuer of the managed certificates
+type ManagedTLSCertManager struct {
+	// Name of the issuer
+	IssuerName string `json:"issuerName"`
+	// Kind of the issuer (default: Issuer)
+	// +kubebuilder:validation:Enum=Issuer;ClusterIssuer
+	IssuerKind string `json:"issuerKind,omitempty"`
+	// API group of the issuer (default: cert-manager.io)
+	IssuerGroup string `json:"issuerGroup,omitempty"`
+}
+
+// ManagedTLSCASecretName is the name of the secret holding the CA generated by the operator
+func (l *Logging) ManagedTLSCASecretName() string {
+	return l.QualifiedName("managed-tls-ca")
+}
+
+// ManagedTLSAggregatorSecretName is the name of the secret holding the server certificate of the aggregators
+func (l *Logging) ManagedTLSAggregatorSecretName() string {
+	return l.QualifiedName("aggregator-tls")
+}
+
+// ManagedTLSAgentSecretName is the name of the secret holding the client certificate of the agents
+func (l *Logging) ManagedTLSAgentSecretName() string {
+	return l.QualifiedName("agent-tls")
+}
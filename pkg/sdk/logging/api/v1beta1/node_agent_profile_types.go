@@ -0,0 +1,70 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"github.com/cisco-open/operator-tools/pkg/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +name:"NodeAgentProfile"
+// +weight:"200"
+type _hugoNodeAgentProfile interface{} //nolint:deadcode,unused
+
+// +name:"NodeAgentProfile"
+// +version:"v1beta1"
+// +description:"NodeAgentProfile defines reusable defaults for NodeAgents"
+type _metaNodeAgentProfile interface{} //nolint:deadcode,unused
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:categories=logging-all,scope=Cluster
+// +kubebuilder:printcolumn:name="Base",type="string",JSONPath=".spec.baseProfile",description="Profile the defaults are based on"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:storageversion
+
+// NodeAgentProfile defines a named set of NodeAgent defaults, NodeAgents select it with the profile field
+// the same way as the built-in profiles.
+type NodeAgentProfile struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec NodeAgentProfileSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
+// NodeAgentProfileSpec defines the defaults of the NodeAgents using the profile
+type NodeAgentProfileSpec struct {
+	// Name of the built-in profile (linux, linux-docker, linux-containerd, k3s, openshift, bottlerocket, windows)
+	// or another NodeAgentProfile this profile is based on, unknown profiles fall back to linux (default: linux)
+	BaseProfile string `json:"baseProfile,omitempty"`
+	// Default metadata of the NodeAgent resources
+	Metadata types.MetaBase `json:"metadata,omitempty"`
+	// Default fluent-bit settings, the settings of the NodeAgents take precedence
+	FluentbitSpec *NodeAgentFluentbit `json:"nodeAgentFluentbit,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NodeAgentProfileList contains a list of NodeAgentProfiles
+type NodeAgentProfileList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NodeAgentProfile `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NodeAgentProfile{}, &NodeAgentProfileList{})
+}
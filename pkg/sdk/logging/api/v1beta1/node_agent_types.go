@@ -52,6 +52,8 @@ type NodeAgentSpec struct {
 // +kubebuilder:object:generate=true
 
 type NodeAgentConfig struct {
+	// Name of the built-in profile (linux, linux-docker, linux-containerd, k3s, openshift, bottlerocket, windows)
+	// or the NodeAgentProfile the defaults of the agent are taken from, unknown profiles fall back to linux (default: linux)
 	Profile       string              `json:"profile,omitempty"`
 	Metadata      types.MetaBase      `json:"metadata,omitempty"`
 	FluentbitSpec *NodeAgentFluentbit `json:"nodeAgentFluentbit,omitempty"`
@@ -58,6 +58,9 @@ type OutputSpec struct {
 	SQSOutputConfig              *output.SQSOutputConfig              `json:"sqs,omitempty"`
 	MattermostOutputConfig       *output.MattermostOutputConfig       `json:"mattermost,omitempty"`
 	RelabelOutputConfig          *output.RelabelOutputConfig          `json:"relabel,omitempty"`
+	NATSOutputConfig             *output.NATSOutputConfig             `json:"nats,omitempty"`
+	RabbitMQOutputConfig         *output.RabbitMQOutputConfig         `json:"rabbitmq,omitempty"`
+	GCloudPubSubOutputConfig     *output.GCloudPubSubOutputConfig     `json:"gcloudPubsub,omitempty"`
 }
 
 // OutputStatus defines the observed state of Output
@@ -49,6 +49,9 @@ type SyslogNGClusterFlowSpec struct {
 	Filters          []SyslogNGFilter `json:"filters,omitempty"`
 	LoggingRef       string           `json:"loggingRef,omitempty"`
 	GlobalOutputRefs []string         `json:"globalOutputRefs,omitempty"`
+	// Names of the network sources of the syslog-ng spec to read the logs from.
+	// By default the flow processes the Kubernetes logs forwarded by the node agents.
+	Sources []string `json:"sources,omitempty"`
 }
 
 type SyslogNGClusterMatch SyslogNGMatch
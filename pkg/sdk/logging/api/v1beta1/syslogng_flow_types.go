@@ -52,6 +52,16 @@ type SyslogNGFilter struct {
 	Parser  *filter.ParserConfig   `json:"parser,omitempty" syslog-ng:"xform-kind=parser"`
 }
 
+// PatternDBRefs returns the names of the pattern databases referenced by the db-parser filters
+func PatternDBRefs(filters []SyslogNGFilter) (refs []string) {
+	for _, f := range filters {
+		if f.Parser != nil && f.Parser.DBParser != nil {
+			refs = append(refs, string(f.Parser.DBParser.PatternDB))
+		}
+	}
+	return
+}
+
 type SyslogNGFlowStatus FlowStatus
 
 // +kubebuilder:object:root=true
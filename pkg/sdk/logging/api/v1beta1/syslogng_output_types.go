@@ -39,6 +39,12 @@ type SyslogNGOutputSpec struct {
 	SumologicSyslog *output.SumologicSyslogOutput `json:"sumologic-syslog,omitempty" syslog-ng:"dest-drv"`
 	HTTP            *output.HTTPOutput            `json:"http,omitempty" syslog-ng:"dest-drv"`
 	LogScale        *output.LogScaleOutput        `json:"logscale,omitempty" syslog-ng:"dest-drv"`
+	Elasticsearch   *output.ElasticsearchOutput   `json:"elasticsearch-http,omitempty" syslog-ng:"dest-drv"`
+	OpenSearch      *output.OpenSearchOutput      `json:"opensearch,omitempty" syslog-ng:"dest-drv"`
+	Loki            *output.LokiOutput            `json:"loki,omitempty" syslog-ng:"dest-drv"`
+	Kafka           *output.KafkaOutput           `json:"kafka,omitempty" syslog-ng:"name=kafka-c,dest-drv"`
+	S3              *output.S3Output              `json:"s3,omitempty" syslog-ng:"dest-drv"`
+	OpenTelemetry   *output.OpenTelemetryOutput   `json:"opentelemetry,omitempty" syslog-ng:"dest-drv"`
 }
 
 type SyslogNGOutputStatus OutputStatus
@@ -15,7 +15,11 @@
 package v1beta1
 
 import (
+	"fmt"
+
 	"github.com/cisco-open/operator-tools/pkg/typeoverride"
+	"github.com/cisco-open/operator-tools/pkg/volume"
+	corev1 "k8s.io/api/core/v1"
 )
 
 // +name:"SyslogNGSpec"
@@ -47,13 +51,149 @@ type SyslogNGSpec struct {
 	JSONKeyDelimiter                    string                       `json:"jsonKeyDelim,omitempty"`
 	MaxConnections                      int                          `json:"maxConnections,omitempty"`
 	LogIWSize                           int                          `json:"logIWSize,omitempty"`
+	// Accept logs from OpenTelemetry instrumented applications over OTLP/gRPC.
+	// The Kubernetes resource attributes of the records are mapped to the keys used by the flows.
+	OTLPSource *SyslogNGOTLPSource `json:"otlpSource,omitempty"`
+	// Pattern databases mounted into the syslog-ng pods, the db-parser filters of the flows reference them by name.
+	PatternDBs []SyslogNGPatternDB `json:"patternDBs,omitempty"`
+	// Additional syslog listeners that accept logs directly from network devices, exposed through the syslog-ng service.
+	// SyslogNGClusterFlows can select them by name.
+	NetworkSources []SyslogNGNetworkSource `json:"networkSources,omitempty"`
+	// Scaling of the syslog-ng statefulset and draining of the disk-buffers left behind after scaling down
+	Scaling *SyslogNGScaling `json:"scaling,omitempty"`
+	// BufferStorageVolume is mounted to /buffers, the disk-buffers of the outputs are stored there by default.
+	// A persistentVolumeClaim is added to the volume claim templates of the statefulset, a hostPath or emptyDir to the pod volumes.
+	// No volume is added unless configured, or if the statefulset overrides already mount a volume to /buffers.
+	BufferStorageVolume volume.KubernetesVolume `json:"bufferStorageVolume,omitempty"`
+}
+
+// BufferStorageVolumeConfigured returns true if the buffer storage volume is set in the spec
+func (s *SyslogNGSpec) BufferStorageVolumeConfigured() bool {
+	v := s.BufferStorageVolume
+	return v.HostPathLegacy != nil || v.HostPath != nil || v.EmptyDir != nil || v.PersistentVolumeClaim != nil
+}
+
+// SyslogNGBufferStoragePath is the directory the buffer storage volume is mounted to
+const SyslogNGBufferStoragePath = "/buffers"
+
+// +kubebuilder:object:generate=true
+
+// SyslogNGPatternDB defines a pattern database XML provided in a ConfigMap or a Secret of the control namespace
+type SyslogNGPatternDB struct {
+	// Name of the pattern database
+	Name string `json:"name"`
+	// ConfigMap key containing the pattern database XML
+	ConfigMap *corev1.ConfigMapKeySelector `json:"configMap,omitempty"`
+	// Secret key containing the pattern database XML
+	Secret *corev1.SecretKeySelector `json:"secret,omitempty"`
+}
+
+// Validate checks that the pattern database is provided by exactly one of a ConfigMap or a Secret
+func (db SyslogNGPatternDB) Validate() error {
+	if (db.ConfigMap == nil) == (db.Secret == nil) {
+		return fmt.Errorf("pattern database %s must reference exactly one of configMap or secret", db.Name)
+	}
+	return nil
+}
+
+// HasPatternDB returns true if a pattern database with the given name is listed in the spec
+func (s *SyslogNGSpec) HasPatternDB(name string) bool {
+	if s == nil {
+		return false
+	}
+	for _, db := range s.PatternDBs {
+		if db.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// +kubebuilder:object:generate=true
+
+// SyslogNGScaling defines the scaling of the syslog-ng statefulset
+type SyslogNGScaling struct {
+	// Number of syslog-ng replicas, the replicas set in the statefulset overrides take precedence
+	Replicas int                 `json:"replicas,omitempty"`
+	Drain    SyslogNGDrainConfig `json:"drain,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
+// SyslogNGDrainConfig enables configuring the drain behavior when scaling down the syslog-ng statefulset
+type SyslogNGDrainConfig struct {
+	// Should disk-buffers on persistent volumes left after scaling down the statefulset be drained
+	Enabled bool `json:"enabled,omitempty"`
+	// Annotations of the drainer pods
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Should persistent volume claims be deleted after draining is done
+	DeleteVolume bool `json:"deleteVolume,omitempty"`
+	// Container image to use for the drain watch sidecar (default: the syslog-ng image)
+	Image ImageSpec `json:"image,omitempty"`
+	// Container image to use for the syslog-ng placeholder pod
+	PauseImage ImageSpec `json:"pauseImage,omitempty"`
+}
+
+// SyslogNGTLSDir is the directory the TLS secret of the source of the agents is mounted to
+const SyslogNGTLSDir = "/syslog-ng/tls"
+
+// SyslogNGNetworkSourceTLSDir is the directory the TLS secrets of the network sources are mounted under
+const SyslogNGNetworkSourceTLSDir = "/syslog-ng/sources"
+
+// +kubebuilder:object:generate=true
+
+// SyslogNGNetworkSource defines a syslog listener of syslog-ng
+type SyslogNGNetworkSource struct {
+	// Name of the source, SyslogNGClusterFlows select the source by this name
+	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	Name string `json:"name"`
+	// Port to listen on, it must differ from the port of the main source (601), the OTLP source and the other network sources of the same protocol (tcp and tls listeners share the tcp ports)
+	Port int32 `json:"port"`
+	// Transport protocol of the listener (default: tcp)
+	// +kubebuilder:validation:Enum=tcp;udp;tls
+	Transport string `json:"transport,omitempty"`
+	// Format of the incoming messages: rfc3164 (BSD syslog) or rfc5424 (IETF syslog with octet counting framing) (default: rfc3164)
+	// +kubebuilder:validation:Enum=rfc3164;rfc5424
+	Protocol string `json:"protocol,omitempty"`
+	// Flags of the source driver, for example no-parse, no-multi-line, store-raw-message
+	Flags []string `json:"flags,omitempty"`
+	// Maximum number of simultaneous connections
+	MaxConnections int `json:"maxConnections,omitempty"`
+	// TLS settings, required by the tls transport
+	TLS *SyslogNGNetworkSourceTLS `json:"tls,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
+// SyslogNGNetworkSourceTLS defines the TLS settings of a network source
+type SyslogNGNetworkSourceTLS struct {
+	// Name of the Secret containing the server certificate (tls.crt), its key (tls.key)
+	// and optionally the CA certificate (ca.crt) used to verify the client certificates
+	SecretName string `json:"secretName"`
+	// The Secret contains the CA certificate (ca.crt), it is used to verify the client certificates
+	CACert bool `json:"caCert,omitempty"`
+	// Verification method of the client certificates (default: optional-untrusted)
+	// +kubebuilder:validation:Enum=optional-trusted;optional-untrusted;required-trusted;required-untrusted
+	PeerVerify string `json:"peerVerify,omitempty"`
+}
+
+// TLSDir returns the directory the TLS secret of the source is mounted to
+func (s SyslogNGNetworkSource) TLSDir() string {
+	return SyslogNGNetworkSourceTLSDir + "/" + s.Name
+}
+
+// +kubebuilder:object:generate=true
 
-	// TODO: option to turn on/off buffer volume PVC
+// SyslogNGOTLPSource defines the OTLP/gRPC source of syslog-ng
+type SyslogNGOTLPSource struct {
+	// Port of the OTLP/gRPC listener (default: 4317)
+	Port int32 `json:"port,omitempty"`
 }
 
 // +kubebuilder:object:generate=true
 
-// SyslogNGTLS defines the TLS configs
+// SyslogNGTLS defines the TLS configs of the source of the agents.
+// When enabled, the source requires the client certificates of the agents signed by the ca.crt of the secret.
 type SyslogNGTLS struct {
 	Enabled    bool   `json:"enabled"`
 	SecretName string `json:"secretName,omitempty"`
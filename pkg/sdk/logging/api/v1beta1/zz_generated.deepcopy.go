@@ -29,6 +29,7 @@ import (
 	syslogngoutput "github.com/kube-logging/logging-operator/pkg/sdk/logging/model/syslogng/output"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -389,6 +390,128 @@ func (in *DefaultFlowSpec) DeepCopy() *DefaultFlowSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DirectOutputElasticsearch) DeepCopyInto(out *DirectOutputElasticsearch) {
+	*out = *in
+	if in.HTTPPasswd != nil {
+		in, out := &in.HTTPPasswd, &out.HTTPPasswd
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DirectOutputElasticsearch.
+func (in *DirectOutputElasticsearch) DeepCopy() *DirectOutputElasticsearch {
+	if in == nil {
+		return nil
+	}
+	out := new(DirectOutputElasticsearch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DirectOutputHTTP) DeepCopyInto(out *DirectOutputHTTP) {
+	*out = *in
+	if in.Header != nil {
+		in, out := &in.Header, &out.Header
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HTTPPasswd != nil {
+		in, out := &in.HTTPPasswd, &out.HTTPPasswd
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DirectOutputHTTP.
+func (in *DirectOutputHTTP) DeepCopy() *DirectOutputHTTP {
+	if in == nil {
+		return nil
+	}
+	out := new(DirectOutputHTTP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DirectOutputKafka) DeepCopyInto(out *DirectOutputKafka) {
+	*out = *in
+	if in.RDKafkaOptions != nil {
+		in, out := &in.RDKafkaOptions, &out.RDKafkaOptions
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SASLPassword != nil {
+		in, out := &in.SASLPassword, &out.SASLPassword
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DirectOutputKafka.
+func (in *DirectOutputKafka) DeepCopy() *DirectOutputKafka {
+	if in == nil {
+		return nil
+	}
+	out := new(DirectOutputKafka)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DirectOutputLoki) DeepCopyInto(out *DirectOutputLoki) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LabelKeys != nil {
+		in, out := &in.LabelKeys, &out.LabelKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RemoveKeys != nil {
+		in, out := &in.RemoveKeys, &out.RemoveKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HTTPPasswd != nil {
+		in, out := &in.HTTPPasswd, &out.HTTPPasswd
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DirectOutputLoki.
+func (in *DirectOutputLoki) DeepCopy() *DirectOutputLoki {
+	if in == nil {
+		return nil
+	}
+	out := new(DirectOutputLoki)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DirectOutputS3) DeepCopyInto(out *DirectOutputS3) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DirectOutputS3.
+func (in *DirectOutputS3) DeepCopy() *DirectOutputS3 {
+	if in == nil {
+		return nil
+	}
+	out := new(DirectOutputS3)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Exclude) DeepCopyInto(out *Exclude) {
 	*out = *in
@@ -524,6 +647,21 @@ func (in *Filter) DeepCopyInto(out *Filter) {
 		*out = new(filter.KubeEventsTimestampConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Redact != nil {
+		in, out := &in.Redact, &out.Redact
+		*out = new(filter.Redact)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RewriteTag != nil {
+		in, out := &in.RewriteTag, &out.RewriteTag
+		*out = new(filter.RewriteTag)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Dedup != nil {
+		in, out := &in.Dedup, &out.Dedup
+		*out = new(filter.Dedup)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Filter.
@@ -591,6 +729,31 @@ func (in *FilterAws) DeepCopy() *FilterAws {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilterGrep) DeepCopyInto(out *FilterGrep) {
+	*out = *in
+	if in.Regex != nil {
+		in, out := &in.Regex, &out.Regex
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilterGrep.
+func (in *FilterGrep) DeepCopy() *FilterGrep {
+	if in == nil {
+		return nil
+	}
+	out := new(FilterGrep)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FilterKey) DeepCopyInto(out *FilterKey) {
 	*out = *in
@@ -636,6 +799,42 @@ func (in *FilterKubernetes) DeepCopy() *FilterKubernetes {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilterLua) DeepCopyInto(out *FilterLua) {
+	*out = *in
+	in.Script.DeepCopyInto(&out.Script)
+	if in.ProtectedMode != nil {
+		in, out := &in.ProtectedMode, &out.ProtectedMode
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TimeAsTable != nil {
+		in, out := &in.TimeAsTable, &out.TimeAsTable
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TypeIntKey != nil {
+		in, out := &in.TypeIntKey, &out.TypeIntKey
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TypeArrayKey != nil {
+		in, out := &in.TypeArrayKey, &out.TypeArrayKey
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilterLua.
+func (in *FilterLua) DeepCopy() *FilterLua {
+	if in == nil {
+		return nil
+	}
+	out := new(FilterLua)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FilterModify) DeepCopyInto(out *FilterModify) {
 	*out = *in
@@ -790,6 +989,101 @@ func (in *FilterModifyRule) DeepCopy() *FilterModifyRule {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilterMultiline) DeepCopyInto(out *FilterMultiline) {
+	*out = *in
+	if in.Parser != nil {
+		in, out := &in.Parser, &out.Parser
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Buffer != nil {
+		in, out := &in.Buffer, &out.Buffer
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilterMultiline.
+func (in *FilterMultiline) DeepCopy() *FilterMultiline {
+	if in == nil {
+		return nil
+	}
+	out := new(FilterMultiline)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilterNest) DeepCopyInto(out *FilterNest) {
+	*out = *in
+	if in.Wildcard != nil {
+		in, out := &in.Wildcard, &out.Wildcard
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilterNest.
+func (in *FilterNest) DeepCopy() *FilterNest {
+	if in == nil {
+		return nil
+	}
+	out := new(FilterNest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilterRewriteTag) DeepCopyInto(out *FilterRewriteTag) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]FilterRewriteTagRule, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilterRewriteTag.
+func (in *FilterRewriteTag) DeepCopy() *FilterRewriteTag {
+	if in == nil {
+		return nil
+	}
+	out := new(FilterRewriteTag)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilterRewriteTagRule) DeepCopyInto(out *FilterRewriteTagRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilterRewriteTagRule.
+func (in *FilterRewriteTagRule) DeepCopy() *FilterRewriteTagRule {
+	if in == nil {
+		return nil
+	}
+	out := new(FilterRewriteTagRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilterThrottle) DeepCopyInto(out *FilterThrottle) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilterThrottle.
+func (in *FilterThrottle) DeepCopy() *FilterThrottle {
+	if in == nil {
+		return nil
+	}
+	out := new(FilterThrottle)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Flow) DeepCopyInto(out *Flow) {
 	*out = *in
@@ -945,13 +1239,43 @@ func (in *FluentOutLogrotate) DeepCopy() *FluentOutLogrotate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FluentbitAdditionalInput) DeepCopyInto(out *FluentbitAdditionalInput) {
+	*out = *in
+	if in.Systemd != nil {
+		in, out := &in.Systemd, &out.Systemd
+		*out = new(InputSystemd)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tail != nil {
+		in, out := &in.Tail, &out.Tail
+		*out = new(InputHostTail)
+		**out = **in
+	}
+	if in.Kmsg != nil {
+		in, out := &in.Kmsg, &out.Kmsg
+		*out = new(InputKmsg)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluentbitAdditionalInput.
+func (in *FluentbitAdditionalInput) DeepCopy() *FluentbitAdditionalInput {
+	if in == nil {
+		return nil
+	}
+	out := new(FluentbitAdditionalInput)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FluentbitAgent) DeepCopyInto(out *FluentbitAgent) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluentbitAgent.
@@ -1004,6 +1328,133 @@ func (in *FluentbitAgentList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FluentbitDirectOutput) DeepCopyInto(out *FluentbitDirectOutput) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Workers != nil {
+		in, out := &in.Workers, &out.Workers
+		*out = new(int)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(FluentbitDirectOutputTLS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Loki != nil {
+		in, out := &in.Loki, &out.Loki
+		*out = new(DirectOutputLoki)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(DirectOutputS3)
+		**out = **in
+	}
+	if in.Elasticsearch != nil {
+		in, out := &in.Elasticsearch, &out.Elasticsearch
+		*out = new(DirectOutputElasticsearch)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(DirectOutputHTTP)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Kafka != nil {
+		in, out := &in.Kafka, &out.Kafka
+		*out = new(DirectOutputKafka)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluentbitDirectOutput.
+func (in *FluentbitDirectOutput) DeepCopy() *FluentbitDirectOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(FluentbitDirectOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FluentbitDirectOutputTLS) DeepCopyInto(out *FluentbitDirectOutputTLS) {
+	*out = *in
+	if in.Verify != nil {
+		in, out := &in.Verify, &out.Verify
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluentbitDirectOutputTLS.
+func (in *FluentbitDirectOutputTLS) DeepCopy() *FluentbitDirectOutputTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(FluentbitDirectOutputTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FluentbitFilter) DeepCopyInto(out *FluentbitFilter) {
+	*out = *in
+	if in.Grep != nil {
+		in, out := &in.Grep, &out.Grep
+		*out = new(FilterGrep)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Lua != nil {
+		in, out := &in.Lua, &out.Lua
+		*out = new(FilterLua)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Nest != nil {
+		in, out := &in.Nest, &out.Nest
+		*out = new(FilterNest)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RewriteTag != nil {
+		in, out := &in.RewriteTag, &out.RewriteTag
+		*out = new(FilterRewriteTag)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Throttle != nil {
+		in, out := &in.Throttle, &out.Throttle
+		*out = new(FilterThrottle)
+		**out = **in
+	}
+	if in.Multiline != nil {
+		in, out := &in.Multiline, &out.Multiline
+		*out = new(FilterMultiline)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluentbitFilter.
+func (in *FluentbitFilter) DeepCopy() *FluentbitFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(FluentbitFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FluentbitNameProvider) DeepCopyInto(out *FluentbitNameProvider) {
 	*out = *in
@@ -1159,6 +1610,13 @@ func (in *FluentbitSpec) DeepCopyInto(out *FluentbitSpec) {
 		}
 	}
 	in.InputTail.DeepCopyInto(&out.InputTail)
+	if in.AdditionalInputs != nil {
+		in, out := &in.AdditionalInputs, &out.AdditionalInputs
+		*out = make([]FluentbitAdditionalInput, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.FilterAws != nil {
 		in, out := &in.FilterAws, &out.FilterAws
 		*out = new(FilterAws)
@@ -1171,6 +1629,20 @@ func (in *FluentbitSpec) DeepCopyInto(out *FluentbitSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = make([]FluentbitFilter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DirectOutputs != nil {
+		in, out := &in.DirectOutputs, &out.DirectOutputs
+		*out = make([]FluentbitDirectOutput, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	out.FilterKubernetes = in.FilterKubernetes
 	if in.DisableKubernetesFilter != nil {
 		in, out := &in.DisableKubernetesFilter, &out.DisableKubernetesFilter
@@ -1210,6 +1682,11 @@ func (in *FluentbitSpec) DeepCopyInto(out *FluentbitSpec) {
 		*out = new(ForwardOptions)
 		**out = **in
 	}
+	if in.UpstreamDiscovery != nil {
+		in, out := &in.UpstreamDiscovery, &out.UpstreamDiscovery
+		*out = new(FluentbitUpstreamDiscovery)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ServiceAccountOverrides != nil {
 		in, out := &in.ServiceAccountOverrides, &out.ServiceAccountOverrides
 		*out = new(typeoverride.ServiceAccount)
@@ -1226,6 +1703,16 @@ func (in *FluentbitSpec) DeepCopyInto(out *FluentbitSpec) {
 		**out = **in
 	}
 	in.UpdateStrategy.DeepCopyInto(&out.UpdateStrategy)
+	if in.ConfigCheckPodOverrides != nil {
+		in, out := &in.ConfigCheckPodOverrides, &out.ConfigCheckPodOverrides
+		*out = new(typeoverride.PodSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigHotReload != nil {
+		in, out := &in.ConfigHotReload, &out.ConfigHotReload
+		*out = new(HotReload)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluentbitSpec.
@@ -1241,6 +1728,23 @@ func (in *FluentbitSpec) DeepCopy() *FluentbitSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FluentbitStatus) DeepCopyInto(out *FluentbitStatus) {
 	*out = *in
+	if in.ConfigCheckResults != nil {
+		in, out := &in.ConfigCheckResults, &out.ConfigCheckResults
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.CrashLoopingNodes != nil {
+		in, out := &in.CrashLoopingNodes, &out.CrashLoopingNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StorageBacklog != nil {
+		in, out := &in.StorageBacklog, &out.StorageBacklog
+		*out = new(FluentbitStorageBacklog)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluentbitStatus.
@@ -1253,6 +1757,26 @@ func (in *FluentbitStatus) DeepCopy() *FluentbitStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FluentbitStorageBacklog) DeepCopyInto(out *FluentbitStorageBacklog) {
+	*out = *in
+	if in.BackloggedNodes != nil {
+		in, out := &in.BackloggedNodes, &out.BackloggedNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluentbitStorageBacklog.
+func (in *FluentbitStorageBacklog) DeepCopy() *FluentbitStorageBacklog {
+	if in == nil {
+		return nil
+	}
+	out := new(FluentbitStorageBacklog)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FluentbitTCPOutput) DeepCopyInto(out *FluentbitTCPOutput) {
 	*out = *in
@@ -1288,6 +1812,26 @@ func (in *FluentbitTLS) DeepCopy() *FluentbitTLS {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FluentbitUpstreamDiscovery) DeepCopyInto(out *FluentbitUpstreamDiscovery) {
+	*out = *in
+	if in.RefreshInterval != nil {
+		in, out := &in.RefreshInterval, &out.RefreshInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluentbitUpstreamDiscovery.
+func (in *FluentbitUpstreamDiscovery) DeepCopy() *FluentbitUpstreamDiscovery {
+	if in == nil {
+		return nil
+	}
+	out := new(FluentbitUpstreamDiscovery)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FluentdDrainConfig) DeepCopyInto(out *FluentdDrainConfig) {
 	*out = *in
@@ -1376,6 +1920,11 @@ func (in *FluentdSpec) DeepCopyInto(out *FluentdSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.GrokPatternConfigMaps != nil {
+		in, out := &in.GrokPatternConfigMaps, &out.GrokPatternConfigMaps
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.FluentdPvcSpec != nil {
 		in, out := &in.FluentdPvcSpec, &out.FluentdPvcSpec
 		*out = new(volume.KubernetesVolume)
@@ -1546,6 +2095,23 @@ func (in *GlobalOptions) DeepCopy() *GlobalOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HotReload) DeepCopyInto(out *HotReload) {
+	*out = *in
+	in.Image.DeepCopyInto(&out.Image)
+	in.Resources.DeepCopyInto(&out.Resources)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HotReload.
+func (in *HotReload) DeepCopy() *HotReload {
+	if in == nil {
+		return nil
+	}
+	out := new(HotReload)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImageSpec) DeepCopyInto(out *ImageSpec) {
 	*out = *in
@@ -1577,7 +2143,57 @@ func (in *InlineNodeAgent) DeepCopy() *InlineNodeAgent {
 	if in == nil {
 		return nil
 	}
-	out := new(InlineNodeAgent)
+	out := new(InlineNodeAgent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InputHostTail) DeepCopyInto(out *InputHostTail) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InputHostTail.
+func (in *InputHostTail) DeepCopy() *InputHostTail {
+	if in == nil {
+		return nil
+	}
+	out := new(InputHostTail)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InputKmsg) DeepCopyInto(out *InputKmsg) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InputKmsg.
+func (in *InputKmsg) DeepCopy() *InputKmsg {
+	if in == nil {
+		return nil
+	}
+	out := new(InputKmsg)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InputSystemd) DeepCopyInto(out *InputSystemd) {
+	*out = *in
+	if in.SystemdFilter != nil {
+		in, out := &in.SystemdFilter, &out.SystemdFilter
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InputSystemd.
+func (in *InputSystemd) DeepCopy() *InputSystemd {
+	if in == nil {
+		return nil
+	}
+	out := new(InputSystemd)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1706,6 +2322,13 @@ func (in *LoggingSpec) DeepCopyInto(out *LoggingSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.EnforcedFilters != nil {
+		in, out := &in.EnforcedFilters, &out.EnforcedFilters
+		*out = make([]Filter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.WatchNamespaces != nil {
 		in, out := &in.WatchNamespaces, &out.WatchNamespaces
 		*out = make([]string, len(*in))
@@ -1727,6 +2350,11 @@ func (in *LoggingSpec) DeepCopyInto(out *LoggingSpec) {
 			}
 		}
 	}
+	if in.ManagedTLS != nil {
+		in, out := &in.ManagedTLS, &out.ManagedTLS
+		*out = new(ManagedTLS)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoggingSpec.
@@ -1766,6 +2394,51 @@ func (in *LoggingStatus) DeepCopy() *LoggingStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedTLS) DeepCopyInto(out *ManagedTLS) {
+	*out = *in
+	if in.CertManager != nil {
+		in, out := &in.CertManager, &out.CertManager
+		*out = new(ManagedTLSCertManager)
+		**out = **in
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedTLS.
+func (in *ManagedTLS) DeepCopy() *ManagedTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedTLSCertManager) DeepCopyInto(out *ManagedTLSCertManager) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedTLSCertManager.
+func (in *ManagedTLSCertManager) DeepCopy() *ManagedTLSCertManager {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedTLSCertManager)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Match) DeepCopyInto(out *Match) {
 	*out = *in
@@ -1983,6 +2656,85 @@ func (in *NodeAgentList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeAgentProfile) DeepCopyInto(out *NodeAgentProfile) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeAgentProfile.
+func (in *NodeAgentProfile) DeepCopy() *NodeAgentProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeAgentProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodeAgentProfile) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeAgentProfileList) DeepCopyInto(out *NodeAgentProfileList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NodeAgentProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeAgentProfileList.
+func (in *NodeAgentProfileList) DeepCopy() *NodeAgentProfileList {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeAgentProfileList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodeAgentProfileList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeAgentProfileSpec) DeepCopyInto(out *NodeAgentProfileSpec) {
+	*out = *in
+	in.Metadata.DeepCopyInto(&out.Metadata)
+	if in.FluentbitSpec != nil {
+		in, out := &in.FluentbitSpec, &out.FluentbitSpec
+		*out = new(NodeAgentFluentbit)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeAgentProfileSpec.
+func (in *NodeAgentProfileSpec) DeepCopy() *NodeAgentProfileSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeAgentProfileSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeAgentSpec) DeepCopyInto(out *NodeAgentSpec) {
 	*out = *in
@@ -2226,6 +2978,21 @@ func (in *OutputSpec) DeepCopyInto(out *OutputSpec) {
 		*out = new(output.RelabelOutputConfig)
 		**out = **in
 	}
+	if in.NATSOutputConfig != nil {
+		in, out := &in.NATSOutputConfig, &out.NATSOutputConfig
+		*out = new(output.NATSOutputConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RabbitMQOutputConfig != nil {
+		in, out := &in.RabbitMQOutputConfig, &out.RabbitMQOutputConfig
+		*out = new(output.RabbitMQOutputConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GCloudPubSubOutputConfig != nil {
+		in, out := &in.GCloudPubSubOutputConfig, &out.GCloudPubSubOutputConfig
+		*out = new(output.GCloudPubSubOutputConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutputSpec.
@@ -2493,6 +3260,11 @@ func (in *SyslogNGClusterFlowSpec) DeepCopyInto(out *SyslogNGClusterFlowSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyslogNGClusterFlowSpec.
@@ -2532,6 +3304,36 @@ func (in *SyslogNGClusterMatch) DeepCopyInto(out *SyslogNGClusterMatch) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Facility != nil {
+		in, out := &in.Facility, &out.Facility
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Level != nil {
+		in, out := &in.Level, &out.Level
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Host != nil {
+		in, out := &in.Host, &out.Host
+		*out = new(syslogngfilter.PatternMatchExpr)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Program != nil {
+		in, out := &in.Program, &out.Program
+		*out = new(syslogngfilter.PatternMatchExpr)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InList != nil {
+		in, out := &in.InList, &out.InList
+		*out = new(syslogngfilter.InListMatchExpr)
+		**out = **in
+	}
+	if in.Compare != nil {
+		in, out := &in.Compare, &out.Compare
+		*out = new(syslogngfilter.CompareMatchExpr)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyslogNGClusterMatch.
@@ -2624,6 +3426,30 @@ func (in *SyslogNGClusterOutputSpec) DeepCopy() *SyslogNGClusterOutputSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyslogNGDrainConfig) DeepCopyInto(out *SyslogNGDrainConfig) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Image.DeepCopyInto(&out.Image)
+	in.PauseImage.DeepCopyInto(&out.PauseImage)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyslogNGDrainConfig.
+func (in *SyslogNGDrainConfig) DeepCopy() *SyslogNGDrainConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SyslogNGDrainConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SyslogNGFilter) DeepCopyInto(out *SyslogNGFilter) {
 	*out = *in
@@ -2804,6 +3630,36 @@ func (in *SyslogNGMatch) DeepCopyInto(out *SyslogNGMatch) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Facility != nil {
+		in, out := &in.Facility, &out.Facility
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Level != nil {
+		in, out := &in.Level, &out.Level
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Host != nil {
+		in, out := &in.Host, &out.Host
+		*out = new(syslogngfilter.PatternMatchExpr)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Program != nil {
+		in, out := &in.Program, &out.Program
+		*out = new(syslogngfilter.PatternMatchExpr)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InList != nil {
+		in, out := &in.InList, &out.InList
+		*out = new(syslogngfilter.InListMatchExpr)
+		**out = **in
+	}
+	if in.Compare != nil {
+		in, out := &in.Compare, &out.Compare
+		*out = new(syslogngfilter.CompareMatchExpr)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyslogNGMatch.
@@ -2816,6 +3672,61 @@ func (in *SyslogNGMatch) DeepCopy() *SyslogNGMatch {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyslogNGNetworkSource) DeepCopyInto(out *SyslogNGNetworkSource) {
+	*out = *in
+	if in.Flags != nil {
+		in, out := &in.Flags, &out.Flags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(SyslogNGNetworkSourceTLS)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyslogNGNetworkSource.
+func (in *SyslogNGNetworkSource) DeepCopy() *SyslogNGNetworkSource {
+	if in == nil {
+		return nil
+	}
+	out := new(SyslogNGNetworkSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyslogNGNetworkSourceTLS) DeepCopyInto(out *SyslogNGNetworkSourceTLS) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyslogNGNetworkSourceTLS.
+func (in *SyslogNGNetworkSourceTLS) DeepCopy() *SyslogNGNetworkSourceTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(SyslogNGNetworkSourceTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyslogNGOTLPSource) DeepCopyInto(out *SyslogNGOTLPSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyslogNGOTLPSource.
+func (in *SyslogNGOTLPSource) DeepCopy() *SyslogNGOTLPSource {
+	if in == nil {
+		return nil
+	}
+	out := new(SyslogNGOTLPSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SyslogNGOutput) DeepCopyInto(out *SyslogNGOutput) {
 	*out = *in
@@ -2918,6 +3829,36 @@ func (in *SyslogNGOutputSpec) DeepCopyInto(out *SyslogNGOutputSpec) {
 		*out = new(syslogngoutput.LogScaleOutput)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Elasticsearch != nil {
+		in, out := &in.Elasticsearch, &out.Elasticsearch
+		*out = new(syslogngoutput.ElasticsearchOutput)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OpenSearch != nil {
+		in, out := &in.OpenSearch, &out.OpenSearch
+		*out = new(syslogngoutput.OpenSearchOutput)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Loki != nil {
+		in, out := &in.Loki, &out.Loki
+		*out = new(syslogngoutput.LokiOutput)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Kafka != nil {
+		in, out := &in.Kafka, &out.Kafka
+		*out = new(syslogngoutput.KafkaOutput)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(syslogngoutput.S3Output)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OpenTelemetry != nil {
+		in, out := &in.OpenTelemetry, &out.OpenTelemetry
+		*out = new(syslogngoutput.OpenTelemetryOutput)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyslogNGOutputSpec.
@@ -2955,6 +3896,47 @@ func (in *SyslogNGOutputStatus) DeepCopy() *SyslogNGOutputStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyslogNGPatternDB) DeepCopyInto(out *SyslogNGPatternDB) {
+	*out = *in
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyslogNGPatternDB.
+func (in *SyslogNGPatternDB) DeepCopy() *SyslogNGPatternDB {
+	if in == nil {
+		return nil
+	}
+	out := new(SyslogNGPatternDB)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyslogNGScaling) DeepCopyInto(out *SyslogNGScaling) {
+	*out = *in
+	in.Drain.DeepCopyInto(&out.Drain)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyslogNGScaling.
+func (in *SyslogNGScaling) DeepCopy() *SyslogNGScaling {
+	if in == nil {
+		return nil
+	}
+	out := new(SyslogNGScaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SyslogNGSpec) DeepCopyInto(out *SyslogNGSpec) {
 	*out = *in
@@ -3005,6 +3987,31 @@ func (in *SyslogNGSpec) DeepCopyInto(out *SyslogNGSpec) {
 		*out = new(GlobalOptions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.OTLPSource != nil {
+		in, out := &in.OTLPSource, &out.OTLPSource
+		*out = new(SyslogNGOTLPSource)
+		**out = **in
+	}
+	if in.PatternDBs != nil {
+		in, out := &in.PatternDBs, &out.PatternDBs
+		*out = make([]SyslogNGPatternDB, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NetworkSources != nil {
+		in, out := &in.NetworkSources, &out.NetworkSources
+		*out = make([]SyslogNGNetworkSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Scaling != nil {
+		in, out := &in.Scaling, &out.Scaling
+		*out = new(SyslogNGScaling)
+		(*in).DeepCopyInto(*out)
+	}
+	in.BufferStorageVolume.DeepCopyInto(&out.BufferStorageVolume)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyslogNGSpec.
@@ -0,0 +1,216 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/cisco-open/operator-tools/pkg/secret"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/types"
+)
+
+// +name:"Dedup"
+// +weight:"200"
+type _hugoDedup interface{} //nolint:deadcode,unused
+
+// +kubebuilder:object:generate=true
+// +docName:"Dedup Filter"
+// Collapses repeated identical records, for example the output of crash-looping or chatty containers.
+//
+// Records are considered identical if the values of all the configured keys are equal.
+// The first record of a series is passed through and the repetitions arriving within the time window are dropped.
+// The first record of the series arriving after the window carries the number of dropped repetitions in the `repeat_count_key` field.
+//
+// Limitation: fluentd filters cannot emit records on their own, so there is no summary record when the window expires.
+// If the repetitions stop, the count is only reported when the same record shows up again after the window,
+// and it is lost if the record never shows up again or its series is forgotten because of `max_entries`.
+//
+// The filter is rendered as a [record_modifier](../record_modifier/) filter that marks the repetitions
+// and a [grep](../grep/) filter that drops them, so it needs no extra fluentd plugin.
+// The series are tracked per fluentd worker. Their number is capped by `max_entries`; when the cap is reached, the oldest series is forgotten.
+type _docDedup interface{} //nolint:deadcode,unused
+
+// +name:"Dedup"
+// +url:"https://github.com/repeatedly/fluent-plugin-record-modifier"
+// +version:"2.1.0"
+// +description:"Collapse repeated identical records and count the repetitions"
+// +status:"Testing"
+type _metaDedup interface{} //nolint:deadcode,unused
+
+// DedupDefaultKeys are the record keys identifying a series when no keys are configured
+var DedupDefaultKeys = []string{
+	"$.kubernetes.namespace_name",
+	"$.kubernetes.pod_name",
+	"$.kubernetes.container_name",
+	"log",
+	"message",
+}
+
+const (
+	dedupDefaultWindow         = 10 * time.Second
+	dedupDefaultRepeatCountKey = "repeat_count"
+	dedupDefaultMaxEntries     = 10000
+	// dedupScratchKey holds the result of the ruby expression, the expression modifies the record in place
+	dedupScratchKey = "__dedup"
+	// dedupRepeatKey marks the repetitions for the grep filter dropping them
+	dedupRepeatKey = "__dedup_repeat"
+)
+
+// +kubebuilder:object:generate=true
+type Dedup struct {
+	// Record keys that identify identical records. Nested keys use the record_accessor dot syntax, e.g. $.kubernetes.pod_name
+	// (default: ["$.kubernetes.namespace_name","$.kubernetes.pod_name","$.kubernetes.container_name","log","message"])
+	Keys []string `json:"keys,omitempty"`
+	// Time window in which repeated records are collapsed, e.g. 30s or 1m (default: 10s)
+	Window string `json:"window,omitempty"`
+	// Name of the field that holds the number of repetitions (default: repeat_count)
+	RepeatCountKey string `json:"repeat_count_key,omitempty"`
+	// Maximum number of series tracked at the same time, the oldest series is forgotten when the limit is reached (default: 10000)
+	MaxEntries int `json:"max_entries,omitempty"`
+}
+
+// ## Example `Dedup` filter configurations
+// ```yaml
+// apiVersion: logging.banzaicloud.io/v1beta1
+// kind: Flow
+// metadata:
+//
+//	name: demo-flow
+//
+// spec:
+//
+//	filters:
+//	  - dedup:
+//	      keys:
+//	        - $.kubernetes.pod_name
+//	        - message
+//	      window: 30s
+//	selectors: {}
+//	localOutputRefs:
+//	  - demo-output
+//
+// ```
+//
+// #### Fluentd Config Result
+// ```yaml
+// <filter **>
+//
+//	@type record_modifier
+//	@id test_dedup
+//	prepare_value @dedup = {}; @dedup_window = 30; @dedup_max_entries = 10000
+//	remove_keys __dedup
+//	<record>
+//	  __dedup ${k = [record.dig('kubernetes', 'pod_name'), record['message']]; now = Fluent::Clock.now; e = @dedup[k]; if e && now - e[0] < @dedup_window then e[1] += 1; record['__dedup_repeat'] = 'true' else @dedup.delete(k); record['repeat_count'] = e[1] if e && e[1] > 0; @dedup.shift while @dedup.size >= @dedup_max_entries; @dedup[k] = [now, 0] end; nil}
+//	</record>
+//
+// </filter>
+// <filter **>
+//
+//	@type grep
+//	@id test_dedup_drop
+//	<exclude>
+//	  key __dedup_repeat
+//	  pattern /^true$/
+//	</exclude>
+//
+// </filter>
+// ```
+type _expDedup interface{} //nolint:deadcode,unused
+
+func (d *Dedup) ToDirective(secretLoader secret.SecretLoader, id string) (types.Directive, error) {
+	keys := d.Keys
+	if len(keys) == 0 {
+		keys = DedupDefaultKeys
+	}
+	values := make([]string, 0, len(keys))
+	for _, key := range keys {
+		value, err := dedupKeyAccessor(key)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	window := dedupDefaultWindow
+	if d.Window != "" {
+		var err error
+		if window, err = time.ParseDuration(d.Window); err != nil || window <= 0 {
+			return nil, errors.Errorf("invalid dedup window %q", d.Window)
+		}
+	}
+	repeatCountKey := d.RepeatCountKey
+	if repeatCountKey == "" {
+		repeatCountKey = dedupDefaultRepeatCountKey
+	}
+	maxEntries := d.MaxEntries
+	if maxEntries <= 0 {
+		maxEntries = dedupDefaultMaxEntries
+	}
+
+	mark := &types.GenericDirective{
+		PluginMeta: types.PluginMeta{
+			Type:      "record_modifier",
+			Directive: "filter",
+			Tag:       "**",
+			Id:        id,
+		},
+		Params: map[string]string{
+			"prepare_value": fmt.Sprintf("@dedup = {}; @dedup_window = %s; @dedup_max_entries = %d",
+				strconv.FormatFloat(window.Seconds(), 'f', -1, 64), maxEntries),
+			"remove_keys": dedupScratchKey,
+		},
+	}
+	record := Record{dedupScratchKey: fmt.Sprintf("${k = [%s]; now = Fluent::Clock.now; e = @dedup[k]; "+
+		"if e && now - e[0] < @dedup_window then e[1] += 1; record[%s] = 'true' "+
+		"else @dedup.delete(k); record[%s] = e[1] if e && e[1] > 0; @dedup.shift while @dedup.size >= @dedup_max_entries; @dedup[k] = [now, 0] end; nil}",
+		strings.Join(values, ", "), rubyString(dedupRepeatKey), rubyString(repeatCountKey))}
+	if directive, err := record.ToDirective(secretLoader, ""); err != nil {
+		return nil, err
+	} else {
+		mark.SubDirectives = append(mark.SubDirectives, directive)
+	}
+
+	drop, err := (&GrepConfig{
+		Exclude: []ExcludeSection{{Key: dedupRepeatKey, Pattern: "/^true$/"}},
+	}).ToDirective(secretLoader, id+"_drop")
+	if err != nil {
+		return nil, err
+	}
+	return &types.DirectiveChain{Directives: []types.Directive{mark, drop}}, nil
+}
+
+// dedupKeyAccessor returns the ruby expression reading the value of a plain or a record_accessor style key
+func dedupKeyAccessor(key string) (string, error) {
+	if !strings.HasPrefix(key, "$") {
+		if key == "" {
+			return "", errors.New("dedup keys cannot be empty")
+		}
+		return fmt.Sprintf("record[%s]", rubyString(key)), nil
+	}
+	path := strings.Split(strings.TrimPrefix(key, "$."), ".")
+	if !strings.HasPrefix(key, "$.") || len(path) == 0 {
+		return "", errors.Errorf("invalid dedup key %q, use the $.parent.child syntax for nested keys", key)
+	}
+	for i, part := range path {
+		if part == "" {
+			return "", errors.Errorf("invalid dedup key %q, use the $.parent.child syntax for nested keys", key)
+		}
+		path[i] = rubyString(part)
+	}
+	return fmt.Sprintf("record.dig(%s)", strings.Join(path, ", ")), nil
+}
@@ -0,0 +1,112 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cisco-open/operator-tools/pkg/secret"
+	"github.com/ghodss/yaml"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/filter"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/render"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDedup(t *testing.T) {
+	CONFIG := []byte(`
+keys:
+  - $.kubernetes.pod_name
+  - $.kubernetes.container_name
+  - message
+window: 1m30s
+repeat_count_key: repeated
+max_entries: 500
+`)
+	expected := `
+<filter **>
+  @type record_modifier
+  @id test
+  prepare_value @dedup = {}; @dedup_window = 90; @dedup_max_entries = 500
+  remove_keys __dedup
+  <record>
+    __dedup ${k = [record.dig('kubernetes', 'pod_name'), record.dig('kubernetes', 'container_name'), record['message']]; now = Fluent::Clock.now; e = @dedup[k]; if e && now - e[0] < @dedup_window then e[1] += 1; record['__dedup_repeat'] = 'true' else @dedup.delete(k); record['repeated'] = e[1] if e && e[1] > 0; @dedup.shift while @dedup.size >= @dedup_max_entries; @dedup[k] = [now, 0] end; nil}
+  </record>
+</filter>
+<filter **>
+  @type grep
+  @id test_drop
+  <exclude>
+    key __dedup_repeat
+    pattern /^true$/
+  </exclude>
+</filter>
+`
+	dedup := &filter.Dedup{}
+	require.NoError(t, yaml.Unmarshal(CONFIG, dedup))
+	test := render.NewOutputPluginTest(t, dedup)
+	test.DiffResult(expected)
+}
+
+func TestDedupDefaults(t *testing.T) {
+	expected := `
+<filter **>
+  @type record_modifier
+  @id test
+  prepare_value @dedup = {}; @dedup_window = 10; @dedup_max_entries = 10000
+  remove_keys __dedup
+  <record>
+    __dedup ${k = [record.dig('kubernetes', 'namespace_name'), record.dig('kubernetes', 'pod_name'), record.dig('kubernetes', 'container_name'), record['log'], record['message']]; now = Fluent::Clock.now; e = @dedup[k]; if e && now - e[0] < @dedup_window then e[1] += 1; record['__dedup_repeat'] = 'true' else @dedup.delete(k); record['repeat_count'] = e[1] if e && e[1] > 0; @dedup.shift while @dedup.size >= @dedup_max_entries; @dedup[k] = [now, 0] end; nil}
+  </record>
+</filter>
+<filter **>
+  @type grep
+  @id test_drop
+  <exclude>
+    key __dedup_repeat
+    pattern /^true$/
+  </exclude>
+</filter>
+`
+	test := render.NewOutputPluginTest(t, &filter.Dedup{})
+	test.DiffResult(expected)
+}
+
+func TestDedupValidation(t *testing.T) {
+	secretLoader := secret.NewSecretLoader(nil, "", "", nil)
+
+	_, err := (&filter.Dedup{Window: "often"}).ToDirective(secretLoader, "test")
+	require.EqualError(t, err, `invalid dedup window "often"`)
+
+	_, err = (&filter.Dedup{Keys: []string{"$['kubernetes']['pod_name']"}}).ToDirective(secretLoader, "test")
+	require.ErrorContains(t, err, "use the $.parent.child syntax")
+
+	_, err = (&filter.Dedup{Keys: []string{"$.kubernetes..pod_name"}}).ToDirective(secretLoader, "test")
+	require.ErrorContains(t, err, "use the $.parent.child syntax")
+}
+
+// The repeat count is reported with the next record of the series after the window, there is no timer flushing it
+func TestDedupRepeatCountOnNextRecord(t *testing.T) {
+	directive, err := (&filter.Dedup{}).ToDirective(secret.NewSecretLoader(nil, "", "", nil), "test")
+	require.NoError(t, err)
+	mark := directive.GetSections()[0]
+	expression := mark.GetSections()[0].GetParams()["__dedup"]
+
+	repeat, next, found := strings.Cut(expression, " else ")
+	require.True(t, found)
+	require.NotContains(t, repeat, "record['repeat_count']", "the repetitions within the window are only counted")
+	require.Contains(t, next, "record['repeat_count'] = e[1] if e && e[1] > 0", "the next record after the window carries the count")
+	require.NotContains(t, mark.GetParams()["prepare_value"], "Thread", "nothing flushes the count when the window expires")
+}
@@ -16,6 +16,7 @@ package filter
 
 import (
 	"fmt"
+	"path"
 
 	"emperror.dev/errors"
 	"github.com/cisco-open/operator-tools/pkg/secret"
@@ -29,6 +30,13 @@ type _hugoParser interface{} //nolint:deadcode,unused
 // +kubebuilder:object:generate=true
 // +docName:"[Parser Filter](https://docs.fluentd.org/filter/parser)"
 // Parses a string field in event records and mutates its event record with the parsed result.
+//
+// Use the `multi_format` type to parse records with mixed formats: the patterns are tried in order and the first matching one wins,
+// for example `json`, then `regexp`, then `grok`.
+//
+// The grok parser ships with a bundled pattern library, select it with `grok_pattern_series`.
+// Custom patterns can be loaded from a ConfigMap: list the ConfigMap in the `grokPatternConfigMaps` field of the fluentd spec,
+// and reference it by name with `custom_patterns`.
 type _docParser interface{} //nolint:deadcode,unused
 
 // +name:"Parser"
@@ -38,6 +46,9 @@ type _docParser interface{} //nolint:deadcode,unused
 // +status:"GA"
 type _metaParser interface{} //nolint:deadcode,unused
 
+// GrokPatternsPath is the directory where the ConfigMaps listed in the grokPatternConfigMaps of the fluentd spec are mounted
+const GrokPatternsPath = "/fluentd/grok-patterns"
+
 // +kubebuilder:object:generate=true
 type ParserConfig struct {
 	// Specify field name in the record to parse. If you leave empty the Container Runtime default will be used.
@@ -115,6 +126,14 @@ type ParseSection struct {
 	// File that includes custom grok patterns.
 	CustomPatternPath *secret.Secret `json:"custom_pattern_path,omitempty"`
 	// Only available when using type: grok, multiline_grok.
+	// Name of a ConfigMap listed in the `grokPatternConfigMaps` of the fluentd spec. Every key of the ConfigMap is loaded as a custom grok pattern file.
+	// Cannot be used together with custom_pattern_path.
+	CustomPatterns string `json:"custom_patterns,omitempty" plugin:"hidden"`
+	// Only available when using type: grok, multiline_grok.
+	// The bundled pattern library to use: legacy, ecs-v1 (default: legacy)
+	// +kubebuilder:validation:Enum=legacy;ecs-v1
+	GrokPatternSeries string `json:"grok_pattern_series,omitempty"`
+	// Only available when using type: grok, multiline_grok.
 	// The key has grok failure reason.
 	GrokFailureKey string `json:"grok_failure_key,omitempty"`
 	// Only available when using type: grok, multiline_grok.
@@ -167,6 +186,14 @@ type SingleParseSection struct {
 	// File that includes custom grok patterns.
 	CustomPatternPath *secret.Secret `json:"custom_pattern_path,omitempty"`
 	// Only available when using format: grok, multiline_grok.
+	// Name of a ConfigMap listed in the `grokPatternConfigMaps` of the fluentd spec. Every key of the ConfigMap is loaded as a custom grok pattern file.
+	// Cannot be used together with custom_pattern_path.
+	CustomPatterns string `json:"custom_patterns,omitempty" plugin:"hidden"`
+	// Only available when using format: grok, multiline_grok.
+	// The bundled pattern library to use: legacy, ecs-v1 (default: legacy)
+	// +kubebuilder:validation:Enum=legacy;ecs-v1
+	GrokPatternSeries string `json:"grok_pattern_series,omitempty"`
+	// Only available when using format: grok, multiline_grok.
 	// The key has grok failure reason.
 	GrokFailureKey string `json:"grok_failure_key,omitempty"`
 	// Only available when using format: grok, multiline_grok.
@@ -266,6 +293,9 @@ func (p *SingleParseSection) ToPatternDirective(secretLoader secret.SecretLoader
 	} else {
 		parseSection.Params = params
 	}
+	if err := setCustomPatterns(parseSection.Params, p.CustomPatterns, p.CustomPatternPath); err != nil {
+		return nil, err
+	}
 	for _, grokRule := range section.GrokPatterns {
 		if meta, err := grokRule.ToGrokDirective(secretLoader, ""); err != nil {
 			return nil, err
@@ -284,6 +314,17 @@ func (p *GrokSection) ToGrokDirective(secretLoader secret.SecretLoader, id strin
 	return types.NewFlatDirective(parseMeta, section, secretLoader)
 }
 
+func setCustomPatterns(params types.Params, customPatterns string, customPatternPath *secret.Secret) error {
+	if customPatterns == "" {
+		return nil
+	}
+	if customPatternPath != nil {
+		return errors.New("custom_patterns and custom_pattern_path cannot be used together")
+	}
+	params["custom_pattern_path"] = path.Join(GrokPatternsPath, customPatterns)
+	return nil
+}
+
 func (p *ParseSection) ToDirective(secretLoader secret.SecretLoader, id string) (types.Directive, error) {
 	parseSection := &types.GenericDirective{
 		PluginMeta: types.PluginMeta{
@@ -299,6 +340,9 @@ func (p *ParseSection) ToDirective(secretLoader secret.SecretLoader, id string)
 	} else {
 		parseSection.Params = params
 	}
+	if err := setCustomPatterns(parseSection.Params, p.CustomPatterns, p.CustomPatternPath); err != nil {
+		return nil, err
+	}
 	if len(p.Multiline) > 0 && p.Type == "multiline" {
 		parseSection.Params["format_firstline"] = p.FormatFirstline
 		for i, v := range p.Multiline {
@@ -17,6 +17,7 @@ package filter_test
 import (
 	"testing"
 
+	"github.com/cisco-open/operator-tools/pkg/secret"
 	"github.com/ghodss/yaml"
 	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/filter"
 	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/render"
@@ -197,3 +198,126 @@ parse:
 	test := render.NewOutputPluginTest(t, parser)
 	test.DiffResult(expected)
 }
+
+func TestParserMultiFormatJSONRegexpGrok(t *testing.T) {
+	CONFIG := []byte(`
+remove_key_name_field: true
+reserve_data: true
+parse:
+  type: multi_format
+  patterns:
+  - format: json
+    time_key: ts
+  - format: regexp
+    expression: /^(?<level>[A-Z]+) (?<message>.*)$/
+  - format: grok
+    grok_pattern_series: ecs-v1
+    grok_pattern: "%{COMBINEDAPACHELOG}"
+`)
+
+	expected := `
+<filter **>
+  @type parser
+  @id test
+  key_name message
+  remove_key_name_field true
+  reserve_data true
+  <parse>
+    @type multi_format
+    <pattern>
+      format json
+      time_key ts
+    </pattern>
+    <pattern>
+      expression /^(?<level>[A-Z]+) (?<message>.*)$/
+      format regexp
+    </pattern>
+    <pattern>
+      format grok
+      grok_pattern %{COMBINEDAPACHELOG}
+      grok_pattern_series ecs-v1
+    </pattern>
+  </parse>
+</filter>
+`
+	parser := &filter.ParserConfig{}
+	require.NoError(t, yaml.Unmarshal(CONFIG, parser))
+	test := render.NewOutputPluginTest(t, parser)
+	test.DiffResult(expected)
+}
+
+func TestParserGrokCustomPatterns(t *testing.T) {
+	CONFIG := []byte(`
+parse:
+  type: grok
+  grok_pattern: "%{MYAPP_LINE}"
+  custom_patterns: myapp-patterns
+`)
+
+	expected := `
+<filter **>
+  @type parser
+  @id test
+  key_name message
+  <parse>
+    @type grok
+    custom_pattern_path /fluentd/grok-patterns/myapp-patterns
+    grok_pattern %{MYAPP_LINE}
+  </parse>
+</filter>
+`
+	parser := &filter.ParserConfig{}
+	require.NoError(t, yaml.Unmarshal(CONFIG, parser))
+	test := render.NewOutputPluginTest(t, parser)
+	test.DiffResult(expected)
+}
+
+func TestParserMultiFormatGrokCustomPatterns(t *testing.T) {
+	CONFIG := []byte(`
+parse:
+  type: multi_format
+  patterns:
+  - format: json
+  - format: grok
+    grok_pattern: "%{MYAPP_LINE}"
+    custom_patterns: myapp-patterns
+`)
+
+	expected := `
+<filter **>
+  @type parser
+  @id test
+  key_name message
+  <parse>
+    @type multi_format
+    <pattern>
+      format json
+    </pattern>
+    <pattern>
+      custom_pattern_path /fluentd/grok-patterns/myapp-patterns
+      format grok
+      grok_pattern %{MYAPP_LINE}
+    </pattern>
+  </parse>
+</filter>
+`
+	parser := &filter.ParserConfig{}
+	require.NoError(t, yaml.Unmarshal(CONFIG, parser))
+	test := render.NewOutputPluginTest(t, parser)
+	test.DiffResult(expected)
+}
+
+func TestParserGrokCustomPatternsConflict(t *testing.T) {
+	CONFIG := []byte(`
+parse:
+  type: grok
+  grok_pattern: "%{MYAPP_LINE}"
+  custom_patterns: myapp-patterns
+  custom_pattern_path:
+    value: /etc/patterns
+`)
+	parser := &filter.ParserConfig{}
+	require.NoError(t, yaml.Unmarshal(CONFIG, parser))
+	_, err := parser.ToDirective(secret.NewSecretLoader(nil, "", "", nil), "test")
+	require.EqualError(t, err, "custom_patterns and custom_pattern_path cannot be used together")
+}
@@ -0,0 +1,361 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import (
+	"fmt"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/cisco-open/operator-tools/pkg/secret"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/types"
+)
+
+// +name:"Redact"
+// +weight:"200"
+type _hugoRedact interface{} //nolint:deadcode,unused
+
+// +kubebuilder:object:generate=true
+// +docName:"Redact Filter"
+// Masks sensitive data (PII, credentials) in records before they leave the cluster.
+//
+// The filter is rendered as a [record_modifier](../record_modifier/) filter, so it needs no extra fluentd plugin.
+// Built-in detectors are expanded into rules by the operator, custom rules can be added with regular expressions.
+// Every rule can be scoped to a list of record keys and can override the replacement mode.
+//
+// Supported detectors: `email`, `credit_card`, `ipv4`, `ipv6`, `bearer_token`, `jwt`, `aws_access_key`
+//
+// Replacement modes:
+// - `mask`: replaces the match with the `mask` string
+// - `hash`: replaces the match with the hex encoded SHA-256 hash of the salt and the match
+// - `drop_key`: removes the whole key from the record if it contains a match
+type _docRedact interface{} //nolint:deadcode,unused
+
+// +name:"Redact"
+// +url:"https://github.com/repeatedly/fluent-plugin-record-modifier"
+// +version:"2.1.0"
+// +description:"Mask sensitive data in records"
+// +status:"Testing"
+type _metaRedact interface{} //nolint:deadcode,unused
+
+const (
+	RedactReplaceMask    = "mask"
+	RedactReplaceHash    = "hash"
+	RedactReplaceDropKey = "drop_key"
+
+	redactDefaultMask = "[REDACTED]"
+	// redactScratchKey holds the result of the ruby expression of the hash and drop_key rules,
+	// the rules modify the record in place and the key itself is removed afterwards.
+	redactScratchKey = "__redact"
+)
+
+// RedactDefaultKeys are the record keys scanned when no keys are configured
+var RedactDefaultKeys = []string{"log", "message"}
+
+// RedactDetectors contains the patterns of the built-in detectors
+var RedactDetectors = map[string]string{
+	"email":          `/[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}/`,
+	"credit_card":    `/\b(?:\d[ -]?){12,18}\d\b/`,
+	"ipv4":           `/\b(?:(?:25[0-5]|2[0-4]\d|1?\d?\d)\.){3}(?:25[0-5]|2[0-4]\d|1?\d?\d)\b/`,
+	"ipv6":           `/\b(?:[0-9a-fA-F]{1,4}:){7}[0-9a-fA-F]{1,4}\b|\b(?:[0-9a-fA-F]{1,4}:){1,7}:(?:[0-9a-fA-F]{1,4}(?::[0-9a-fA-F]{1,4}){0,6})?\b/`,
+	"bearer_token":   `/[Bb]earer\s+[A-Za-z0-9\-._~+\/]+=*/`,
+	"jwt":            `/\beyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\b/`,
+	"aws_access_key": `/\b(?:AKIA|ASIA)[0-9A-Z]{16}\b/`,
+}
+
+// +kubebuilder:object:generate=true
+type Redact struct {
+	// Built-in detectors to enable: email, credit_card, ipv4, ipv6, bearer_token, jwt, aws_access_key
+	Detectors []string `json:"detectors,omitempty" plugin:"hidden"`
+	// Custom redaction rules
+	// +docLink:"Rule,#rule"
+	Rules []RedactRule `json:"rules,omitempty"`
+	// Top level record keys to scan (default: ["log", "message"])
+	Keys []string `json:"keys,omitempty"`
+	// How to replace matches: mask, hash, drop_key (default: mask)
+	// +kubebuilder:validation:Enum=mask;hash;drop_key
+	Replace string `json:"replace,omitempty"`
+	// Replacement string in mask mode (default: [REDACTED])
+	Mask string `json:"mask,omitempty"`
+	// Salt used in hash mode, required if any rule uses hash
+	// +docLink:"Secret,../secret/"
+	HashSalt *secret.Secret `json:"hash_salt,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+// +docName:"Rule"
+type RedactRule struct {
+	// Name of the rule, used to identify it in validation errors
+	Name string `json:"name,omitempty"`
+	// Regular expression to match, either as a /regexp/ literal with optional i and m flags or as a plain regular expression
+	Pattern string `json:"pattern"`
+	// Top level record keys to scan, overrides the keys of the filter
+	Keys []string `json:"keys,omitempty"`
+	// How to replace matches, overrides the replace mode of the filter
+	// +kubebuilder:validation:Enum=mask;hash;drop_key
+	Replace string `json:"replace,omitempty"`
+	// Replacement string in mask mode, overrides the mask of the filter
+	Mask string `json:"mask,omitempty"`
+}
+
+// ## Example `Redact` filter configurations
+// ```yaml
+// apiVersion: logging.banzaicloud.io/v1beta1
+// kind: Flow
+// metadata:
+//
+//	name: demo-flow
+//
+// spec:
+//
+//	filters:
+//	  - redact:
+//	      detectors:
+//	        - email
+//	        - credit_card
+//	      keys:
+//	        - message
+//	      rules:
+//	        - name: session
+//	          pattern: /session=[0-9a-f]{32}/
+//	          replace: hash
+//	      hash_salt:
+//	        valueFrom:
+//	          secretKeyRef:
+//	            name: redact-salt
+//	            key: salt
+//	selectors: {}
+//	localOutputRefs:
+//	  - demo-output
+//
+// ```
+//
+// #### Fluentd Config Result
+// ```yaml
+//
+//	<filter **>
+//	  @type record_modifier
+//	  @id test_redact
+//	  prepare_value require "digest"; @redact_salt = '<salt>'; @redact_patterns = [Regexp.new('session=[0-9a-f]{32}')]
+//	  remove_keys __redact
+//	  <replace>
+//	    expression /[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}/
+//	    key message
+//	    replace [REDACTED]
+//	  </replace>
+//	  <replace>
+//	    expression /\b(?:\d[ -]?){12,18}\d\b/
+//	    key message
+//	    replace [REDACTED]
+//	  </replace>
+//	  <record>
+//	    __redact ${record['message'] = record['message'].gsub(@redact_patterns[0]) { |m| Digest::SHA256.hexdigest(@redact_salt + m) } if record['message'].is_a?(String); nil}
+//	  </record>
+//	</filter>
+//
+// ```
+type _expRedact interface{} //nolint:deadcode,unused
+
+func (r *Redact) validate() error {
+	var errs error
+	usesHash := r.Replace == RedactReplaceHash
+	for _, detector := range r.Detectors {
+		if _, ok := RedactDetectors[detector]; !ok {
+			errs = errors.Append(errs, errors.Errorf("unknown redact detector %q", detector))
+		}
+	}
+	for _, key := range r.Keys {
+		errs = errors.Append(errs, validateRedactKey(key))
+	}
+	for i, rule := range r.Rules {
+		if rule.Pattern == "" {
+			errs = errors.Append(errs, errors.Errorf("pattern is required for redact rule with index %d", i))
+		} else if _, _, err := parseRedactPattern(rule.Pattern); err != nil {
+			errs = errors.Append(errs, errors.WrapIff(err, "invalid pattern for redact rule with index %d", i))
+		}
+		for _, key := range rule.Keys {
+			errs = errors.Append(errs, validateRedactKey(key))
+		}
+		if rule.Replace == RedactReplaceHash {
+			usesHash = true
+		}
+	}
+	if usesHash && r.HashSalt == nil {
+		errs = errors.Append(errs, errors.New("hash_salt is required when using the hash replace mode"))
+	}
+	if len(r.Detectors) == 0 && len(r.Rules) == 0 {
+		errs = errors.Append(errs, errors.New("at least one detector or rule is required"))
+	}
+	return errs
+}
+
+// parseRedactPattern splits a pattern given either as a /regexp/ literal with optional i and m flags
+// or as a plain regular expression into its source and flags. The source is only ever passed to ruby
+// as a quoted string, patterns that could break out of the fluentd config line or the placeholders
+// of record_modifier are rejected.
+func parseRedactPattern(pattern string) (string, string, error) {
+	if strings.ContainsAny(pattern, "\r\n") {
+		return "", "", errors.New("pattern must be a single line")
+	}
+	if strings.Contains(pattern, "${") {
+		return "", "", errors.New("pattern must not contain ${")
+	}
+	// fluentd treats a # after whitespace as the start of a comment
+	if strings.Contains(pattern, " #") || strings.Contains(pattern, "\t#") {
+		return "", "", errors.New(`pattern must not contain whitespace followed by #, use \s# or \x20# instead`)
+	}
+	if !strings.HasPrefix(pattern, "/") {
+		return pattern, "", nil
+	}
+	end := strings.LastIndex(pattern, "/")
+	if end == 0 || strings.Trim(pattern[end+1:], "im") != "" {
+		return "", "", errors.Errorf("pattern %q starting with / must be a /regexp/ literal with optional i and m flags", pattern)
+	}
+	source, flags := pattern[1:end], pattern[end+1:]
+	if source == "" {
+		return "", "", errors.New("pattern must not be empty")
+	}
+	return source, flags, nil
+}
+
+// rubyRegexp returns the ruby expression compiling the pattern from a quoted string
+func rubyRegexp(pattern string) string {
+	source, flags, _ := parseRedactPattern(pattern)
+	var options []string
+	if strings.Contains(flags, "i") {
+		options = append(options, "Regexp::IGNORECASE")
+	}
+	if strings.Contains(flags, "m") {
+		options = append(options, "Regexp::MULTILINE")
+	}
+	if len(options) == 0 {
+		return fmt.Sprintf("Regexp.new(%s)", rubyString(source))
+	}
+	return fmt.Sprintf("Regexp.new(%s, %s)", rubyString(source), strings.Join(options, " | "))
+}
+
+func validateRedactKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "$") {
+		return errors.Errorf("invalid redact key %q, only top level record keys are supported", key)
+	}
+	return nil
+}
+
+// rules returns the built-in detectors and the custom rules with the defaults of the filter applied
+func (r *Redact) rules() []RedactRule {
+	rules := make([]RedactRule, 0, len(r.Detectors)+len(r.Rules))
+	for _, detector := range r.Detectors {
+		rules = append(rules, RedactRule{
+			Name:    detector,
+			Pattern: RedactDetectors[detector],
+		})
+	}
+	rules = append(rules, r.Rules...)
+	for i := range rules {
+		if len(rules[i].Keys) == 0 {
+			rules[i].Keys = r.Keys
+		}
+		if len(rules[i].Keys) == 0 {
+			rules[i].Keys = RedactDefaultKeys
+		}
+		if rules[i].Replace == "" {
+			rules[i].Replace = r.Replace
+		}
+		if rules[i].Replace == "" {
+			rules[i].Replace = RedactReplaceMask
+		}
+		if rules[i].Mask == "" {
+			rules[i].Mask = r.Mask
+		}
+		if rules[i].Mask == "" {
+			rules[i].Mask = redactDefaultMask
+		}
+	}
+	return rules
+}
+
+func (r *Redact) ToDirective(secretLoader secret.SecretLoader, id string) (types.Directive, error) {
+	const pluginType = "record_modifier"
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+	redact := &types.GenericDirective{
+		PluginMeta: types.PluginMeta{
+			Type:      pluginType,
+			Directive: "filter",
+			Tag:       "**",
+			Id:        id,
+		},
+		Params: map[string]string{},
+	}
+	// mask rules are plain gsub replaces, hash and drop_key rules need ruby and modify the record in place,
+	// their patterns are compiled once when the filter starts
+	var statements, patterns []string
+	for _, rule := range r.rules() {
+		source, flags, _ := parseRedactPattern(rule.Pattern)
+		pattern := fmt.Sprintf("@redact_patterns[%d]", len(patterns))
+		if rule.Replace == RedactReplaceHash || rule.Replace == RedactReplaceDropKey {
+			patterns = append(patterns, rubyRegexp(rule.Pattern))
+		}
+		for _, key := range rule.Keys {
+			value := fmt.Sprintf("record[%s]", rubyString(key))
+			switch rule.Replace {
+			case RedactReplaceHash:
+				statements = append(statements, fmt.Sprintf(
+					"%s = %s.gsub(%s) { |m| Digest::SHA256.hexdigest(@redact_salt + m) } if %s.is_a?(String)",
+					value, value, pattern, value))
+			case RedactReplaceDropKey:
+				statements = append(statements, fmt.Sprintf(
+					"record.delete(%s) if %s.is_a?(String) && %s.match?(%s)",
+					rubyString(key), value, value, pattern))
+			default:
+				replace := &Replace{
+					Key:        key,
+					Expression: "/" + source + "/" + flags,
+					Replace:    rule.Mask,
+				}
+				if directive, err := replace.ToDirective(secretLoader, ""); err != nil {
+					return nil, err
+				} else {
+					redact.SubDirectives = append(redact.SubDirectives, directive)
+				}
+			}
+		}
+	}
+	if len(statements) > 0 {
+		prepare := []string{fmt.Sprintf("@redact_patterns = [%s]", strings.Join(patterns, ", "))}
+		if r.HashSalt != nil {
+			salt, err := secretLoader.Load(r.HashSalt)
+			if err != nil {
+				return nil, errors.WrapIf(err, "failed to load hash_salt")
+			}
+			prepare = append([]string{"require \"digest\"", fmt.Sprintf("@redact_salt = %s", rubyString(salt))}, prepare...)
+		}
+		redact.Params["prepare_value"] = strings.Join(prepare, "; ")
+		redact.Params["remove_keys"] = redactScratchKey
+		record := Record{redactScratchKey: fmt.Sprintf("${%s; nil}", strings.Join(statements, "; "))}
+		if directive, err := record.ToDirective(secretLoader, ""); err != nil {
+			return nil, err
+		} else {
+			redact.SubDirectives = append(redact.SubDirectives, directive)
+		}
+	}
+	return redact, nil
+}
+
+// rubyString quotes s as a single quoted ruby string literal
+func rubyString(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
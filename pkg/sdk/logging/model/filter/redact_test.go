@@ -0,0 +1,159 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter_test
+
+import (
+	"testing"
+
+	"github.com/cisco-open/operator-tools/pkg/secret"
+	"github.com/ghodss/yaml"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/filter"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/render"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedact(t *testing.T) {
+	CONFIG := []byte(`
+detectors:
+  - email
+  - aws_access_key
+keys:
+  - message
+replace: mask
+mask: "***"
+rules:
+  - name: session
+    pattern: /session=[0-9a-f]{32}/
+    keys:
+      - message
+      - session
+    replace: hash
+  - pattern: /password=\S+/
+    replace: drop_key
+hash_salt:
+  value: salty
+`)
+	expected := `
+<filter **>
+  @type record_modifier
+  @id test
+  prepare_value require "digest"; @redact_salt = 'salty'; @redact_patterns = [Regexp.new('session=[0-9a-f]{32}'), Regexp.new('password=\\S+')]
+  remove_keys __redact
+  <replace>
+    expression /[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}/
+    key message
+    replace ***
+  </replace>
+  <replace>
+    expression /\b(?:AKIA|ASIA)[0-9A-Z]{16}\b/
+    key message
+    replace ***
+  </replace>
+  <record>
+    __redact ${record['message'] = record['message'].gsub(@redact_patterns[0]) { |m| Digest::SHA256.hexdigest(@redact_salt + m) } if record['message'].is_a?(String); record['session'] = record['session'].gsub(@redact_patterns[0]) { |m| Digest::SHA256.hexdigest(@redact_salt + m) } if record['session'].is_a?(String); record.delete('message') if record['message'].is_a?(String) && record['message'].match?(@redact_patterns[1]); nil}
+  </record>
+</filter>
+`
+	redact := &filter.Redact{}
+	require.NoError(t, yaml.Unmarshal(CONFIG, redact))
+	test := render.NewOutputPluginTest(t, redact)
+	test.DiffResult(expected)
+}
+
+func TestRedactValidation(t *testing.T) {
+	secretLoader := secret.NewSecretLoader(nil, "", "", nil)
+
+	_, err := (&filter.Redact{}).ToDirective(secretLoader, "test")
+	require.Error(t, err)
+
+	_, err = (&filter.Redact{Detectors: []string{"phone_number"}}).ToDirective(secretLoader, "test")
+	require.ErrorContains(t, err, "unknown redact detector")
+
+	_, err = (&filter.Redact{Detectors: []string{"email"}, Replace: filter.RedactReplaceHash}).ToDirective(secretLoader, "test")
+	require.ErrorContains(t, err, "hash_salt is required")
+
+	_, err = (&filter.Redact{Rules: []filter.RedactRule{{Name: "empty"}}}).ToDirective(secretLoader, "test")
+	require.ErrorContains(t, err, "pattern is required")
+
+	_, err = (&filter.Redact{Detectors: []string{"email"}, Keys: []string{"$.kubernetes.labels.app"}}).ToDirective(secretLoader, "test")
+	require.ErrorContains(t, err, "only top level record keys are supported")
+
+	for _, pattern := range []string{"/", "//", "/token/x", "/a/ # comment", "${record['log']}", "token\n<match **>"} {
+		_, err = (&filter.Redact{Rules: []filter.RedactRule{{Pattern: pattern}}}).ToDirective(secretLoader, "test")
+		require.ErrorContains(t, err, "invalid pattern", "pattern %q", pattern)
+	}
+}
+
+func TestRedactPatternQuoting(t *testing.T) {
+	CONFIG := []byte(`
+keys:
+  - log
+rules:
+  - pattern: "/it's\\x20#{1}/i"
+    replace: drop_key
+  - pattern: path=/var/[^ ]+
+    replace: drop_key
+  - pattern: /a/) or system('id') or (/b/
+    replace: drop_key
+  - pattern: user=\w+
+`)
+	expected := `
+<filter **>
+  @type record_modifier
+  @id test
+  prepare_value @redact_patterns = [Regexp.new('it\'s\\x20#{1}', Regexp::IGNORECASE), Regexp.new('path=/var/[^ ]+'), Regexp.new('a/) or system(\'id\') or (/b')]
+  remove_keys __redact
+  <replace>
+    expression /user=\w+/
+    key log
+    replace [REDACTED]
+  </replace>
+  <record>
+    __redact ${record.delete('log') if record['log'].is_a?(String) && record['log'].match?(@redact_patterns[0]); record.delete('log') if record['log'].is_a?(String) && record['log'].match?(@redact_patterns[1]); record.delete('log') if record['log'].is_a?(String) && record['log'].match?(@redact_patterns[2]); nil}
+  </record>
+</filter>
+`
+	redact := &filter.Redact{}
+	require.NoError(t, yaml.Unmarshal(CONFIG, redact))
+	test := render.NewOutputPluginTest(t, redact)
+	test.DiffResult(expected)
+}
+
+func TestRedactDefaultKeys(t *testing.T) {
+	CONFIG := []byte(`
+detectors:
+  - jwt
+`)
+	expected := `
+<filter **>
+  @type record_modifier
+  @id test
+  <replace>
+    expression /\beyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\b/
+    key log
+    replace [REDACTED]
+  </replace>
+  <replace>
+    expression /\beyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\b/
+    key message
+    replace [REDACTED]
+  </replace>
+</filter>
+`
+	redact := &filter.Redact{}
+	require.NoError(t, yaml.Unmarshal(CONFIG, redact))
+	test := render.NewOutputPluginTest(t, redact)
+	test.DiffResult(expected)
+}
@@ -0,0 +1,151 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import (
+	"emperror.dev/errors"
+	"github.com/cisco-open/operator-tools/pkg/secret"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/types"
+)
+
+// +name:"Rewrite Tag"
+// +weight:"200"
+type _hugoRewriteTag interface{} //nolint:deadcode,unused
+
+// +kubebuilder:object:generate=true
+// +docName:"[Rewrite Tag Filter](https://github.com/fluent/fluent-plugin-rewrite-tag-filter)"
+// Rewrites the tag of the records based on their content.
+//
+// The rules are evaluated in order, the first matching rule sets the new tag.
+// Records that match none of the rules are discarded, add a catch-all rule (for example `pattern: /.*/` with `tag: ${tag}`) to keep them.
+//
+// The re-tagged records stay inside the label of the originating flow: the filters and outputs defined after the
+// rewrite_tag filter are moved into a separate label that receives the re-emitted records.
+type _docRewriteTag interface{} //nolint:deadcode,unused
+
+// +name:"Rewrite Tag"
+// +url:"https://github.com/fluent/fluent-plugin-rewrite-tag-filter"
+// +version:"2.4.0"
+// +description:"Rewrite the tag of the records based on their content"
+// +status:"GA"
+type _metaRewriteTag interface{} //nolint:deadcode,unused
+
+// +kubebuilder:object:generate=true
+type RewriteTag struct {
+	// Rewrite rules
+	// +docLink:"Rule,#rewrite-tag-rule"
+	Rules []RewriteTagRule `json:"rules"`
+	// Capitalize letters for regular expression backreference (default: false)
+	CapitalizeRegexBackreference bool `json:"capitalize_regex_backreference,omitempty"`
+	// Remove the prefix from the tag before evaluating the ${tag} placeholders
+	RemoveTagPrefix string `json:"remove_tag_prefix,omitempty"`
+	// Override the hostname command used by the ${hostname} placeholder (default: hostname)
+	HostnameCommand string `json:"hostname_command,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+// +docName:"Rewrite Tag Rule"
+type RewriteTagRule struct {
+	// Record key to evaluate, nested keys can be referenced with the record_accessor syntax, e.g. $.kubernetes.labels.app
+	Key string `json:"key"`
+	// Regular expression to match the value of the key
+	Pattern string `json:"pattern"`
+	// New tag of the matching records. Supports ${tag}, ${tag_parts[N]}, ${hostname} placeholders and $1 style backreferences.
+	Tag string `json:"tag"`
+	// Rewrite the tag if the pattern does not match (default: false)
+	Invert bool `json:"invert,omitempty"`
+}
+
+func (r *RewriteTagRule) ToDirective(secretLoader secret.SecretLoader, id string) (types.Directive, error) {
+	return types.NewFlatDirective(types.PluginMeta{
+		Directive: "rule",
+	}, r, secretLoader)
+}
+
+// ## Example `RewriteTag` filter configurations
+// ```yaml
+// apiVersion: logging.banzaicloud.io/v1beta1
+// kind: Flow
+// metadata:
+//
+//	name: demo-flow
+//
+// spec:
+//
+//	filters:
+//	  - rewrite_tag:
+//	      rules:
+//	        - key: $.kubernetes.labels.app
+//	          pattern: /^(.+)$/
+//	          tag: app.$1
+//	selectors: {}
+//	localOutputRefs:
+//	  - demo-output
+//
+// ```
+//
+// #### Fluentd Config Result
+// ```yaml
+//
+//	<label @0fe4b0ba9ef2e49325eb3f2fc0ba4e89>
+//	  <match **>
+//	    @type rewrite_tag_filter
+//	    @id test_rewrite_tag
+//	    @label @0fe4b0ba9ef2e49325eb3f2fc0ba4e89_0
+//	    <rule>
+//	      key $.kubernetes.labels.app
+//	      pattern /^(.+)$/
+//	      tag app.$1
+//	    </rule>
+//	  </match>
+//	</label>
+//	<label @0fe4b0ba9ef2e49325eb3f2fc0ba4e89_0>
+//	  <match **>
+//	    @type null
+//	  </match>
+//	</label>
+//
+// ```
+type _expRewriteTag interface{} //nolint:deadcode,unused
+
+func (r *RewriteTag) ToDirective(secretLoader secret.SecretLoader, id string) (types.Directive, error) {
+	const pluginType = "rewrite_tag_filter"
+	if len(r.Rules) == 0 {
+		return nil, errors.New("at least one rule is required for rewrite_tag")
+	}
+	rewriteTag := &types.ReEmitDirective{
+		GenericDirective: types.GenericDirective{
+			PluginMeta: types.PluginMeta{
+				Type:      pluginType,
+				Directive: "match",
+				Tag:       "**",
+				Id:        id,
+			},
+		},
+	}
+	if params, err := types.NewStructToStringMapper(secretLoader).StringsMap(r); err != nil {
+		return nil, err
+	} else {
+		rewriteTag.Params = params
+	}
+	for _, rule := range r.Rules {
+		if directive, err := rule.ToDirective(secretLoader, ""); err != nil {
+			return nil, err
+		} else {
+			rewriteTag.SubDirectives = append(rewriteTag.SubDirectives, directive)
+		}
+	}
+	return rewriteTag, nil
+}
@@ -0,0 +1,60 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter_test
+
+import (
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/filter"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/render"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRewriteTag(t *testing.T) {
+	CONFIG := []byte(`
+capitalize_regex_backreference: true
+rules:
+  - key: $.kubernetes.labels.app
+    pattern: /^(.+)$/
+    tag: app.$1
+  - key: message
+    pattern: /.*/
+    tag: ${tag}
+    invert: true
+`)
+	expected := `
+<match **>
+  @type rewrite_tag_filter
+  @id test
+  capitalize_regex_backreference true
+  <rule>
+    key $.kubernetes.labels.app
+    pattern /^(.+)$/
+    tag app.$1
+  </rule>
+  <rule>
+    invert true
+    key message
+    pattern /.*/
+    tag ${tag}
+  </rule>
+</match>
+`
+	rewriteTag := &filter.RewriteTag{}
+	require.NoError(t, yaml.Unmarshal(CONFIG, rewriteTag))
+	test := render.NewOutputPluginTest(t, rewriteTag)
+	test.DiffResult(expected)
+}
@@ -83,6 +83,26 @@ func (in *DedotFilterConfig) DeepCopy() *DedotFilterConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Dedup) DeepCopyInto(out *Dedup) {
+	*out = *in
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Dedup.
+func (in *Dedup) DeepCopy() *Dedup {
+	if in == nil {
+		return nil
+	}
+	out := new(Dedup)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DetectExceptions) DeepCopyInto(out *DetectExceptions) {
 	*out = *in
@@ -490,6 +510,63 @@ func (in *RecordTransformer) DeepCopy() *RecordTransformer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Redact) DeepCopyInto(out *Redact) {
+	*out = *in
+	if in.Detectors != nil {
+		in, out := &in.Detectors, &out.Detectors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]RedactRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HashSalt != nil {
+		in, out := &in.HashSalt, &out.HashSalt
+		*out = new(secret.Secret)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Redact.
+func (in *Redact) DeepCopy() *Redact {
+	if in == nil {
+		return nil
+	}
+	out := new(Redact)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedactRule) DeepCopyInto(out *RedactRule) {
+	*out = *in
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedactRule.
+func (in *RedactRule) DeepCopy() *RedactRule {
+	if in == nil {
+		return nil
+	}
+	out := new(RedactRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RegexpSection) DeepCopyInto(out *RegexpSection) {
 	*out = *in
@@ -520,6 +597,41 @@ func (in *Replace) DeepCopy() *Replace {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RewriteTag) DeepCopyInto(out *RewriteTag) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]RewriteTagRule, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RewriteTag.
+func (in *RewriteTag) DeepCopy() *RewriteTag {
+	if in == nil {
+		return nil
+	}
+	out := new(RewriteTag)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RewriteTagRule) DeepCopyInto(out *RewriteTagRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RewriteTagRule.
+func (in *RewriteTagRule) DeepCopy() *RewriteTagRule {
+	if in == nil {
+		return nil
+	}
+	out := new(RewriteTagRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SingleParseSection) DeepCopyInto(out *SingleParseSection) {
 	*out = *in
@@ -0,0 +1,130 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import (
+	"errors"
+
+	"github.com/cisco-open/operator-tools/pkg/secret"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/types"
+)
+
+// +name:"Google Cloud Pub/Sub"
+// +weight:"200"
+type _hugoGCloudPubSub interface{} //nolint:deadcode,unused
+
+// +docName:"Google Cloud Pub/Sub output plugin for Fluentd"
+// Publishes records to Google Cloud Pub/Sub topics.
+// More info at https://github.com/mia-0032/fluent-plugin-gcloud-pubsub-custom
+//
+// The topic can contain buffer placeholders (for example `${tag}`).
+// Set `emulator_host` to publish to a local Pub/Sub emulator instead of the Google API.
+//
+// ## Example output configurations
+// ```yaml
+// spec:
+//
+//	gcloudPubsub:
+//	  project: my-project
+//	  topic: logs
+//	  key:
+//	    mountFrom:
+//	      secretKeyRef:
+//	        name: pubsub-credentials
+//	        key: credentials.json
+//	  format:
+//	    type: json
+//
+// ```
+type _docGCloudPubSub interface{} //nolint:deadcode,unused
+
+// +name:"Google Cloud Pub/Sub"
+// +url:"https://github.com/mia-0032/fluent-plugin-gcloud-pubsub-custom"
+// +version:"1.5.0"
+// +description:"Publish your logs to Google Cloud Pub/Sub"
+// +status:"Testing"
+type _metaGCloudPubSub interface{} //nolint:deadcode,unused
+
+// +kubebuilder:object:generate=true
+// +docName:"Output Config"
+type GCloudPubSubOutputConfig struct {
+	// Project id
+	Project string `json:"project,omitempty"`
+	// Service account credentials JSON file, must be set with mountFrom
+	// +docLink:"Secret,../secret/"
+	Key *secret.Secret `json:"key,omitempty"`
+	// Topic to publish to. Buffer placeholders like ${tag} are replaced per chunk.
+	Topic string `json:"topic"`
+	// Create the topic if it does not exist (default: false)
+	AutocreateTopic *bool `json:"autocreate_topic,omitempty"`
+	// Host and port of a Pub/Sub emulator, e.g. pubsub-emulator:8085
+	EmulatorHost string `json:"emulator_host,omitempty"`
+	// Maximum number of messages published in one request (default: 1000)
+	MaxMessages int `json:"max_messages,omitempty"`
+	// Maximum total size of messages published in one request in bytes (default: 9800000)
+	MaxTotalSize int `json:"max_total_size,omitempty"`
+	// Maximum size of a single message in bytes, larger messages are dropped (default: 4000000)
+	MaxMessageSize int `json:"max_message_size,omitempty"`
+	// Record keys published as message attributes
+	AttributeKeys []string `json:"attribute_keys,omitempty"`
+	// Static attributes added to every message
+	AttributeKeyValues map[string]string `json:"attribute_key_values,omitempty"`
+	// Compress messages with zlib (default: false)
+	CompressBatches *bool `json:"compress_batches,omitempty"`
+	// +docLink:"Format,../format/"
+	Format *Format `json:"format,omitempty"`
+	// +docLink:"Buffer,../buffer/"
+	Buffer *Buffer `json:"buffer,omitempty"`
+	// The threshold for chunk flush performance check.
+	// Parameter type is float, not time, default: 20.0 (seconds)
+	// If chunk flush takes longer time than this threshold, fluentd logs warning message and increases metric fluentd_output_status_slow_flush_count.
+	SlowFlushLogThreshold string `json:"slow_flush_log_threshold,omitempty"`
+}
+
+func (g *GCloudPubSubOutputConfig) ToDirective(secretLoader secret.SecretLoader, id string) (types.Directive, error) {
+	const pluginType = "gcloud_pubsub"
+	pubsub := &types.OutputPlugin{
+		PluginMeta: types.PluginMeta{
+			Type:      pluginType,
+			Directive: "match",
+			Tag:       "**",
+			Id:        id,
+		},
+	}
+	if g.Key != nil && g.Key.MountFrom == nil {
+		return nil, errors.New("key must be set with mountFrom, the plugin expects a file path")
+	}
+	if params, err := types.NewStructToStringMapper(secretLoader).StringsMap(g); err != nil {
+		return nil, err
+	} else {
+		pubsub.Params = params
+	}
+	if g.Buffer == nil {
+		g.Buffer = &Buffer{}
+	}
+	if buffer, err := g.Buffer.ToDirective(secretLoader, id); err != nil {
+		return nil, err
+	} else {
+		pubsub.SubDirectives = append(pubsub.SubDirectives, buffer)
+	}
+	if g.Format != nil {
+		if format, err := g.Format.ToDirective(secretLoader, ""); err != nil {
+			return nil, err
+		} else {
+			pubsub.SubDirectives = append(pubsub.SubDirectives, format)
+		}
+	}
+	return pubsub, nil
+}
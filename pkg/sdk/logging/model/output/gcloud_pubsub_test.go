@@ -0,0 +1,71 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output_test
+
+import (
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/output"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/render"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGCloudPubSub(t *testing.T) {
+	CONFIG := []byte(`
+project: test-project
+topic: logs-${tag}
+autocreate_topic: true
+emulator_host: pubsub-emulator:8085
+attribute_keys:
+  - namespace
+attribute_key_values:
+  cluster: test
+format:
+  type: json
+buffer:
+  timekey: 1m
+  timekey_wait: 30s
+  timekey_use_utc: true
+`)
+	expected := `
+  <match **>
+    @type gcloud_pubsub
+    @id test
+    attribute_key_values {"cluster":"test"}
+    attribute_keys ["namespace"]
+    autocreate_topic true
+    emulator_host pubsub-emulator:8085
+    project test-project
+    topic logs-${tag}
+    <buffer tag,time>
+      @type file
+      chunk_limit_size 8MB
+      path /buffers/test.*.buffer
+      retry_forever true
+      timekey 1m
+      timekey_use_utc true
+      timekey_wait 30s
+    </buffer>
+    <format>
+      @type json
+    </format>
+  </match>
+`
+	pubsub := &output.GCloudPubSubOutputConfig{}
+	require.NoError(t, yaml.Unmarshal(CONFIG, pubsub))
+	test := render.NewOutputPluginTest(t, pubsub)
+	test.DiffResult(expected)
+}
@@ -0,0 +1,156 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import (
+	"github.com/cisco-open/operator-tools/pkg/secret"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/types"
+)
+
+// +name:"NATS"
+// +weight:"200"
+type _hugoNATS interface{} //nolint:deadcode,unused
+
+// +docName:"NATS output plugin for Fluentd"
+// Publishes records to NATS, including subjects captured by JetStream streams.
+// More info at https://github.com/cosmo0920/fluent-plugin-nats
+//
+// The plugin publishes every record to the subject named after its fluentd tag.
+// Set `subject` to publish the records to a subject templated from their Kubernetes metadata,
+// the records are re-tagged with the [Tag Normaliser](../../filters/tagnormaliser/) before they are published.
+//
+// ## Example output configurations
+// ```yaml
+// spec:
+//
+//	nats:
+//	  host: nats.nats.svc.cluster.local
+//	  port: 4222
+//	  subject: logs.${namespace_name}.${labels.app}
+//	  user:
+//	    valueFrom:
+//	      secretKeyRef:
+//	        name: nats-auth
+//	        key: user
+//	  password:
+//	    valueFrom:
+//	      secretKeyRef:
+//	        name: nats-auth
+//	        key: password
+//	  buffer:
+//	    flush_interval: 10s
+//
+// ```
+//
+// ## Testing against a local broker
+// Any NATS server can stand in for the production cluster, for example a single
+// `nats:2` container exposed through a Service. Point `host` and `port` at it:
+// ```yaml
+// spec:
+//
+//	nats:
+//	  host: nats-local.default.svc
+//	  port: 4222
+//
+// ```
+type _docNATS interface{} //nolint:deadcode,unused
+
+// +name:"NATS"
+// +url:"https://github.com/cosmo0920/fluent-plugin-nats"
+// +version:"1.0.0"
+// +description:"Publish your logs to NATS subjects"
+// +status:"Testing"
+type _metaNATS interface{} //nolint:deadcode,unused
+
+// +kubebuilder:object:generate=true
+// +docName:"Output Config"
+type NATSOutputConfig struct {
+	// Host of the NATS server (default: localhost)
+	Host string `json:"host,omitempty"`
+	// Port of the NATS server (default: 4222)
+	Port int `json:"port,omitempty"`
+	// Subject of the records, supports the placeholders of the Tag Normaliser, e.g. logs.${namespace_name}.${pod_name} (default: the tag of the record)
+	Subject string `json:"subject,omitempty" plugin:"hidden"`
+	// Username for authentication (default: nats)
+	// +docLink:"Secret,../secret/"
+	User *secret.Secret `json:"user,omitempty"`
+	// Password for authentication (default: nats)
+	// +docLink:"Secret,../secret/"
+	Password *secret.Secret `json:"password,omitempty"`
+	// Maximum number of reconnect attempts (default: 150)
+	MaxReconnectAttempts int `json:"max_reconnect_attempts,omitempty"`
+	// Number of seconds to wait between reconnect attempts (default: 2)
+	ReconnectTimeWait int `json:"reconnect_time_wait,omitempty"`
+	// Use TLS for the connection (default: false)
+	SSL *bool `json:"ssl,omitempty"`
+	// +docLink:"Format,../format/"
+	Format *Format `json:"format,omitempty"`
+	// +docLink:"Buffer,../buffer/"
+	Buffer *Buffer `json:"buffer,omitempty"`
+	// The threshold for chunk flush performance check.
+	// Parameter type is float, not time, default: 20.0 (seconds)
+	// If chunk flush takes longer time than this threshold, fluentd logs warning message and increases metric fluentd_output_status_slow_flush_count.
+	SlowFlushLogThreshold string `json:"slow_flush_log_threshold,omitempty"`
+}
+
+func (n *NATSOutputConfig) ToDirective(secretLoader secret.SecretLoader, id string) (types.Directive, error) {
+	const pluginType = "nats"
+	nats := &types.OutputPlugin{
+		PluginMeta: types.PluginMeta{
+			Type:      pluginType,
+			Directive: "match",
+			Tag:       "**",
+			Id:        id,
+		},
+	}
+	if params, err := types.NewStructToStringMapper(secretLoader).StringsMap(n); err != nil {
+		return nil, err
+	} else {
+		nats.Params = params
+	}
+	if n.Buffer == nil {
+		n.Buffer = &Buffer{}
+	}
+	if buffer, err := n.Buffer.ToDirective(secretLoader, id); err != nil {
+		return nil, err
+	} else {
+		nats.SubDirectives = append(nats.SubDirectives, buffer)
+	}
+	if n.Format != nil {
+		if format, err := n.Format.ToDirective(secretLoader, ""); err != nil {
+			return nil, err
+		} else {
+			nats.SubDirectives = append(nats.SubDirectives, format)
+		}
+	}
+	if n.Subject == "" {
+		return nats, nil
+	}
+	// the subject is the tag of the record
+	return &types.ReEmitOutput{
+		ReEmitDirective: types.ReEmitDirective{
+			GenericDirective: types.GenericDirective{
+				PluginMeta: types.PluginMeta{
+					Type:      "tag_normaliser",
+					Directive: "match",
+					Tag:       "**",
+					Id:        id + "_subject",
+				},
+				Params: types.Params{"format": n.Subject},
+			},
+		},
+		Output: nats,
+	}, nil
+}
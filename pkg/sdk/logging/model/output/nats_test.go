@@ -0,0 +1,104 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output_test
+
+import (
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/output"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/render"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNATS(t *testing.T) {
+	CONFIG := []byte(`
+host: nats.nats.svc.cluster.local
+port: 4222
+user:
+  value: fluentd
+password:
+  value: secret
+ssl: true
+format:
+  type: json
+buffer:
+  flush_interval: 10s
+`)
+	expected := `
+  <match **>
+    @type nats
+    @id test
+    host nats.nats.svc.cluster.local
+    password secret
+    port 4222
+    ssl true
+    user fluentd
+    <buffer tag,time>
+      @type file
+      chunk_limit_size 8MB
+      flush_interval 10s
+      path /buffers/test.*.buffer
+      retry_forever true
+      timekey 10m
+      timekey_wait 1m
+    </buffer>
+    <format>
+      @type json
+    </format>
+  </match>
+`
+	nats := &output.NATSOutputConfig{}
+	require.NoError(t, yaml.Unmarshal(CONFIG, nats))
+	test := render.NewOutputPluginTest(t, nats)
+	test.DiffResult(expected)
+}
+
+func TestNATSSubject(t *testing.T) {
+	CONFIG := []byte(`
+host: nats.nats.svc.cluster.local
+subject: logs.${namespace_name}.${labels.app}
+buffer:
+  flush_interval: 10s
+`)
+	expected := `
+  <match **>
+    @type tag_normaliser
+    @id test_subject
+    @label @2ab46f3b156b31e1c808ac53885394fb_output_0
+    format logs.${namespace_name}.${labels.app}
+  </match>
+</label>
+<label @2ab46f3b156b31e1c808ac53885394fb_output_0>
+  <match **>
+    @type nats
+    @id test
+    host nats.nats.svc.cluster.local
+    <buffer tag,time>
+      @type file
+      chunk_limit_size 8MB
+      flush_interval 10s
+      path /buffers/test.*.buffer
+      retry_forever true
+      timekey 10m
+      timekey_wait 1m
+    </buffer>
+  </match>
+`
+	nats := &output.NATSOutputConfig{}
+	require.NoError(t, yaml.Unmarshal(CONFIG, nats))
+	test := render.NewOutputPluginTest(t, nats)
+	test.DiffResult(expected)
+}
@@ -0,0 +1,214 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import (
+	"fmt"
+
+	"github.com/cisco-open/operator-tools/pkg/secret"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/types"
+)
+
+// +name:"RabbitMQ"
+// +weight:"200"
+type _hugoRabbitMQ interface{} //nolint:deadcode,unused
+
+// +docName:"RabbitMQ output plugin for Fluentd"
+// Publishes records to a RabbitMQ (AMQP 0-9-1) exchange.
+// More info at https://github.com/nttcom/fluent-plugin-rabbitmq
+//
+// The routing key can contain buffer placeholders (for example `${tag}`).
+// The TLS certificate and key options are file paths, so set them with `mountFrom`.
+//
+// ## Example output configurations
+// ```yaml
+// spec:
+//
+//	rabbitmq:
+//	  host: rabbitmq.rabbitmq.svc.cluster.local
+//	  vhost: /
+//	  exchange: logs
+//	  exchange_type: topic
+//	  routing_key: logs.${tag}
+//	  user:
+//	    valueFrom:
+//	      secretKeyRef:
+//	        name: rabbitmq-auth
+//	        key: user
+//	  pass:
+//	    valueFrom:
+//	      secretKeyRef:
+//	        name: rabbitmq-auth
+//	        key: password
+//	  format:
+//	    type: json
+//
+// ```
+//
+// ## Testing against a local broker
+// A single `rabbitmq:3` container exposed through a Service can stand in for the
+// production cluster. The default `guest` user is only allowed from localhost, so
+// create a dedicated user in the container and point the output at it:
+// ```yaml
+// spec:
+//
+//	rabbitmq:
+//	  host: rabbitmq-local.default.svc
+//	  exchange: logs
+//	  exchange_type: fanout
+//	  user:
+//	    value: test
+//	  pass:
+//	    value: test
+//
+// ```
+type _docRabbitMQ interface{} //nolint:deadcode,unused
+
+// +name:"RabbitMQ"
+// +url:"https://github.com/nttcom/fluent-plugin-rabbitmq"
+// +version:"0.1.4"
+// +description:"Publish your logs to RabbitMQ exchanges"
+// +status:"Testing"
+type _metaRabbitMQ interface{} //nolint:deadcode,unused
+
+// +kubebuilder:object:generate=true
+// +docName:"Output Config"
+type RabbitMQOutputConfig struct {
+	// Host of the RabbitMQ server (default: localhost)
+	Host string `json:"host,omitempty"`
+	// List of RabbitMQ hosts for a clustered setup. If set, host is ignored.
+	Hosts []string `json:"hosts,omitempty"`
+	// Port of the RabbitMQ server (default: 5672)
+	Port int `json:"port,omitempty"`
+	// Username for authentication (default: guest)
+	// +docLink:"Secret,../secret/"
+	User *secret.Secret `json:"user,omitempty"`
+	// Password for authentication (default: guest)
+	// +docLink:"Secret,../secret/"
+	Pass *secret.Secret `json:"pass,omitempty"`
+	// Virtual host (default: /)
+	Vhost string `json:"vhost,omitempty"`
+	// Connection timeout in seconds
+	ConnectionTimeout int `json:"connection_timeout,omitempty"`
+	// Heartbeat timeout in seconds
+	Heartbeat int `json:"heartbeat,omitempty"`
+	// Recover the connection automatically after a network failure (default: true)
+	AutomaticallyRecover *bool `json:"automatically_recover,omitempty"`
+	// Interval in seconds between network recovery attempts (default: 5)
+	NetworkRecoveryInterval int `json:"network_recovery_interval,omitempty"`
+	// Use TLS for the connection (default: false)
+	TLS *bool `json:"tls,omitempty"`
+	// TLS: client certificate file, must be set with mountFrom
+	// +docLink:"Secret,../secret/"
+	TLSCert *secret.Secret `json:"tls_cert,omitempty"`
+	// TLS: client certificate key file, must be set with mountFrom
+	// +docLink:"Secret,../secret/"
+	TLSKey *secret.Secret `json:"tls_key,omitempty"`
+	// TLS: CA certificate file for server certificate verification, must be set with mountFrom
+	// +docLink:"Secret,../secret/"
+	TLSCACertificates *secret.Secret `json:"tls_ca_certificates,omitempty"`
+	// TLS: verify the server certificate (default: true)
+	VerifyPeer *bool `json:"verify_peer,omitempty"`
+	// Name of the exchange to publish to
+	Exchange string `json:"exchange"`
+	// Type of the exchange: direct, fanout, topic, headers
+	// +kubebuilder:validation:Enum=direct;fanout;topic;headers
+	ExchangeType string `json:"exchange_type"`
+	// Whether the exchange is durable (default: false)
+	ExchangeDurable *bool `json:"exchange_durable,omitempty"`
+	// Do not declare the exchange, it must already exist (default: false)
+	ExchangeNoDeclare *bool `json:"exchange_no_declare,omitempty"`
+	// Routing key of the published messages. Buffer placeholders like ${tag} are replaced per chunk.
+	RoutingKey string `json:"routing_key,omitempty"`
+	// Record key whose value is used as the message id
+	IdKey string `json:"id_key,omitempty"`
+	// Mark messages as persistent (default: false)
+	Persistent *bool `json:"persistent,omitempty"`
+	// Add the record timestamp to the message properties (default: false)
+	Timestamp *bool `json:"timestamp,omitempty"`
+	// Content type of the messages
+	ContentType string `json:"content_type,omitempty"`
+	// Content encoding of the messages
+	ContentEncoding string `json:"content_encoding,omitempty"`
+	// Message time-to-live in milliseconds
+	Expiration int `json:"expiration,omitempty"`
+	// Message type property
+	MessageType string `json:"message_type,omitempty"`
+	// Message priority
+	Priority int `json:"priority,omitempty"`
+	// Application id property
+	AppId string `json:"app_id,omitempty"`
+	// +docLink:"Format,../format/"
+	Format *Format `json:"format,omitempty"`
+	// +docLink:"Buffer,../buffer/"
+	Buffer *Buffer `json:"buffer,omitempty"`
+	// The threshold for chunk flush performance check.
+	// Parameter type is float, not time, default: 20.0 (seconds)
+	// If chunk flush takes longer time than this threshold, fluentd logs warning message and increases metric fluentd_output_status_slow_flush_count.
+	SlowFlushLogThreshold string `json:"slow_flush_log_threshold,omitempty"`
+}
+
+func (r *RabbitMQOutputConfig) ToDirective(secretLoader secret.SecretLoader, id string) (types.Directive, error) {
+	const pluginType = "rabbitmq"
+	rabbitmq := &types.OutputPlugin{
+		PluginMeta: types.PluginMeta{
+			Type:      pluginType,
+			Directive: "match",
+			Tag:       "**",
+			Id:        id,
+		},
+	}
+	if err := r.validateTLSFiles(); err != nil {
+		return nil, err
+	}
+	if params, err := types.NewStructToStringMapper(secretLoader).StringsMap(r); err != nil {
+		return nil, err
+	} else {
+		rabbitmq.Params = params
+	}
+	if r.Buffer == nil {
+		r.Buffer = &Buffer{}
+	}
+	if buffer, err := r.Buffer.ToDirective(secretLoader, id); err != nil {
+		return nil, err
+	} else {
+		rabbitmq.SubDirectives = append(rabbitmq.SubDirectives, buffer)
+	}
+	if r.Format != nil {
+		if format, err := r.Format.ToDirective(secretLoader, ""); err != nil {
+			return nil, err
+		} else {
+			rabbitmq.SubDirectives = append(rabbitmq.SubDirectives, format)
+		}
+	}
+	return rabbitmq, nil
+}
+
+func (r *RabbitMQOutputConfig) validateTLSFiles() error {
+	files := []struct {
+		name   string
+		secret *secret.Secret
+	}{
+		{"tls_cert", r.TLSCert},
+		{"tls_key", r.TLSKey},
+		{"tls_ca_certificates", r.TLSCACertificates},
+	}
+	for _, file := range files {
+		if file.secret != nil && file.secret.MountFrom == nil {
+			return fmt.Errorf("%s must be set with mountFrom, the plugin expects a file path", file.name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output_test
+
+import (
+	"testing"
+
+	"github.com/cisco-open/operator-tools/pkg/secret"
+	"github.com/ghodss/yaml"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/output"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/render"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRabbitMQ(t *testing.T) {
+	CONFIG := []byte(`
+hosts:
+  - rabbitmq-0.rabbitmq
+  - rabbitmq-1.rabbitmq
+user:
+  value: guest
+pass:
+  value: guest
+vhost: /
+tls: true
+exchange: logs
+exchange_type: topic
+exchange_durable: true
+routing_key: logs.${tag}
+persistent: true
+format:
+  type: json
+buffer:
+  timekey: 1m
+  timekey_wait: 30s
+  timekey_use_utc: true
+`)
+	expected := `
+  <match **>
+    @type rabbitmq
+    @id test
+    exchange logs
+    exchange_durable true
+    exchange_type topic
+    hosts ["rabbitmq-0.rabbitmq","rabbitmq-1.rabbitmq"]
+    pass guest
+    persistent true
+    routing_key logs.${tag}
+    tls true
+    user guest
+    vhost /
+    <buffer tag,time>
+      @type file
+      chunk_limit_size 8MB
+      path /buffers/test.*.buffer
+      retry_forever true
+      timekey 1m
+      timekey_use_utc true
+      timekey_wait 30s
+    </buffer>
+    <format>
+      @type json
+    </format>
+  </match>
+`
+	rabbitmq := &output.RabbitMQOutputConfig{}
+	require.NoError(t, yaml.Unmarshal(CONFIG, rabbitmq))
+	test := render.NewOutputPluginTest(t, rabbitmq)
+	test.DiffResult(expected)
+}
+
+func TestRabbitMQInlineTLSCert(t *testing.T) {
+	CONFIG := []byte(`
+host: rabbitmq
+exchange: logs
+exchange_type: topic
+tls: true
+tls_cert:
+  value: "-----BEGIN CERTIFICATE-----"
+`)
+	rabbitmq := &output.RabbitMQOutputConfig{}
+	require.NoError(t, yaml.Unmarshal(CONFIG, rabbitmq))
+	_, err := rabbitmq.ToDirective(secret.NewSecretLoader(nil, "", "", nil), "test")
+	require.EqualError(t, err, "tls_cert must be set with mountFrom, the plugin expects a file path")
+}
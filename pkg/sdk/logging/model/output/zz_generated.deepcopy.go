@@ -561,6 +561,58 @@ func (in *GCSOutput) DeepCopy() *GCSOutput {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCloudPubSubOutputConfig) DeepCopyInto(out *GCloudPubSubOutputConfig) {
+	*out = *in
+	if in.Key != nil {
+		in, out := &in.Key, &out.Key
+		*out = new(secret.Secret)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AutocreateTopic != nil {
+		in, out := &in.AutocreateTopic, &out.AutocreateTopic
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AttributeKeys != nil {
+		in, out := &in.AttributeKeys, &out.AttributeKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AttributeKeyValues != nil {
+		in, out := &in.AttributeKeyValues, &out.AttributeKeyValues
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.CompressBatches != nil {
+		in, out := &in.CompressBatches, &out.CompressBatches
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Format != nil {
+		in, out := &in.Format, &out.Format
+		*out = new(Format)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Buffer != nil {
+		in, out := &in.Buffer, &out.Buffer
+		*out = new(Buffer)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCloudPubSubOutputConfig.
+func (in *GCloudPubSubOutputConfig) DeepCopy() *GCloudPubSubOutputConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GCloudPubSubOutputConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GELFOutputConfig) DeepCopyInto(out *GELFOutputConfig) {
 	*out = *in
@@ -1093,6 +1145,46 @@ func (in *MattermostOutputConfig) DeepCopy() *MattermostOutputConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATSOutputConfig) DeepCopyInto(out *NATSOutputConfig) {
+	*out = *in
+	if in.User != nil {
+		in, out := &in.User, &out.User
+		*out = new(secret.Secret)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Password != nil {
+		in, out := &in.Password, &out.Password
+		*out = new(secret.Secret)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SSL != nil {
+		in, out := &in.SSL, &out.SSL
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Format != nil {
+		in, out := &in.Format, &out.Format
+		*out = new(Format)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Buffer != nil {
+		in, out := &in.Buffer, &out.Buffer
+		*out = new(Buffer)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATSOutputConfig.
+func (in *NATSOutputConfig) DeepCopy() *NATSOutputConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NATSOutputConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NewRelicOutputConfig) DeepCopyInto(out *NewRelicOutputConfig) {
 	*out = *in
@@ -1303,6 +1395,96 @@ func (in *OpenSearchOutput) DeepCopy() *OpenSearchOutput {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitMQOutputConfig) DeepCopyInto(out *RabbitMQOutputConfig) {
+	*out = *in
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.User != nil {
+		in, out := &in.User, &out.User
+		*out = new(secret.Secret)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Pass != nil {
+		in, out := &in.Pass, &out.Pass
+		*out = new(secret.Secret)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AutomaticallyRecover != nil {
+		in, out := &in.AutomaticallyRecover, &out.AutomaticallyRecover
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TLSCert != nil {
+		in, out := &in.TLSCert, &out.TLSCert
+		*out = new(secret.Secret)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLSKey != nil {
+		in, out := &in.TLSKey, &out.TLSKey
+		*out = new(secret.Secret)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLSCACertificates != nil {
+		in, out := &in.TLSCACertificates, &out.TLSCACertificates
+		*out = new(secret.Secret)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VerifyPeer != nil {
+		in, out := &in.VerifyPeer, &out.VerifyPeer
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ExchangeDurable != nil {
+		in, out := &in.ExchangeDurable, &out.ExchangeDurable
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ExchangeNoDeclare != nil {
+		in, out := &in.ExchangeNoDeclare, &out.ExchangeNoDeclare
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Persistent != nil {
+		in, out := &in.Persistent, &out.Persistent
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Timestamp != nil {
+		in, out := &in.Timestamp, &out.Timestamp
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Format != nil {
+		in, out := &in.Format, &out.Format
+		*out = new(Format)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Buffer != nil {
+		in, out := &in.Buffer, &out.Buffer
+		*out = new(Buffer)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQOutputConfig.
+func (in *RabbitMQOutputConfig) DeepCopy() *RabbitMQOutputConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitMQOutputConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RedisOutputConfig) DeepCopyInto(out *RedisOutputConfig) {
 	*out = *in
@@ -40,6 +40,12 @@ func (f *FluentRender) RenderDirectives(directives []types.Directive, indent int
 		if d == nil {
 			continue
 		}
+		if chain, ok := d.(*types.DirectiveChain); ok {
+			if err := f.RenderDirectives(chain.Directives, indent); err != nil {
+				return err
+			}
+			continue
+		}
 		meta := d.GetPluginMeta()
 		if meta.Directive == "" {
 			return fmt.Errorf("Directive must have a name %s", meta)
@@ -819,3 +819,183 @@ func toDirective(t *testing.T, converter plugins.DirectiveConverter) types.Direc
 	}
 	return directive
 }
+
+func TestRenderFlowWithRewriteTag(t *testing.T) {
+	system := types.NewSystemBuilder(toDirective(t, input.NewTailInputConfig("input.log")), nil, types.NewRouter("test", nil))
+
+	flowObj, err := types.NewFlow(
+		[]types.FlowMatch{
+			{Namespaces: []string{"ns-test"}},
+		}, "", "", "", "", util.BoolPointer(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rewriteTag := &filter.RewriteTag{
+		Rules: []filter.RewriteTagRule{
+			{Key: "$.kubernetes.labels.app", Pattern: "/^(.+)$/", Tag: "app.$1"},
+		},
+	}
+	flowObj.
+		WithFilters(
+			toDirective(t, filter.NewStdOutFilterConfig()),
+			toDirective(t, rewriteTag),
+			toDirective(t, filter.NewStdOutFilterConfig())).
+		WithOutputs(toDirective(t, output.NewNullOutputConfig()))
+
+	err = system.RegisterFlow(flowObj)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fluentConfig, err := system.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := &bytes.Buffer{}
+	renderer := render.FluentRender{
+		Out:    b,
+		Indent: 2,
+	}
+	err = renderer.Render(fluentConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `
+		<source>
+          @type tail
+          @id test
+          path input.log
+        </source>
+        <match **>
+          @type label_router
+          @id test
+          <route>
+            @label @a42fd8d29c181fcf9887280c4a51bd1e
+			  <match>
+			    namespaces ns-test
+			    negate false
+			  </match>
+          </route>
+        </match>
+        <label @a42fd8d29c181fcf9887280c4a51bd1e>
+          <filter **>
+            @type stdout
+            @id test
+          </filter>
+          <match **>
+            @type rewrite_tag_filter
+            @id test
+            @label @a42fd8d29c181fcf9887280c4a51bd1e_0
+            <rule>
+              key $.kubernetes.labels.app
+              pattern /^(.+)$/
+              tag app.$1
+            </rule>
+          </match>
+        </label>
+        <label @a42fd8d29c181fcf9887280c4a51bd1e_0>
+          <filter **>
+            @type stdout
+            @id test
+          </filter>
+          <match **>
+            @type null
+            @id test
+          </match>
+        </label>`
+
+	if a, e := diff.TrimLinesInString(b.String()), diff.TrimLinesInString(expected); a != e {
+		t.Errorf("Result does not match (-actual vs +expected):\n%v\nActual: %s", diff.LineDiff(a, e), b.String())
+	}
+}
+
+func TestRenderFlowWithReEmitOutput(t *testing.T) {
+	system := types.NewSystemBuilder(toDirective(t, input.NewTailInputConfig("input.log")), nil, types.NewRouter("test", nil))
+
+	flowObj, err := types.NewFlow(
+		[]types.FlowMatch{
+			{Namespaces: []string{"ns-test"}},
+		}, "", "", "", "", util.BoolPointer(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	nats := &output.NATSOutputConfig{
+		Subject: "logs.${namespace_name}",
+		Buffer:  &output.Buffer{Type: "memory", Timekey: "1m", TimekeyWait: "30s"},
+	}
+	flowObj.WithOutputs(
+		toDirective(t, nats),
+		toDirective(t, output.NewNullOutputConfig()))
+
+	err = system.RegisterFlow(flowObj)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fluentConfig, err := system.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := &bytes.Buffer{}
+	renderer := render.FluentRender{
+		Out:    b,
+		Indent: 2,
+	}
+	err = renderer.Render(fluentConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `
+		<source>
+          @type tail
+          @id test
+          path input.log
+        </source>
+        <match **>
+          @type label_router
+          @id test
+          <route>
+            @label @a42fd8d29c181fcf9887280c4a51bd1e
+			  <match>
+			    namespaces ns-test
+			    negate false
+			  </match>
+          </route>
+        </match>
+        <label @a42fd8d29c181fcf9887280c4a51bd1e>
+          <match **>
+            @type copy
+            <store>
+              @type tag_normaliser
+              @id test_subject
+              @label @a42fd8d29c181fcf9887280c4a51bd1e_output_0
+              format logs.${namespace_name}
+            </store>
+            <store>
+              @type null
+              @id test
+            </store>
+          </match>
+        </label>
+        <label @a42fd8d29c181fcf9887280c4a51bd1e_output_0>
+          <match **>
+            @type nats
+            @id test
+            <buffer tag,time>
+              @type memory
+              chunk_limit_size 8MB
+              retry_forever true
+              timekey 1m
+              timekey_wait 30s
+            </buffer>
+          </match>
+        </label>`
+
+	if a, e := diff.TrimLinesInString(b.String()), diff.TrimLinesInString(expected); a != e {
+		t.Errorf("Result does not match (-actual vs +expected):\n%v\nActual: %s", diff.LineDiff(a, e), b.String())
+	}
+}
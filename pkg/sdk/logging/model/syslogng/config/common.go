@@ -45,6 +45,8 @@ func renderValue(value reflect.Value, secretLoader secret.SecretLoader) []render
 			return []render.Renderer{render.Error(err)}
 		}
 		return []render.Renderer{render.Literal(sec)}
+	case filter.PatternDBRef:
+		return []render.Renderer{render.Literal(val.Path())}
 	}
 
 	if value.CanConvert(matchExprType) {
@@ -115,8 +117,9 @@ func renderValue(value reflect.Value, secretLoader secret.SecretLoader) []render
 
 		var posArgs []posArg
 		var nonPos []render.Renderer
+		arrows := make(map[string]*[]render.Renderer)
 		for _, f := range fs {
-			renderField(f, secretLoader, &nonPos, &posArgs)
+			renderField(f, secretLoader, &nonPos, &posArgs, arrows)
 		}
 		slices.SortFunc(posArgs, func(a, b posArg) bool { return a.pos < b.pos })
 		var res []render.Renderer
@@ -134,10 +137,12 @@ type posArg struct {
 	rnds []render.Renderer
 }
 
-func renderField(f Field, secretLoader secret.SecretLoader, nonPos *[]render.Renderer, posArgs *[]posArg) {
+// renderField appends the rendered field to the positional or non-positional arguments.
+// Fields rendered as key-value pairs with the same option key are merged into a single option, which is kept in arrows.
+func renderField(f Field, secretLoader secret.SecretLoader, nonPos *[]render.Renderer, posArgs *[]posArg, arrows map[string]*[]render.Renderer) {
 	if f.Meta.Anonymous {
 		for _, ff := range fieldsOf(f.Value) {
-			renderField(ff, secretLoader, nonPos, posArgs)
+			renderField(ff, secretLoader, nonPos, posArgs, arrows)
 		}
 		return
 	}
@@ -161,9 +166,70 @@ func renderField(f Field, secretLoader secret.SecretLoader, nonPos *[]render.Ren
 		*nonPos = append(*nonPos, render.Error(err))
 		return
 	}
+	if settings.Arrows() {
+		// the option's arguments are "key" => "value" pairs
+		if args, ok := arrows[key]; ok {
+			*args = append(*args, renderArrows(f.Value, secretLoader)...)
+			return
+		}
+		args := renderArrows(f.Value, secretLoader)
+		arrows[key] = &args
+		*nonPos = append(*nonPos, func(ctx render.RenderContext) error {
+			return optionExpr(key, args...)(ctx)
+		})
+		return
+	}
+	if settings.Pairs() {
+		// the option is repeated for each entry with the key and the value as its arguments
+		*nonPos = append(*nonPos, renderPairs(key, f.Value, secretLoader)...)
+		return
+	}
 	*nonPos = append(*nonPos, optionExpr(key, renderValue(f.Value, secretLoader)...))
 }
 
+func renderPairs(option string, value reflect.Value, secretLoader secret.SecretLoader) []render.Renderer {
+	value = derefAll(value)
+	if value.Kind() != reflect.Map || value.Type().Key().Kind() != reflect.String {
+		return []render.Renderer{render.Error(fmt.Errorf("cannot render value of type %s as repeated key-value options", value.Type()))}
+	}
+	keys := value.MapKeys()
+	slices.SortFunc(keys, func(a, b reflect.Value) bool { return a.String() < b.String() })
+	res := make([]render.Renderer, 0, len(keys))
+	for _, keyVal := range keys {
+		res = append(res, optionExpr(option, append([]render.Renderer{render.Quoted(keyVal.String())}, renderValue(value.MapIndex(keyVal), secretLoader)...)...))
+	}
+	return res
+}
+
+func renderArrows(value reflect.Value, secretLoader secret.SecretLoader) []render.Renderer {
+	value = derefAll(value)
+	switch value.Kind() {
+	case reflect.Map:
+		if value.Type().Key().Kind() != reflect.String {
+			return []render.Renderer{render.Error(fmt.Errorf("cannot render map entry with key type %s", value.Type().Key()))}
+		}
+		keys := value.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) bool { return a.String() < b.String() })
+		res := make([]render.Renderer, 0, len(keys))
+		for _, keyVal := range keys {
+			res = append(res, arrowExpr(keyVal.String(), renderValue(value.MapIndex(keyVal), secretLoader)...))
+		}
+		return res
+	case reflect.Struct:
+		var res []render.Renderer
+		for _, f := range fieldsOf(value) {
+			key, err := fieldKey(f, nil)
+			if err != nil {
+				res = append(res, render.Error(err))
+				continue
+			}
+			res = append(res, arrowExpr(key, renderValue(f.Value, secretLoader)...))
+		}
+		return res
+	}
+	return []render.Renderer{render.Error(fmt.Errorf("cannot render value of type %s as key-value pairs", value.Type()))}
+}
+
 func renderDriver(f Field, secretLoader secret.SecretLoader) render.Renderer {
 	name, err := fieldKey(f, nil)
 	if err != nil {
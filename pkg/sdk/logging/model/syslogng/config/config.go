@@ -57,10 +57,22 @@ type SecretLoaderFactory interface {
 const configVersion = "current"
 const sourceName = "main_input"
 
+func hasPatternDBs(spec *v1beta1.SyslogNGSpec, filters []v1beta1.SyslogNGFilter) bool {
+	for _, ref := range v1beta1.PatternDBRefs(filters) {
+		if !spec.HasPatternDB(ref) {
+			return false
+		}
+	}
+	return true
+}
+
 func configRenderer(in Input) (render.Renderer, error) {
 	if in.Logging.Spec.SyslogNGSpec == nil {
 		return nil, errors.New("missing syslog-ng spec")
 	}
+	if err := validateSourcePorts(in); err != nil {
+		return nil, err
+	}
 
 	// TODO: this should happen at the spec level, in something like `SyslogNGSpec.FinalGlobalOptions() GlobalOptions`
 	if in.Logging.Spec.SyslogNGSpec.Metrics != nil {
@@ -78,19 +90,36 @@ func configRenderer(in Input) (render.Renderer, error) {
 
 	globalOptions := renderAny(in.Logging.Spec.SyslogNGSpec.GlobalOptions, in.SecretLoaderFactory.SecretLoaderForNamespace(in.Logging.Namespace))
 
+	// the disk-buffers are stored on the buffer storage volume if the operator mounts one
+	var bufferDir string
+	if in.Logging.Spec.SyslogNGSpec.BufferStorageVolumeConfigured() {
+		bufferDir = v1beta1.SyslogNGBufferStoragePath
+	}
 	destinationDefs := make([]render.Renderer, 0, len(in.ClusterOutputs)+len(in.Outputs))
 	for _, co := range in.ClusterOutputs {
-		destinationDefs = append(destinationDefs, renderClusterOutput(co, in.SecretLoaderFactory))
+		destinationDefs = append(destinationDefs, renderClusterOutput(co, keyDelim(in.Logging.Spec.SyslogNGSpec.JSONKeyDelimiter), bufferDir, in.SecretLoaderFactory))
 	}
 	for _, o := range in.Outputs {
-		destinationDefs = append(destinationDefs, renderOutput(o, in.SecretLoaderFactory))
+		destinationDefs = append(destinationDefs, renderOutput(o, keyDelim(in.Logging.Spec.SyslogNGSpec.JSONKeyDelimiter), bufferDir, in.SecretLoaderFactory))
 	}
 
 	logDefs := make([]render.Renderer, 0, len(in.ClusterFlows)+len(in.Flows))
 	for _, cf := range in.ClusterFlows {
-		logDefs = append(logDefs, renderClusterFlow(sourceName, cf, in.SecretLoaderFactory))
+		if !hasPatternDBs(in.Logging.Spec.SyslogNGSpec, cf.Spec.Filters) {
+			continue
+		}
+		sources, ok := clusterFlowSources(cf, in.Logging.Spec.SyslogNGSpec.NetworkSources)
+		if !ok {
+			// the validation reconciler reports the dangling network source reference
+			continue
+		}
+		logDefs = append(logDefs, renderClusterFlow(sources, cf, in.SecretLoaderFactory))
 	}
 	for _, f := range in.Flows {
+		if !hasPatternDBs(in.Logging.Spec.SyslogNGSpec, f.Spec.Filters) {
+			// the validation reconciler reports the dangling pattern database reference
+			continue
+		}
 		logDefs = append(logDefs, renderFlow(in.Logging.Spec.ControlNamespace, sourceName, keyDelim(in.Logging.Spec.SyslogNGSpec.JSONKeyDelimiter), f, in.SecretLoaderFactory))
 	}
 
@@ -105,16 +134,10 @@ func configRenderer(in Input) (render.Renderer, error) {
 					versionStmt(configVersion),
 					includeStmt("scl.conf"),
 					globalOptionsDefStmt(globalOptions...),
-					sourceDefStmt(sourceName,
+					sourceDefStmt(sourceName, render.AllOf(
 						channelDefStmt(
 							sourceDefStmt("", renderDriver(Field{
-								Value: reflect.ValueOf(NetworkSourceDriver{
-									Transport:      "tcp",
-									Port:           uint16(in.SourcePort),
-									MaxConnections: in.Logging.Spec.SyslogNGSpec.MaxConnections,
-									LogIWSize:      logIWSizeCalculator(in),
-									Flags:          []string{"no-parse"},
-								}),
+								Value: reflect.ValueOf(mainSourceDriver(in)),
 							}, nil)),
 							[]render.Renderer{
 								parserDefStmt("", renderDriver(Field{
@@ -124,8 +147,11 @@ func configRenderer(in Input) (render.Renderer, error) {
 									}),
 								}, nil)),
 							},
-						)),
+						),
+						otlpSourceChannel(in.Logging.Spec.SyslogNGSpec.OTLPSource, keyDelim(in.Logging.Spec.SyslogNGSpec.JSONKeyDelimiter)),
+					)),
 				),
+				seqs.Map(seqs.FromSlice(in.Logging.Spec.SyslogNGSpec.NetworkSources), renderNetworkSource),
 				seqs.FromSlice(destinationDefs),
 				seqs.FromSlice(logDefs),
 			),
@@ -15,6 +15,7 @@
 package config
 
 import (
+	"errors"
 	"strings"
 	"testing"
 
@@ -51,6 +52,41 @@ func TestRenderConfigInto(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		"network source on the main source port": {
+			input: Input{
+				Logging: v1beta1.Logging{
+					Spec: v1beta1.LoggingSpec{
+						SyslogNGSpec: &v1beta1.SyslogNGSpec{
+							NetworkSources: []v1beta1.SyslogNGNetworkSource{
+								{Name: "switches", Port: 601},
+							},
+						},
+					},
+				},
+				SecretLoaderFactory: &TestSecretLoaderFactory{},
+				SourcePort:          601,
+			},
+			wantErr: errors.New("network source switches listens on port 601, which is already used by the main source"),
+		},
+		"network sources on the same port": {
+			input: Input{
+				Logging: v1beta1.Logging{
+					Spec: v1beta1.LoggingSpec{
+						SyslogNGSpec: &v1beta1.SyslogNGSpec{
+							OTLPSource: &v1beta1.SyslogNGOTLPSource{Port: 4317},
+							NetworkSources: []v1beta1.SyslogNGNetworkSource{
+								{Name: "switches", Port: 514, Transport: "udp"},
+								{Name: "routers", Port: 514},
+								{Name: "firewalls", Port: 514, Transport: "tls"},
+							},
+						},
+					},
+				},
+				SecretLoaderFactory: &TestSecretLoaderFactory{},
+				SourcePort:          601,
+			},
+			wantErr: errors.New("network source firewalls listens on port 514, which is already used by network source routers"),
+		},
 		"single flow with single output": {
 			input: Input{
 				SourcePort: 601,
@@ -214,6 +250,34 @@ source "main_input" {
         };
     };
 };
+`,
+		},
+		"tls source": {
+			input: Input{
+				Logging: v1beta1.Logging{
+					Spec: v1beta1.LoggingSpec{
+						SyslogNGSpec: &v1beta1.SyslogNGSpec{
+							TLS: v1beta1.SyslogNGTLS{Enabled: true, SecretName: "aggregator-tls"},
+						},
+					},
+				},
+				SourcePort:          601,
+				SecretLoaderFactory: &TestSecretLoaderFactory{},
+			},
+			wantOut: `@version: current
+
+@include "scl.conf"
+
+source "main_input" {
+    channel {
+        source {
+            network(flags("no-parse") port(601) transport("tls") tls(key-file("/syslog-ng/tls/tls.key") cert-file("/syslog-ng/tls/tls.crt") ca-file("/syslog-ng/tls/ca.crt") peer-verify("required-trusted")));
+        };
+        parser {
+            json-parser(prefix("json."));
+        };
+    };
+};
 `,
 		},
 		"global options_new_stats": {
@@ -681,6 +745,249 @@ source "main_input" {
         };
     };
 };
+`),
+		},
+		"otlp source": {
+			input: Input{
+				Logging: v1beta1.Logging{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "logging",
+						Name:      "test",
+					},
+					Spec: v1beta1.LoggingSpec{
+						SyslogNGSpec: &v1beta1.SyslogNGSpec{
+							OTLPSource: &v1beta1.SyslogNGOTLPSource{
+								Port: 4317,
+							},
+						},
+					},
+				},
+				SecretLoaderFactory: &TestSecretLoaderFactory{},
+				SourcePort:          601,
+			},
+			wantOut: Untab(`@version: current
+
+@include "scl.conf"
+
+source "main_input" {
+    channel {
+        source {
+            network(flags("no-parse") port(601) transport("tcp"));
+        };
+        parser {
+            json-parser(prefix("json."));
+        };
+    };
+    channel {
+        source {
+            opentelemetry(port(4317));
+        };
+        rewrite {
+            set("${.otel.log.body}" value("json.message"));
+            set("${.otel.resource.attributes.k8s.namespace.name}" value("json.kubernetes.namespace_name"));
+            set("${.otel.resource.attributes.k8s.pod.name}" value("json.kubernetes.pod_name"));
+            set("${.otel.resource.attributes.k8s.pod.uid}" value("json.kubernetes.pod_id"));
+            set("${.otel.resource.attributes.k8s.container.name}" value("json.kubernetes.container_name"));
+            set("${.otel.resource.attributes.k8s.node.name}" value("json.kubernetes.host"));
+        };
+    };
+};
+`),
+		},
+		"network sources": {
+			input: Input{
+				Logging: v1beta1.Logging{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "logging",
+						Name:      "test",
+					},
+					Spec: v1beta1.LoggingSpec{
+						SyslogNGSpec: &v1beta1.SyslogNGSpec{
+							NetworkSources: []v1beta1.SyslogNGNetworkSource{
+								{
+									Name:      "switches",
+									Port:      514,
+									Transport: "udp",
+									Protocol:  "rfc3164",
+								},
+								{
+									Name:      "firewalls",
+									Port:      6514,
+									Transport: "tls",
+									Protocol:  "rfc5424",
+									TLS: &v1beta1.SyslogNGNetworkSourceTLS{
+										SecretName: "firewalls-tls",
+										CACert:     true,
+										PeerVerify: "required-trusted",
+									},
+								},
+								{
+									Name:      "routers",
+									Port:      6515,
+									Transport: "tls",
+									Protocol:  "rfc5424",
+									TLS: &v1beta1.SyslogNGNetworkSourceTLS{
+										SecretName: "routers-tls",
+									},
+								},
+							},
+						},
+					},
+				},
+				ClusterFlows: []v1beta1.SyslogNGClusterFlow{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "logging",
+							Name:      "devices",
+						},
+						Spec: v1beta1.SyslogNGClusterFlowSpec{
+							Sources: []string{"switches", "firewalls"},
+						},
+					},
+				},
+				SecretLoaderFactory: &TestSecretLoaderFactory{},
+				SourcePort:          601,
+			},
+			wantOut: Untab(`@version: current
+
+@include "scl.conf"
+
+source "main_input" {
+    channel {
+        source {
+            network(flags("no-parse") port(601) transport("tcp"));
+        };
+        parser {
+            json-parser(prefix("json."));
+        };
+    };
+};
+
+source "network_switches" {
+    network(port(514) transport("udp"));
+};
+
+source "network_firewalls" {
+    syslog(port(6514) transport("tls") tls(key-file("/syslog-ng/sources/firewalls/tls.key") cert-file("/syslog-ng/sources/firewalls/tls.crt") ca-file("/syslog-ng/sources/firewalls/ca.crt") peer-verify("required-trusted")));
+};
+
+source "network_routers" {
+    syslog(port(6515) transport("tls") tls(key-file("/syslog-ng/sources/routers/tls.key") cert-file("/syslog-ng/sources/routers/tls.crt")));
+};
+
+log {
+    source("network_switches");
+    source("network_firewalls");
+};
+`),
+		},
+		"undefined network source": {
+			input: Input{
+				Logging: v1beta1.Logging{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "logging",
+						Name:      "test",
+					},
+					Spec: v1beta1.LoggingSpec{
+						SyslogNGSpec: &v1beta1.SyslogNGSpec{},
+					},
+				},
+				ClusterFlows: []v1beta1.SyslogNGClusterFlow{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "logging",
+							Name:      "devices",
+						},
+						Spec: v1beta1.SyslogNGClusterFlowSpec{
+							Sources: []string{"switches"},
+						},
+					},
+				},
+				SecretLoaderFactory: &TestSecretLoaderFactory{},
+				SourcePort:          601,
+			},
+			wantOut: Untab(`@version: current
+
+@include "scl.conf"
+
+source "main_input" {
+    channel {
+        source {
+            network(flags("no-parse") port(601) transport("tcp"));
+        };
+        parser {
+            json-parser(prefix("json."));
+        };
+    };
+};
+`),
+		},
+		"undefined pattern database": {
+			input: Input{
+				Logging: v1beta1.Logging{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "logging",
+						Name:      "test",
+					},
+					Spec: v1beta1.LoggingSpec{
+						SyslogNGSpec: &v1beta1.SyslogNGSpec{
+							PatternDBs: []v1beta1.SyslogNGPatternDB{{Name: "sshd"}},
+						},
+					},
+				},
+				Flows: []v1beta1.SyslogNGFlow{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "default",
+							Name:      "sshd",
+						},
+						Spec: v1beta1.SyslogNGFlowSpec{
+							Filters: []v1beta1.SyslogNGFilter{
+								{Parser: &filter.ParserConfig{DBParser: &filter.DBParser{PatternDB: "sshd"}}},
+							},
+						},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "default",
+							Name:      "dangling",
+						},
+						Spec: v1beta1.SyslogNGFlowSpec{
+							Filters: []v1beta1.SyslogNGFilter{
+								{Parser: &filter.ParserConfig{DBParser: &filter.DBParser{PatternDB: "missing"}}},
+							},
+						},
+					},
+				},
+				SecretLoaderFactory: &TestSecretLoaderFactory{},
+				SourcePort:          601,
+			},
+			wantOut: Untab(`@version: current
+
+@include "scl.conf"
+
+source "main_input" {
+    channel {
+        source {
+            network(flags("no-parse") port(601) transport("tcp"));
+        };
+        parser {
+            json-parser(prefix("json."));
+        };
+    };
+};
+
+filter "flow_default_sshd_ns_filter" {
+	match("default" value("json.kubernetes.namespace_name") type("string"));
+};
+parser "flow_default_sshd_filters_0" {
+	db-parser(file("/etc/syslog-ng/patterndb/sshd/patterndb.xml"));
+};
+log {
+	source("main_input");
+	filter("flow_default_sshd_ns_filter");
+	parser("flow_default_sshd_filters_0");
+};
 `),
 		},
 	}
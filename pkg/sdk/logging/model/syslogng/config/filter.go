@@ -16,10 +16,13 @@ package config
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 
 	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/syslogng/config/model"
 	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/syslogng/config/render"
 	"github.com/siliconbrain/go-seqs/seqs"
+	"golang.org/x/exp/slices"
 )
 
 func filterDefStmt(name string, body render.Renderer) render.Renderer {
@@ -50,17 +53,8 @@ func filterExpr(expr model.FilterExpr) render.Renderer {
 				args = append(args, optionExpr("template", render.Literal(string(scope.Alt))))
 			}
 		}
-		if typ := expr.Alt.Type; typ != "" {
-			args = append(args, optionExpr("type", render.Literal(typ)))
-		}
-		if flags := expr.Alt.Flags; len(flags) > 0 {
-			args = append(args, optionExpr("flags", seqs.ToSlice(seqs.Map(seqs.FromSlice(flags), render.Literal[string]))...))
-		}
-		return render.AllOf(
-			render.String("match("),
-			render.SpaceSeparated(args...),
-			render.String(")"),
-		)
+		args = append(args, patternOptions(expr.Alt.Type, expr.Alt.Flags)...)
+		return filterFuncExpr("match", args...)
 	case model.FilterExprAlt[model.FilterExprNot]:
 		return render.AllOf(render.String("(not "), filterExpr(expr.Alt.Expr), render.String(")"))
 	case model.FilterExprAlt[model.FilterExprOr]:
@@ -69,7 +63,62 @@ func filterExpr(expr model.FilterExpr) render.Renderer {
 			render.AllFrom(seqs.Intersperse(seqs.Map(seqs.FromSlice(expr.Alt), filterExpr), render.String(" or "))),
 			render.String(")"),
 		)
+	case model.FilterExprAlt[model.FilterExprFacility]:
+		return filterFuncExpr("facility", nameListExpr(expr.Alt))
+	case model.FilterExprAlt[model.FilterExprLevel]:
+		return filterFuncExpr("level", nameListExpr(expr.Alt))
+	case model.FilterExprAlt[model.FilterExprHost]:
+		return filterFuncExpr("host", append([]render.Renderer{render.Quoted(expr.Alt.Pattern)}, patternOptions(expr.Alt.Type, expr.Alt.Flags)...)...)
+	case model.FilterExprAlt[model.FilterExprProgram]:
+		return filterFuncExpr("program", append([]render.Renderer{render.Quoted(expr.Alt.Pattern)}, patternOptions(expr.Alt.Type, expr.Alt.Flags)...)...)
+	case model.FilterExprAlt[model.FilterExprNetmask]:
+		if strings.Contains(string(expr.Alt), ":") {
+			return filterFuncExpr("netmask6", render.Quoted(string(expr.Alt)))
+		}
+		return filterFuncExpr("netmask", render.Quoted(string(expr.Alt)))
+	case model.FilterExprAlt[model.FilterExprInList]:
+		return filterFuncExpr("in-list", render.Quoted(expr.Alt.File), optionExpr("value", render.Literal(expr.Alt.Value)))
+	case model.FilterExprAlt[model.FilterExprCompare]:
+		if !slices.Contains(comparisonOperators, expr.Alt.Operator) {
+			return render.Error(fmt.Errorf("unsupported comparison operator %q", expr.Alt.Operator))
+		}
+		return render.SpaceSeparated(render.Quoted(expr.Alt.Left), render.String(expr.Alt.Operator), render.Quoted(expr.Alt.Right))
 	default:
 		return render.Error(fmt.Errorf("unsupported filter expression %T", expr))
 	}
 }
+
+var comparisonOperators = []string{"==", "!=", "<", "<=", ">", ">=", "eq", "ne", "lt", "le", "gt", "ge"}
+
+// facility and level names (and ranges of them) are rendered unquoted, so they are restricted to identifiers
+var filterNameRegexp = regexp.MustCompile(`^[a-z0-9]+(\.\.[a-z0-9]+)?$`)
+
+func filterFuncExpr(name string, args ...render.Renderer) render.Renderer {
+	return render.AllOf(
+		render.String(name+"("),
+		render.SpaceSeparated(args...),
+		render.String(")"),
+	)
+}
+
+func nameListExpr(names []string) render.Renderer {
+	if len(names) == 0 {
+		return render.Error(fmt.Errorf("empty name list"))
+	}
+	for _, name := range names {
+		if !filterNameRegexp.MatchString(name) {
+			return render.Error(fmt.Errorf("invalid facility or level name %q", name))
+		}
+	}
+	return render.String(strings.Join(names, ", "))
+}
+
+func patternOptions(typ string, flags []string) (args []render.Renderer) {
+	if typ != "" {
+		args = append(args, optionExpr("type", render.Literal(typ)))
+	}
+	if len(flags) > 0 {
+		args = append(args, optionExpr("flags", seqs.ToSlice(seqs.Map(seqs.FromSlice(flags), render.Literal[string]))...))
+	}
+	return
+}
@@ -98,6 +98,73 @@ func TestFilterExpr(t *testing.T) {
 			}),
 			wantOut: `match("^foo" template("${HOST}|${MESSAGE}"))`,
 		},
+		"facility": {
+			expr:    model.NewFilterExpr(model.FilterExprFacility{"kern", "local0..local7"}),
+			wantOut: `facility(kern, local0..local7)`,
+		},
+		"level": {
+			expr:    model.NewFilterExpr(model.FilterExprLevel{"warning..emerg"}),
+			wantOut: `level(warning..emerg)`,
+		},
+		"level with invalid name": {
+			expr:    model.NewFilterExpr(model.FilterExprLevel{"err) or level(debug"}),
+			wantErr: true,
+		},
+		"host": {
+			expr: model.NewFilterExpr(model.FilterExprHost{
+				Pattern: "^node-",
+				Type:    "pcre",
+			}),
+			wantOut: `host("^node-" type("pcre"))`,
+		},
+		"program": {
+			expr: model.NewFilterExpr(model.FilterExprProgram{
+				Pattern: "sshd",
+				Flags:   []string{"ignore-case"},
+			}),
+			wantOut: `program("sshd" flags("ignore-case"))`,
+		},
+		"netmask": {
+			expr:    model.NewFilterExpr(model.FilterExprNetmask("10.0.0.0/8")),
+			wantOut: `netmask("10.0.0.0/8")`,
+		},
+		"netmask ipv6": {
+			expr:    model.NewFilterExpr(model.FilterExprNetmask("fd00::/8")),
+			wantOut: `netmask6("fd00::/8")`,
+		},
+		"in-list": {
+			expr: model.NewFilterExpr(model.FilterExprInList{
+				File:  "/etc/syslog-ng/programs.list",
+				Value: "PROGRAM",
+			}),
+			wantOut: `in-list("/etc/syslog-ng/programs.list" value("PROGRAM"))`,
+		},
+		"compare": {
+			expr: model.NewFilterExpr(model.FilterExprCompare{
+				Left:     "${json.status}",
+				Operator: ">=",
+				Right:    "500",
+			}),
+			wantOut: `"${json.status}" >= "500"`,
+		},
+		"compare with unsupported operator": {
+			expr: model.NewFilterExpr(model.FilterExprCompare{
+				Left:     "${json.status}",
+				Operator: "=~",
+				Right:    "500",
+			}),
+			wantErr: true,
+		},
+		"not compare": {
+			expr: model.NewFilterExpr(model.FilterExprNot{
+				Expr: model.NewFilterExpr(model.FilterExprCompare{
+					Left:     "${json.level}",
+					Operator: "eq",
+					Right:    "debug",
+				}),
+			}),
+			wantOut: `(not "${json.level}" eq "debug")`,
+		},
 	}
 	for name, testCase := range tests {
 		testCase := testCase
@@ -29,7 +29,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-func renderClusterFlow(sourceName string, f v1beta1.SyslogNGClusterFlow, secretLoaderFactory SecretLoaderFactory) render.Renderer {
+func renderClusterFlow(sourceNames []string, f v1beta1.SyslogNGClusterFlow, secretLoaderFactory SecretLoaderFactory) render.Renderer {
 	baseName := fmt.Sprintf("clusterflow_%s_%s", f.Namespace, f.Name)
 	matchName := fmt.Sprintf("%s_match", baseName)
 	filterDefs := seqs.MapWithIndex(seqs.FromSlice(f.Spec.Filters), func(idx int, flt v1beta1.SyslogNGFilter) render.Renderer {
@@ -39,7 +39,7 @@ func renderClusterFlow(sourceName string, f v1beta1.SyslogNGClusterFlow, secretL
 		renderFlowMatch(matchName, f.Spec.Match),
 		render.AllFrom(filterDefs),
 		logDefStmt(
-			[]string{sourceName},
+			sourceNames,
 			seqs.ToSlice(seqs.Concat(
 				seqs.FromValues(
 					render.If(!f.Spec.Match.IsEmpty(), filterRefStmt(matchName)),
@@ -199,6 +199,35 @@ func filterExprFromMatchExpr(expr filter.MatchExpr) model.FilterExpr {
 			m.Scope = model.NewFilterExprMatchScope(model.FilterExprMatchScopeValue(expr.Regexp.Value))
 		}
 		return model.NewFilterExpr(m)
+	case len(expr.Facility) > 0:
+		return model.NewFilterExpr(model.FilterExprFacility(expr.Facility))
+	case len(expr.Level) > 0:
+		return model.NewFilterExpr(model.FilterExprLevel(expr.Level))
+	case expr.Host != nil:
+		return model.NewFilterExpr(model.FilterExprHost{
+			Pattern: expr.Host.Pattern,
+			Type:    expr.Host.Type,
+			Flags:   expr.Host.Flags,
+		})
+	case expr.Program != nil:
+		return model.NewFilterExpr(model.FilterExprProgram{
+			Pattern: expr.Program.Pattern,
+			Type:    expr.Program.Type,
+			Flags:   expr.Program.Flags,
+		})
+	case expr.Netmask != "":
+		return model.NewFilterExpr(model.FilterExprNetmask(expr.Netmask))
+	case expr.InList != nil:
+		return model.NewFilterExpr(model.FilterExprInList{
+			File:  expr.InList.File,
+			Value: expr.InList.Value,
+		})
+	case expr.Compare != nil:
+		return model.NewFilterExpr(model.FilterExprCompare{
+			Left:     expr.Compare.Left,
+			Operator: expr.Compare.Operator,
+			Right:    expr.Compare.Right,
+		})
 	default:
 		return nil
 	}
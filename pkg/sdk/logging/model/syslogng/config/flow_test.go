@@ -20,6 +20,7 @@ import (
 
 	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
 	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/syslogng/config/render"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/syslogng/filter"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -65,7 +66,261 @@ source("test_input");
 		testCase := testCase
 		t.Run(name, func(t *testing.T) {
 			out := strings.Builder{}
-			require.NoError(t, renderClusterFlow("test_input", testCase.clusterFlow, nil)(render.RenderContext{
+			require.NoError(t, renderClusterFlow([]string{"test_input"}, testCase.clusterFlow, nil)(render.RenderContext{
+				Out: &out,
+			}))
+			assert.Equal(t, testCase.expected, out.String())
+		})
+	}
+}
+
+func TestRenderFlowFilterParsers(t *testing.T) {
+	flow := &v1beta1.SyslogNGFlow{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "test_flow",
+			Namespace: "test_ns",
+		},
+	}
+	testCases := map[string]struct {
+		parser   filter.ParserConfig
+		expected string
+	}{
+		"json parser": {
+			parser: filter.ParserConfig{
+				JSONParser: &filter.JSONParser{
+					Prefix:        ".parsed.",
+					Template:      "${json.message}",
+					Marker:        "@cee:",
+					ExtractPrefix: "data",
+					KeyDelimiter:  "~",
+				},
+			},
+			expected: Untab(`parser "test_flow_filters_0" {
+	json-parser(prefix(".parsed.") template("${json.message}") marker("@cee:") extract-prefix("data") key-delimiter("~"));
+};
+`),
+		},
+		"kv parser": {
+			parser: filter.ParserConfig{
+				KVParser: &filter.KVParser{
+					Prefix:                ".kv.",
+					ValueSeparator:        ":",
+					PairSeparator:         ";",
+					ExtractStrayWordsInto: ".kv.stray",
+				},
+			},
+			expected: Untab(`parser "test_flow_filters_0" {
+	kv-parser(prefix(".kv.") value-separator(":") pair-separator(";") extract-stray-words-into(".kv.stray"));
+};
+`),
+		},
+		"csv parser": {
+			parser: filter.ParserConfig{
+				CSVParser: &filter.CSVParser{
+					Columns:    []string{"client", "method", "path"},
+					Prefix:     ".csv.",
+					Template:   "${json.message}",
+					Delimiters: ";",
+					QuotePairs: `""[]`,
+					Dialect:    "escape-double-char",
+					Flags:      []string{"strip-whitespace", "greedy"},
+				},
+			},
+			expected: Untab(`parser "test_flow_filters_0" {
+	csv-parser(columns("client" "method" "path") prefix(".csv.") template("${json.message}") delimiters(";") quote-pairs("\"\"[]") dialect("escape-double-char") flags("strip-whitespace" "greedy"));
+};
+`),
+		},
+		"date parser": {
+			parser: filter.ParserConfig{
+				DateParser: &filter.DateParser{
+					Format:   []string{"%Y-%m-%dT%H:%M:%S%z", "%d/%b/%Y:%H:%M:%S %z"},
+					Template: "${json.time}",
+					TimeZone: "Europe/Budapest",
+					Value:    "stamp",
+					Flags:    []string{"guess-timezone"},
+				},
+			},
+			expected: Untab(`parser "test_flow_filters_0" {
+	date-parser(format("%Y-%m-%dT%H:%M:%S%z" "%d/%b/%Y:%H:%M:%S %z") template("${json.time}") time-zone("Europe/Budapest") value("stamp") flags("guess-timezone"));
+};
+`),
+		},
+		"apache accesslog parser": {
+			parser: filter.ParserConfig{
+				ApacheAccessLogParser: &filter.ApacheAccessLogParser{
+					Prefix: ".apache.",
+				},
+			},
+			expected: Untab(`parser "test_flow_filters_0" {
+	apache-accesslog-parser(prefix(".apache."));
+};
+`),
+		},
+		"db parser": {
+			parser: filter.ParserConfig{
+				DBParser: &filter.DBParser{
+					PatternDB:     "sshd",
+					Template:      "${json.message}",
+					DropUnmatched: amp(true),
+				},
+			},
+			expected: Untab(`parser "test_flow_filters_0" {
+	db-parser(file("/etc/syslog-ng/patterndb/sshd/patterndb.xml") template("${json.message}") drop-unmatched(yes));
+};
+`),
+		},
+		"grouping-by": {
+			parser: filter.ParserConfig{
+				GroupingBy: &filter.GroupingBy{
+					Key:     "${json.session_id}",
+					Scope:   "global",
+					Timeout: 60,
+					Trigger: &filter.MatchExpr{
+						Regexp: &filter.RegexpMatchExpr{
+							Pattern: "logout",
+							Value:   "json.event",
+							Type:    "string",
+						},
+					},
+					Having: &filter.MatchExpr{
+						Compare: &filter.CompareMatchExpr{
+							Left:     "$(context-length)",
+							Operator: ">",
+							Right:    "1",
+						},
+					},
+					Aggregate: &filter.GroupingByAggregate{
+						Values: map[string]string{
+							"json.user":  "${json.user}@1",
+							"json.event": "session",
+						},
+						Tags:        []string{"session"},
+						InheritMode: "none",
+					},
+					InjectMode: "aggregate-only",
+				},
+			},
+			expected: Untab(`parser "test_flow_filters_0" {
+	grouping-by(key("${json.session_id}") scope("global") timeout(60) trigger(match("logout" value("json.event") type("string"))) having("$(context-length)" > "1") aggregate(value("json.event" "session") value("json.user" "${json.user}@1") tags("session") inherit-mode("none")) inject-mode("aggregate-only"));
+};
+`),
+		},
+		"apache accesslog parser with defaults": {
+			parser: filter.ParserConfig{
+				ApacheAccessLogParser: &filter.ApacheAccessLogParser{},
+			},
+			expected: Untab(`parser "test_flow_filters_0" {
+	apache-accesslog-parser();
+};
+`),
+		},
+	}
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			out := strings.Builder{}
+			flt := v1beta1.SyslogNGFilter{
+				Parser: &testCase.parser,
+			}
+			require.NoError(t, renderFlowFilter(flt, flow, 0, "test_flow", nil)(render.RenderContext{
+				Out:        &out,
+				IndentWith: "    ",
+			}))
+			assert.Equal(t, testCase.expected, out.String())
+		})
+	}
+}
+
+func TestRenderFlowFilterMultipleParsers(t *testing.T) {
+	flow := &v1beta1.SyslogNGFlow{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "test_flow",
+			Namespace: "test_ns",
+		},
+	}
+	flt := v1beta1.SyslogNGFilter{
+		Parser: &filter.ParserConfig{
+			JSONParser: &filter.JSONParser{},
+			KVParser:   &filter.KVParser{},
+		},
+	}
+	out := strings.Builder{}
+	assert.Error(t, renderFlowFilter(flt, flow, 0, "test_flow", nil)(render.RenderContext{
+		Out: &out,
+	}))
+}
+
+func TestRenderMatchExpr(t *testing.T) {
+	testCases := map[string]struct {
+		expr     filter.MatchExpr
+		expected string
+	}{
+		"level and status": {
+			expr: filter.MatchExpr{
+				And: []filter.MatchExpr{
+					{
+						Level: []string{"warning..emerg"},
+					},
+					{
+						Compare: &filter.CompareMatchExpr{
+							Left:     "${json.status}",
+							Operator: ">=",
+							Right:    "500",
+						},
+					},
+				},
+			},
+			expected: `(level(warning..emerg) and "${json.status}" >= "500");
+`,
+		},
+		"facility, host and program": {
+			expr: filter.MatchExpr{
+				Or: []filter.MatchExpr{
+					{
+						Facility: []string{"auth", "authpriv"},
+					},
+					{
+						Host: &filter.PatternMatchExpr{
+							Pattern: "^bastion",
+						},
+					},
+					{
+						Program: &filter.PatternMatchExpr{
+							Pattern: "sudo",
+							Type:    "string",
+						},
+					},
+				},
+			},
+			expected: `(facility(auth, authpriv) or host("^bastion") or program("sudo" type("string")));
+`,
+		},
+		"netmask and in-list": {
+			expr: filter.MatchExpr{
+				And: []filter.MatchExpr{
+					{
+						Netmask: "10.0.0.0/8",
+					},
+					{
+						Not: &filter.MatchExpr{
+							InList: &filter.InListMatchExpr{
+								File:  "/etc/blocklist",
+								Value: "json.kubernetes.namespace_name",
+							},
+						},
+					},
+				},
+			},
+			expected: `(netmask("10.0.0.0/8") and (not in-list("/etc/blocklist" value("json.kubernetes.namespace_name"))));
+`,
+		},
+	}
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			out := strings.Builder{}
+			require.NoError(t, renderMatchExpr(testCase.expr)(render.RenderContext{
 				Out: &out,
 			}))
 			assert.Equal(t, testCase.expected, out.String())
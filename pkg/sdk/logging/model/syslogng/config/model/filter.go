@@ -21,7 +21,9 @@ type FilterExpr interface {
 type FilterExprAlts interface {
 	// https://www.syslog-ng.com/technical-documents/doc/syslog-ng-open-source-edition/3.37/administration-guide/65#TOPIC-1829161
 	// https://www.syslog-ng.com/technical-documents/doc/syslog-ng-open-source-edition/3.37/administration-guide/66#TOPIC-1829165
-	FilterExprAnd | FilterExprMatch | FilterExprNot | FilterExprOr
+	FilterExprAnd | FilterExprMatch | FilterExprNot | FilterExprOr |
+		FilterExprFacility | FilterExprLevel | FilterExprHost | FilterExprProgram |
+		FilterExprNetmask | FilterExprInList | FilterExprCompare
 }
 
 func NewFilterExpr[Alt FilterExprAlts](alt Alt) FilterExpr {
@@ -52,6 +54,42 @@ type FilterExprNot struct {
 
 type FilterExprOr []FilterExpr
 
+// https://www.syslog-ng.com/technical-documents/doc/syslog-ng-open-source-edition/3.37/administration-guide/68#TOPIC-1829166
+type FilterExprFacility []string
+
+// https://www.syslog-ng.com/technical-documents/doc/syslog-ng-open-source-edition/3.37/administration-guide/68#TOPIC-1829169
+type FilterExprLevel []string
+
+// https://www.syslog-ng.com/technical-documents/doc/syslog-ng-open-source-edition/3.37/administration-guide/68#TOPIC-1829168
+type FilterExprHost struct {
+	Pattern string
+	Type    string
+	Flags   []string
+}
+
+// https://www.syslog-ng.com/technical-documents/doc/syslog-ng-open-source-edition/3.37/administration-guide/68#TOPIC-1829174
+type FilterExprProgram struct {
+	Pattern string
+	Type    string
+	Flags   []string
+}
+
+// https://www.syslog-ng.com/technical-documents/doc/syslog-ng-open-source-edition/3.37/administration-guide/68#TOPIC-1829172
+type FilterExprNetmask string
+
+// https://www.syslog-ng.com/technical-documents/doc/syslog-ng-open-source-edition/3.37/administration-guide/68#TOPIC-1829170
+type FilterExprInList struct {
+	File  string
+	Value string
+}
+
+// https://www.syslog-ng.com/technical-documents/doc/syslog-ng-open-source-edition/3.37/administration-guide/65#TOPIC-1829162
+type FilterExprCompare struct {
+	Left     string
+	Operator string
+	Right    string
+}
+
 type FilterExprMatchScope interface {
 	__FilterExprMatchScope_union()
 }
@@ -17,19 +17,22 @@ package config
 import (
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
 	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/syslogng/config/render"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/syslogng/output"
 	"github.com/cisco-open/operator-tools/pkg/secret"
 	"github.com/siliconbrain/go-seqs/seqs"
+	"golang.org/x/exp/slices"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-func renderClusterOutput(o v1beta1.SyslogNGClusterOutput, secretLoaderFactory SecretLoaderFactory) render.Renderer {
+func renderClusterOutput(o v1beta1.SyslogNGClusterOutput, keyDelim string, bufferDir string, secretLoaderFactory SecretLoaderFactory) render.Renderer {
 	name := clusterOutputDestName(o.Namespace, o.Name)
 	return destinationDefStmt(
 		name,
-		renderOutputSpec(o.Spec.SyslogNGOutputSpec, name, &o, secretLoaderFactory.SecretLoaderForNamespace(o.Namespace)),
+		renderOutputSpec(o.Spec.SyslogNGOutputSpec, name, &o, keyDelim, bufferDir, secretLoaderFactory.SecretLoaderForNamespace(o.Namespace)),
 	)
 }
 
@@ -37,11 +40,11 @@ func clusterOutputDestName(ns string, name string) string {
 	return fmt.Sprintf("clusteroutput_%s_%s", ns, name)
 }
 
-func renderOutput(o v1beta1.SyslogNGOutput, secretLoaderFactory SecretLoaderFactory) render.Renderer {
+func renderOutput(o v1beta1.SyslogNGOutput, keyDelim string, bufferDir string, secretLoaderFactory SecretLoaderFactory) render.Renderer {
 	name := outputDestName(o.Namespace, o.Name)
 	return destinationDefStmt(
 		name,
-		renderOutputSpec(o.Spec, name, &o, secretLoaderFactory.SecretLoaderForNamespace(o.Namespace)),
+		renderOutputSpec(o.Spec, name, &o, keyDelim, bufferDir, secretLoaderFactory.SecretLoaderForNamespace(o.Namespace)),
 	)
 }
 
@@ -49,7 +52,7 @@ func outputDestName(ns string, name string) string {
 	return fmt.Sprintf("output_%s_%s", ns, name)
 }
 
-func renderOutputSpec(spec v1beta1.SyslogNGOutputSpec, destName string, output metav1.Object, secretLoader secret.SecretLoader) render.Renderer {
+func renderOutputSpec(spec v1beta1.SyslogNGOutputSpec, destName string, output metav1.Object, keyDelim string, bufferDir string, secretLoader secret.SecretLoader) render.Renderer {
 	specValue := reflect.ValueOf(spec)
 	driverFields := seqs.ToSlice(seqs.Filter(seqs.FromSlice(fieldsOf(specValue)), isActiveDestinationDriver))
 	switch len(driverFields) {
@@ -58,6 +61,12 @@ func renderOutputSpec(spec v1beta1.SyslogNGOutputSpec, destName string, output m
 	case 1:
 		driverField := driverFields[0]
 		defaultPersistName(driverField.Value, destName) // HACK: defaulting should be done properly
+		if bufferDir != "" {
+			defaultDiskBufferDir(driverField.Value, bufferDir)
+		}
+		if spec.OpenTelemetry != nil {
+			return openTelemetryDestinationChannel(spec.OpenTelemetry, keyDelim, renderDriver(driverField, secretLoader))
+		}
 		return renderDriver(driverField, secretLoader)
 	default:
 		return render.Error(fmt.Errorf(
@@ -68,6 +77,56 @@ func renderOutputSpec(spec v1beta1.SyslogNGOutputSpec, destName string, output m
 	}
 }
 
+// openTelemetryDestinationChannel sets the OpenTelemetry log record fields of the messages before sending them to the destination.
+// The default body and resource attributes are the reverse of the mapping done by the OTLP source.
+func openTelemetryDestinationChannel(o *output.OpenTelemetryOutput, keyDelim string, driver render.Renderer) render.Renderer {
+	jsonKeyTemplate := func(key ...string) string {
+		return "${" + strings.Join(append([]string{"json"}, key...), keyDelim) + "}"
+	}
+	body := o.Body
+	if body == "" {
+		body = jsonKeyTemplate("message")
+	}
+	attributes := make(map[string]string, len(otlpResourceAttributes)+len(o.ResourceAttributes))
+	for _, a := range otlpResourceAttributes {
+		attributes[a.Attribute] = jsonKeyTemplate(a.Key...)
+	}
+	for name, value := range o.ResourceAttributes {
+		attributes[name] = value
+	}
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	sets := []render.Renderer{
+		setStmt("log", ".otel.type"),
+		setStmt(body, ".otel.log.body"),
+	}
+	for _, name := range names {
+		sets = append(sets, setStmt(attributes[name], ".otel.resource.attributes."+name))
+	}
+	return channelDefStmt(nil, []render.Renderer{
+		rewriteDefStmt("", render.AllOf(sets...)),
+		destinationDefStmt("", driver),
+	})
+}
+
+// defaultDiskBufferDir stores the disk-buffer on the buffer storage volume, unless the output sets the directory explicitly
+func defaultDiskBufferDir(value reflect.Value, dir string) {
+	switch value.Kind() {
+	case reflect.Pointer:
+		defaultDiskBufferDir(derefAll(value), dir)
+	case reflect.Struct:
+		if diskBuffer := value.FieldByName("DiskBuffer"); diskBuffer.IsValid() && diskBuffer.Kind() == reflect.Pointer && !diskBuffer.IsNil() {
+			if bufferDir := diskBuffer.Elem().FieldByName("Dir"); bufferDir.IsValid() && bufferDir.Kind() == reflect.String && bufferDir.String() == "" {
+				bufferDir.SetString(dir)
+			}
+		}
+	}
+}
+
 func defaultPersistName(value reflect.Value, name string) {
 	switch value.Kind() {
 	case reflect.Pointer:
@@ -0,0 +1,68 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"testing"
+
+	"github.com/cisco-open/operator-tools/pkg/secret"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/syslogng/config"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/syslogng/output"
+)
+
+func TestElasticsearchOutput(t *testing.T) {
+	docType := ""
+	config.CheckConfigForOutput(t,
+		v1beta1.SyslogNGOutput{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "test-elasticsearch-out",
+			},
+			Spec: v1beta1.SyslogNGOutputSpec{
+				Elasticsearch: &output.ElasticsearchOutput{
+					HTTPOutput: output.HTTPOutput{
+						URL: "https://elasticsearch:9200/_bulk",
+						TLS: &output.TLS{
+							PeerVerify: "required-trusted",
+						},
+						DiskBuffer: &output.DiskBuffer{
+							DiskBufSize: 512000000,
+							Reliable:    true,
+						},
+						Batch: output.Batch{
+							BatchLines:   1000,
+							BatchTimeout: 5000,
+						},
+						User: "elastic",
+						Password: secret.Secret{
+							Value: "changeme",
+						},
+					},
+					Index:    "k8s-${json.kubernetes.namespace_name}-${YEAR}.${MONTH}.${DAY}",
+					Type:     &docType,
+					CustomID: "${UNIQID}",
+				},
+			},
+		},
+		`
+destination "output_default_test-elasticsearch-out" {
+	elasticsearch-http(url("https://elasticsearch:9200/_bulk") tls(peer_verify("required-trusted")) disk_buffer(disk_buf_size(512000000) reliable(yes)) batch-lines(1000) batch-timeout(5000) user("elastic") password("changeme") persist_name("output_default_test-elasticsearch-out") index("k8s-${json.kubernetes.namespace_name}-${YEAR}.${MONTH}.${DAY}") type("") custom_id("${UNIQID}"));
+};
+`,
+	)
+}
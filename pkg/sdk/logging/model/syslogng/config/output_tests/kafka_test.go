@@ -0,0 +1,67 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"testing"
+
+	"github.com/cisco-open/operator-tools/pkg/secret"
+	"github.com/cisco-open/operator-tools/pkg/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/syslogng/config"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/syslogng/output"
+)
+
+func TestKafkaOutput(t *testing.T) {
+	config.CheckConfigForOutput(t,
+		v1beta1.SyslogNGOutput{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "test-kafka-out",
+			},
+			Spec: v1beta1.SyslogNGOutputSpec{
+				Kafka: &output.KafkaOutput{
+					BootstrapServers: "kafka-0:9092,kafka-1:9092",
+					Topic:            "logs-${json.kubernetes.namespace_name}",
+					FallbackTopic:    "logs",
+					Key:              "${json.kubernetes.pod_name}",
+					SyncSend:         utils.BoolPointer(true),
+					Config: map[string]string{
+						"compression.codec": "lz4",
+						"acks":              "all",
+					},
+					Security: &output.KafkaSecurity{
+						Protocol:       "SASL_SSL",
+						SASLMechanisms: "SCRAM-SHA-512",
+						SASLUsername: &secret.Secret{
+							Value: "user",
+						},
+						SASLPassword: &secret.Secret{
+							Value: "pass",
+						},
+					},
+					Workers: 2,
+				},
+			},
+		},
+		`
+destination "output_default_test-kafka-out" {
+	kafka-c(bootstrap-servers("kafka-0:9092,kafka-1:9092") topic("logs-${json.kubernetes.namespace_name}") fallback-topic("logs") key("${json.kubernetes.pod_name}") sync-send(yes) config("acks" => "all" "compression.codec" => "lz4" "security.protocol" => "SASL_SSL" "sasl.mechanisms" => "SCRAM-SHA-512" "sasl.username" => "user" "sasl.password" => "pass") workers(2) persist_name("output_default_test-kafka-out"));
+};
+`,
+	)
+}
@@ -0,0 +1,93 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"testing"
+
+	"github.com/cisco-open/operator-tools/pkg/secret"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/syslogng/config"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/syslogng/output"
+)
+
+func TestLokiOutput(t *testing.T) {
+	config.CheckConfigForOutput(t,
+		v1beta1.SyslogNGOutput{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "test-loki-out",
+			},
+			Spec: v1beta1.SyslogNGOutputSpec{
+				Loki: &output.LokiOutput{
+					URL: "loki:9095",
+					Labels: map[string]string{
+						"namespace": "${json.kubernetes.namespace_name}",
+						"app":       "${json.kubernetes.labels.app}",
+						"pod":       "${json.kubernetes.pod_name}",
+					},
+					Auth: &output.Auth{
+						Insecure: &output.Insecure{},
+					},
+					TenantID:  "team-a",
+					Timestamp: "msg",
+					Batch: output.Batch{
+						BatchLines:   1000,
+						BatchTimeout: 5000,
+					},
+					Workers: 4,
+				},
+			},
+		},
+		`
+destination "output_default_test-loki-out" {
+	loki(labels("app" => "${json.kubernetes.labels.app}" "namespace" => "${json.kubernetes.namespace_name}" "pod" => "${json.kubernetes.pod_name}") url("loki:9095") auth(insecure()) tenant_id("team-a") timestamp("msg") batch-lines(1000) batch-timeout(5000) workers(4) persist_name("output_default_test-loki-out"));
+};
+`,
+	)
+}
+
+func TestLokiOutputTLS(t *testing.T) {
+	config.CheckConfigForOutput(t,
+		v1beta1.SyslogNGOutput{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "test-loki-out",
+			},
+			Spec: v1beta1.SyslogNGOutputSpec{
+				Loki: &output.LokiOutput{
+					URL: "loki:9095",
+					Labels: map[string]string{
+						"host": "$HOST",
+					},
+					Auth: &output.Auth{
+						TLS: &output.GrpcTLS{
+							CaFile: &secret.Secret{
+								Value: "/etc/ca.crt",
+							},
+						},
+					},
+				},
+			},
+		},
+		`
+destination "output_default_test-loki-out" {
+	loki(labels("host" => "$HOST") url("loki:9095") auth(tls(ca_file("/etc/ca.crt"))) persist_name("output_default_test-loki-out"));
+};
+`,
+	)
+}
@@ -0,0 +1,58 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"testing"
+
+	"github.com/cisco-open/operator-tools/pkg/secret"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/syslogng/config"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/syslogng/output"
+)
+
+func TestOpenSearchOutput(t *testing.T) {
+	config.CheckConfigForOutput(t,
+		v1beta1.SyslogNGOutput{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "test-opensearch-out",
+			},
+			Spec: v1beta1.SyslogNGOutputSpec{
+				OpenSearch: &output.OpenSearchOutput{
+					HTTPOutput: output.HTTPOutput{
+						URL: "https://opensearch:9200/_bulk",
+						Batch: output.Batch{
+							BatchLines: 500,
+						},
+						User: "admin",
+						Password: secret.Secret{
+							Value: "admin",
+						},
+					},
+					Index:    "k8s-${YEAR}.${MONTH}.${DAY}",
+					Template: "$(format-json --scope rfc5424 --key ISODATE @timestamp=${ISODATE})",
+				},
+			},
+		},
+		`
+destination "output_default_test-opensearch-out" {
+	opensearch(url("https://opensearch:9200/_bulk") batch-lines(500) user("admin") password("admin") persist_name("output_default_test-opensearch-out") index("k8s-${YEAR}.${MONTH}.${DAY}") template("$(format-json --scope rfc5424 --key ISODATE @timestamp=${ISODATE})"));
+};
+`,
+	)
+}
@@ -0,0 +1,104 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/syslogng/config"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/syslogng/output"
+)
+
+func TestOpenTelemetryOutput(t *testing.T) {
+	config.CheckConfigForOutput(t,
+		v1beta1.SyslogNGOutput{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "test-otel-out",
+			},
+			Spec: v1beta1.SyslogNGOutputSpec{
+				OpenTelemetry: &output.OpenTelemetryOutput{
+					URL: "otel-collector:4317",
+					Auth: &output.Auth{
+						Insecure: &output.Insecure{},
+					},
+					ResourceAttributes: map[string]string{
+						"k8s.cluster.name": "production",
+					},
+					Batch: output.Batch{
+						BatchLines: 1000,
+					},
+				},
+			},
+		},
+		`
+destination "output_default_test-otel-out" {
+	channel {
+		rewrite {
+			set("log" value(".otel.type"));
+			set("${json.message}" value(".otel.log.body"));
+			set("production" value(".otel.resource.attributes.k8s.cluster.name"));
+			set("${json.kubernetes.container_name}" value(".otel.resource.attributes.k8s.container.name"));
+			set("${json.kubernetes.namespace_name}" value(".otel.resource.attributes.k8s.namespace.name"));
+			set("${json.kubernetes.host}" value(".otel.resource.attributes.k8s.node.name"));
+			set("${json.kubernetes.pod_name}" value(".otel.resource.attributes.k8s.pod.name"));
+			set("${json.kubernetes.pod_id}" value(".otel.resource.attributes.k8s.pod.uid"));
+		};
+		destination {
+			opentelemetry(url("otel-collector:4317") auth(insecure()) batch-lines(1000) persist_name("output_default_test-otel-out"));
+		};
+	};
+};
+`,
+	)
+}
+
+func TestOpenTelemetryOutputKeyDelimiter(t *testing.T) {
+	config.CheckConfigForOutput(t,
+		v1beta1.SyslogNGOutput{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "test-otel-out",
+			},
+			Spec: v1beta1.SyslogNGOutputSpec{
+				OpenTelemetry: &output.OpenTelemetryOutput{
+					URL: "otel-collector:4317",
+				},
+			},
+		},
+		`
+destination "output_default_test-otel-out" {
+	channel {
+		rewrite {
+			set("log" value(".otel.type"));
+			set("${json;message}" value(".otel.log.body"));
+			set("${json;kubernetes;container_name}" value(".otel.resource.attributes.k8s.container.name"));
+			set("${json;kubernetes;namespace_name}" value(".otel.resource.attributes.k8s.namespace.name"));
+			set("${json;kubernetes;host}" value(".otel.resource.attributes.k8s.node.name"));
+			set("${json;kubernetes;pod_name}" value(".otel.resource.attributes.k8s.pod.name"));
+			set("${json;kubernetes;pod_id}" value(".otel.resource.attributes.k8s.pod.uid"));
+		};
+		destination {
+			opentelemetry(url("otel-collector:4317") persist_name("output_default_test-otel-out"));
+		};
+	};
+};
+`,
+		func(options *config.OutputConfigCheckOptions) { options.KeyDelimiter = ";" },
+	)
+}
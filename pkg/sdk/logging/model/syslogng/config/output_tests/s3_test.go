@@ -0,0 +1,90 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"testing"
+
+	"github.com/cisco-open/operator-tools/pkg/secret"
+	"github.com/cisco-open/operator-tools/pkg/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/syslogng/config"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/syslogng/output"
+)
+
+func TestS3Output(t *testing.T) {
+	config.CheckConfigForOutput(t,
+		v1beta1.SyslogNGOutput{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "test-s3-out",
+			},
+			Spec: v1beta1.SyslogNGOutputSpec{
+				S3: &output.S3Output{
+					URL:    "http://minio:9000",
+					Bucket: "logs",
+					AccessKey: &secret.Secret{
+						Value: "minioadmin",
+					},
+					SecretKey: &secret.Secret{
+						Value: "minioadmin",
+					},
+					ObjectKey:     "${json.kubernetes.namespace_name}/${json.kubernetes.pod_name}",
+					Compression:   utils.BoolPointer(true),
+					CompressLevel: 6,
+					ChunkSize:     10,
+					DiskBuffer: &output.DiskBuffer{
+						DiskBufSize: 512000000,
+						Reliable:    true,
+					},
+				},
+			},
+		},
+		`
+destination "output_default_test-s3-out" {
+	s3(url("http://minio:9000") bucket("logs") access_key("minioadmin") secret_key("minioadmin") object_key("${json.kubernetes.namespace_name}/${json.kubernetes.pod_name}") compression(yes) compresslevel(6) chunk_size(10) disk_buffer(disk_buf_size(512000000) reliable(yes)) persist_name("output_default_test-s3-out"));
+};
+`,
+	)
+}
+
+func TestS3OutputBufferDir(t *testing.T) {
+	config.CheckConfigForOutput(t,
+		v1beta1.SyslogNGOutput{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "test-s3-out",
+			},
+			Spec: v1beta1.SyslogNGOutputSpec{
+				S3: &output.S3Output{
+					URL:    "http://minio:9000",
+					Bucket: "logs",
+					DiskBuffer: &output.DiskBuffer{
+						DiskBufSize: 512000000,
+						Reliable:    true,
+					},
+				},
+			},
+		},
+		`
+destination "output_default_test-s3-out" {
+	s3(url("http://minio:9000") bucket("logs") disk_buffer(disk_buf_size(512000000) reliable(yes) dir("/buffers")) persist_name("output_default_test-s3-out"));
+};
+`,
+		func(options *config.OutputConfigCheckOptions) { options.BufferDir = v1beta1.SyslogNGBufferStoragePath },
+	)
+}
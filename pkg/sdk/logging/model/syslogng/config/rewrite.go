@@ -22,6 +22,10 @@ func rewriteDefStmt(name string, body render.Renderer) render.Renderer {
 	return braceDefStmt("rewrite", name, body)
 }
 
+func setStmt(value string, field string) render.Renderer {
+	return parenDefStmt("set", render.Literal(value), optionExpr("value", render.Literal(field)))
+}
+
 func isActiveRewriteDriver(f Field) bool {
 	return hasRewriteDriverTag(f) && isActiveField(f)
 }
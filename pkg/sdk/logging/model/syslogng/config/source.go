@@ -15,17 +15,182 @@
 package config
 
 import (
+	"fmt"
+	"path"
+	"reflect"
+	"strings"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
 	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/syslogng/config/render"
+	"golang.org/x/exp/slices"
 )
 
 type NetworkSourceDriver struct {
-	__meta         struct{} `syslog-ng:"name=network"` //lint:ignore U1000 field used for adding tag to the type
-	Flags          []string `syslog-ng:"name=flags,optional"`
-	IP             string   `syslog-ng:"name=ip,optional"`
-	Port           uint16   `syslog-ng:"name=port,optional"`
-	Transport      string   `syslog-ng:"name=transport,optional"`
-	MaxConnections int      `syslog-ng:"name=max-connections,optional"`
-	LogIWSize      int      `syslog-ng:"name=log-iw-size,optional"`
+	__meta         struct{}   `syslog-ng:"name=network"` //lint:ignore U1000 field used for adding tag to the type
+	Flags          []string   `syslog-ng:"name=flags,optional"`
+	IP             string     `syslog-ng:"name=ip,optional"`
+	Port           uint16     `syslog-ng:"name=port,optional"`
+	Transport      string     `syslog-ng:"name=transport,optional"`
+	MaxConnections int        `syslog-ng:"name=max-connections,optional"`
+	LogIWSize      int        `syslog-ng:"name=log-iw-size,optional"`
+	TLS            *SourceTLS `syslog-ng:"name=tls,optional"`
+}
+
+type SyslogSourceDriver struct {
+	__meta         struct{}   `syslog-ng:"name=syslog"` //lint:ignore U1000 field used for adding tag to the type
+	Flags          []string   `syslog-ng:"name=flags,optional"`
+	Port           uint16     `syslog-ng:"name=port,optional"`
+	Transport      string     `syslog-ng:"name=transport,optional"`
+	MaxConnections int        `syslog-ng:"name=max-connections,optional"`
+	TLS            *SourceTLS `syslog-ng:"name=tls,optional"`
+}
+
+type SourceTLS struct {
+	KeyFile    string `syslog-ng:"name=key-file,optional"`
+	CertFile   string `syslog-ng:"name=cert-file,optional"`
+	CaFile     string `syslog-ng:"name=ca-file,optional"`
+	PeerVerify string `syslog-ng:"name=peer-verify,optional"`
+}
+
+type OpenTelemetrySourceDriver struct {
+	__meta struct{} `syslog-ng:"name=opentelemetry"` //lint:ignore U1000 field used for adding tag to the type
+	Port   uint16   `syslog-ng:"name=port,optional"`
+}
+
+// otlpResourceAttributes maps the resource attributes of the OTLP log records to the keys of the Kubernetes metadata used by the flows
+var otlpResourceAttributes = []struct {
+	Attribute string
+	Key       []string
+}{
+	{Attribute: "k8s.namespace.name", Key: []string{"kubernetes", "namespace_name"}},
+	{Attribute: "k8s.pod.name", Key: []string{"kubernetes", "pod_name"}},
+	{Attribute: "k8s.pod.uid", Key: []string{"kubernetes", "pod_id"}},
+	{Attribute: "k8s.container.name", Key: []string{"kubernetes", "container_name"}},
+	{Attribute: "k8s.node.name", Key: []string{"kubernetes", "host"}},
+}
+
+func otlpSourceChannel(otlp *v1beta1.SyslogNGOTLPSource, keyDelim string) render.Renderer {
+	if otlp == nil {
+		return nil
+	}
+	jsonKey := func(key ...string) string {
+		return strings.Join(append([]string{"json"}, key...), keyDelim)
+	}
+	sets := []render.Renderer{
+		setStmt("${.otel.log.body}", jsonKey("message")),
+	}
+	for _, a := range otlpResourceAttributes {
+		sets = append(sets, setStmt("${.otel.resource.attributes."+a.Attribute+"}", jsonKey(a.Key...)))
+	}
+	return channelDefStmt(
+		sourceDefStmt("", renderDriver(Field{
+			Value: reflect.ValueOf(OpenTelemetrySourceDriver{
+				Port: uint16(otlp.Port),
+			}),
+		}, nil)),
+		[]render.Renderer{
+			rewriteDefStmt("", render.AllOf(sets...)),
+		},
+	)
+}
+
+// mainSourceDriver returns the listener of the agents, it requires the client certificates of the agents when TLS is enabled
+func mainSourceDriver(in Input) NetworkSourceDriver {
+	driver := NetworkSourceDriver{
+		Transport:      "tcp",
+		Port:           uint16(in.SourcePort),
+		MaxConnections: in.Logging.Spec.SyslogNGSpec.MaxConnections,
+		LogIWSize:      logIWSizeCalculator(in),
+		Flags:          []string{"no-parse"},
+	}
+	if in.Logging.Spec.SyslogNGSpec.TLS.Enabled {
+		driver.Transport = "tls"
+		driver.TLS = &SourceTLS{
+			KeyFile:    path.Join(v1beta1.SyslogNGTLSDir, "tls.key"),
+			CertFile:   path.Join(v1beta1.SyslogNGTLSDir, "tls.crt"),
+			CaFile:     path.Join(v1beta1.SyslogNGTLSDir, "ca.crt"),
+			PeerVerify: "required-trusted",
+		}
+	}
+	return driver
+}
+
+func networkSourceName(name string) string {
+	return "network_" + name
+}
+
+func renderNetworkSource(source v1beta1.SyslogNGNetworkSource) render.Renderer {
+	var tls *SourceTLS
+	if source.TLS != nil {
+		tls = &SourceTLS{
+			KeyFile:    path.Join(source.TLSDir(), "tls.key"),
+			CertFile:   path.Join(source.TLSDir(), "tls.crt"),
+			PeerVerify: source.TLS.PeerVerify,
+		}
+		if source.TLS.CACert {
+			tls.CaFile = path.Join(source.TLSDir(), "ca.crt")
+		}
+	}
+	var driver any
+	switch source.Protocol {
+	case "rfc5424":
+		driver = SyslogSourceDriver{
+			Flags:          source.Flags,
+			Port:           uint16(source.Port),
+			Transport:      source.Transport,
+			MaxConnections: source.MaxConnections,
+			TLS:            tls,
+		}
+	default:
+		driver = NetworkSourceDriver{
+			Flags:          source.Flags,
+			Port:           uint16(source.Port),
+			Transport:      source.Transport,
+			MaxConnections: source.MaxConnections,
+			TLS:            tls,
+		}
+	}
+	return sourceDefStmt(networkSourceName(source.Name), renderDriver(Field{
+		Value: reflect.ValueOf(driver),
+	}, nil))
+}
+
+// validateSourcePorts returns an error if a network source listens on the port of the main source, the OTLP source or another network source
+func validateSourcePorts(in Input) error {
+	type listener struct {
+		port int32
+		udp  bool
+	}
+	listeners := map[listener]string{
+		{port: int32(in.SourcePort)}: "the main source",
+	}
+	if otlp := in.Logging.Spec.SyslogNGSpec.OTLPSource; otlp != nil {
+		listeners[listener{port: otlp.Port}] = "the OTLP source"
+	}
+	for _, source := range in.Logging.Spec.SyslogNGSpec.NetworkSources {
+		l := listener{port: source.Port, udp: source.Transport == "udp"}
+		if owner, ok := listeners[l]; ok {
+			return fmt.Errorf("network source %s listens on port %d, which is already used by %s", source.Name, source.Port, owner)
+		}
+		listeners[l] = "network source " + source.Name
+	}
+	return nil
+}
+
+// clusterFlowSources returns the names of the sources the cluster flow reads from,
+// ok is false if the flow references a network source that is not defined
+func clusterFlowSources(flow v1beta1.SyslogNGClusterFlow, networkSources []v1beta1.SyslogNGNetworkSource) (sources []string, ok bool) {
+	if len(flow.Spec.Sources) == 0 {
+		return []string{sourceName}, true
+	}
+	res := make([]string, 0, len(flow.Spec.Sources))
+	for _, name := range flow.Spec.Sources {
+		if slices.IndexFunc(networkSources, func(s v1beta1.SyslogNGNetworkSource) bool { return s.Name == name }) < 0 {
+			return nil, false
+		}
+		res = append(res, networkSourceName(name))
+	}
+	return res, true
 }
 
 func sourceDefStmt(name string, body render.Renderer) render.Renderer {
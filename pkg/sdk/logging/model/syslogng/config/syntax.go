@@ -31,3 +31,7 @@ func parenDefStmt(kind string, args ...render.Renderer) render.Renderer {
 func optionExpr(key string, args ...render.Renderer) render.Renderer {
 	return render.AllOf(render.String(key), render.String("("), render.SpaceSeparated(args...), render.String(")"))
 }
+
+func arrowExpr(key string, value ...render.Renderer) render.Renderer {
+	return render.SpaceSeparated(append([]render.Renderer{render.Quoted(key), render.String("=>")}, value...)...)
+}
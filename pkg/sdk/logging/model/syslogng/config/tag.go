@@ -63,6 +63,14 @@ func (s syslogNGTagSettings) Optional() bool {
 	return s.Has("optional")
 }
 
+func (s syslogNGTagSettings) Arrows() bool {
+	return s.Has("arrows")
+}
+
+func (s syslogNGTagSettings) Pairs() bool {
+	return s.Has("pairs")
+}
+
 func jsonNameOf(f reflect.StructField) string {
 	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
 	return name
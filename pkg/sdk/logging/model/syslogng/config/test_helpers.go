@@ -42,7 +42,7 @@ func CheckConfigForOutput(t *testing.T, output v1beta1.SyslogNGOutput, expected
 	if options.SecretLoaderFactory == nil {
 		options.SecretLoaderFactory = &TestSecretLoaderFactory{}
 	}
-	renderer := renderOutput(output, options.SecretLoaderFactory)
+	renderer := renderOutput(output, keyDelim(options.KeyDelimiter), options.BufferDir, options.SecretLoaderFactory)
 	result := &strings.Builder{}
 	err := renderer(render.RenderContext{
 		Out:        result,
@@ -62,7 +62,7 @@ func CheckConfigForClusterOutput(t *testing.T, output v1beta1.SyslogNGClusterOut
 	if options.SecretLoaderFactory == nil {
 		options.SecretLoaderFactory = &TestSecretLoaderFactory{}
 	}
-	renderer := renderClusterOutput(output, options.SecretLoaderFactory)
+	renderer := renderClusterOutput(output, keyDelim(options.KeyDelimiter), options.BufferDir, options.SecretLoaderFactory)
 	result := &strings.Builder{}
 	err := renderer(render.RenderContext{
 		Out:        result,
@@ -75,6 +75,8 @@ func CheckConfigForClusterOutput(t *testing.T, output v1beta1.SyslogNGClusterOut
 type OutputConfigCheckOptions struct {
 	ExpectedError       interface{}
 	IndentWith          string
+	KeyDelimiter        string
+	BufferDir           string
 	SecretLoaderFactory SecretLoaderFactory
 }
 
@@ -37,6 +37,21 @@ type _hugoMatch interface{} //nolint:deadcode,unused
 //	        type: string
 //
 // {{</ highlight >}}
+//
+// Besides regexp, the native syslog-ng filter functions can be used as well:
+//
+// {{< highlight yaml >}}
+//
+//	filters:
+//	- match:
+//	    and:
+//	    - level: [warning..emerg]
+//	    - compare:
+//	        left: ${json.status}
+//	        operator: ">="
+//	        right: "500"
+//
+// {{</ highlight >}}
 type _docMatch interface{} //nolint:deadcode,unused
 
 // +name:"Syslog-NG Match"
@@ -67,11 +82,29 @@ type MatchExpr struct {
 	// +kubebuilder:pruning:PreserveUnknownFields
 	// +kubebuilder:validation:Schemaless
 	Or []MatchExpr `json:"or,omitempty"`
+	// Select messages by their facility. Elements are facility names (kern, user, local0, ...) or ranges like local0..local7.
+	Facility []string `json:"facility,omitempty"`
+	// Select messages by their level (also known as severity). Elements are level names (debug, info, warning, err, ...) or ranges like warning..emerg.
+	Level []string `json:"level,omitempty"`
+	// Select messages by matching the HOST field against a pattern.
+	// +docLink:"Pattern Directive,#Pattern-Directive"
+	Host *PatternMatchExpr `json:"host,omitempty"`
+	// Select messages by matching the PROGRAM field against a pattern.
+	// +docLink:"Pattern Directive,#Pattern-Directive"
+	Program *PatternMatchExpr `json:"program,omitempty"`
+	// Select messages sent by a host in the given IP subnet, e.g. 10.0.0.0/8. IPv6 subnets are supported as well.
+	Netmask string `json:"netmask,omitempty"`
+	// +docLink:"In-List Directive,#In-List-Directive"
+	InList *InListMatchExpr `json:"in-list,omitempty"`
+	// +docLink:"Compare Directive,#Compare-Directive"
+	Compare *CompareMatchExpr `json:"compare,omitempty"`
 }
 
 // IsEmpty returns true if the expression is not specified, i.e. empty.
 func (expr *MatchExpr) IsEmpty() bool {
-	return expr == nil || (len(expr.And) == 0 && expr.Not == nil && len(expr.Or) == 0 && expr.Regexp == nil)
+	return expr == nil || (len(expr.And) == 0 && expr.Not == nil && len(expr.Or) == 0 && expr.Regexp == nil &&
+		len(expr.Facility) == 0 && len(expr.Level) == 0 && expr.Host == nil && expr.Program == nil &&
+		expr.Netmask == "" && expr.InList == nil && expr.Compare == nil)
 }
 
 // +kubebuilder:object:generate=true
@@ -90,6 +123,41 @@ type RegexpMatchExpr struct {
 	Type string `json:"type,omitempty"` // https://www.syslog-ng.com/technical-documents/doc/syslog-ng-open-source-edition/3.37/administration-guide/81#TOPIC-1829223
 }
 
+// +kubebuilder:object:generate=true
+// +docName:"Pattern Directive"
+// Match a macro of the message (HOST or PROGRAM) against a pattern.
+type PatternMatchExpr struct {
+	// Pattern expression to evaluate
+	Pattern string `json:"pattern"`
+	// Pattern flags
+	Flags []string `json:"flags,omitempty"`
+	// Pattern type
+	Type string `json:"type,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+// +docName:"In-List Directive"
+// Select messages whose field is listed in a file, one value per line. For details, see the [syslog-ng documentation](https://www.syslog-ng.com/technical-documents/doc/syslog-ng-open-source-edition/3.37/administration-guide/68#TOPIC-1829170).
+type InListMatchExpr struct {
+	// Path of the file containing the list of values
+	File string `json:"file"`
+	// Specify a field name of the record to look up in the list.
+	Value string `json:"value"`
+}
+
+// +kubebuilder:object:generate=true
+// +docName:"Compare Directive"
+// Compare two templated values. Numeric operators (==, !=, <, <=, >, >=) compare the values as numbers, string operators (eq, ne, lt, le, gt, ge) compare them as strings.
+type CompareMatchExpr struct {
+	// Template of the left operand, e.g. ${json.status}
+	Left string `json:"left"`
+	// Comparison operator
+	// +kubebuilder:validation:Enum="==";"!=";"<";"<=";">";">=";eq;ne;lt;le;gt;ge
+	Operator string `json:"operator"`
+	// Template of the right operand, e.g. 500
+	Right string `json:"right"`
+}
+
 // #### Example `Regexp` filter configurations
 // ```yaml
 // apiVersion: logging.banzaicloud.io/v1beta1
@@ -14,6 +14,8 @@
 
 package filter
 
+import "path"
+
 // +name:"Parser"
 // +weight:"200"
 type _hugoParser interface{} //nolint:deadcode,unused
@@ -25,6 +27,13 @@ type _hugoParser interface{} //nolint:deadcode,unused
 //
 // - [regexp](#regexp)
 // - [syslog-parser](#syslog)
+// - [json-parser](#json)
+// - [kv-parser](#kv)
+// - [csv-parser](#csv)
+// - [date-parser](#date)
+// - [apache-accesslog-parser](#apache-accesslog)
+// - [db-parser](#db-parser)
+// - [grouping-by](#grouping-by)
 //
 // ## Regexp parser {#regexp}
 //
@@ -54,6 +63,126 @@ type _hugoParser interface{} //nolint:deadcode,unused
 //	    syslog-parser: {}
 //
 // {{</ highlight >}}
+//
+// ## JSON parser {#json}
+//
+// The JSON parser parses a JSON formatted field of the record into name-value pairs.
+// For details, see the [syslog-ng documentation](https://www.syslog-ng.com/technical-documents/doc/syslog-ng-open-source-edition/3.37/administration-guide/87).
+//
+// {{< highlight yaml >}}
+//
+//	filters:
+//	- parser:
+//	    json-parser:
+//	      template: ${json.message}
+//	      prefix: .parsed.
+//
+// {{</ highlight >}}
+//
+// ## Key=value parser {#kv}
+//
+// The key=value parser extracts name-value pairs like `user=alice status=200` from the message.
+// For details, see the [syslog-ng documentation](https://www.syslog-ng.com/technical-documents/doc/syslog-ng-open-source-edition/3.37/administration-guide/88).
+//
+// {{< highlight yaml >}}
+//
+//	filters:
+//	- parser:
+//	    kv-parser:
+//	      prefix: .kv.
+//	      value-separator: ":"
+//
+// {{</ highlight >}}
+//
+// ## CSV parser {#csv}
+//
+// The CSV parser splits the message into columns using the configured delimiters.
+// For details, see the [syslog-ng documentation](https://www.syslog-ng.com/technical-documents/doc/syslog-ng-open-source-edition/3.37/administration-guide/85).
+//
+// {{< highlight yaml >}}
+//
+//	filters:
+//	- parser:
+//	    csv-parser:
+//	      columns: ["client", "method", "path"]
+//	      delimiters: ";"
+//	      prefix: .csv.
+//
+// {{</ highlight >}}
+//
+// ## Date parser {#date}
+//
+// The date parser parses a timestamp from the record and sets it as the timestamp of the message.
+// For details, see the [syslog-ng documentation](https://www.syslog-ng.com/technical-documents/doc/syslog-ng-open-source-edition/3.37/administration-guide/86).
+//
+// {{< highlight yaml >}}
+//
+//	filters:
+//	- parser:
+//	    date-parser:
+//	      format: ["%Y-%m-%dT%H:%M:%S%z"]
+//	      template: ${json.time}
+//
+// {{</ highlight >}}
+//
+// ## Apache access log parser {#apache-accesslog}
+//
+// The Apache access log parser parses access logs in the Common or Combined Log Format.
+// For details, see the [syslog-ng documentation](https://www.syslog-ng.com/technical-documents/doc/syslog-ng-open-source-edition/3.37/administration-guide/84).
+//
+// {{< highlight yaml >}}
+//
+//	filters:
+//	- parser:
+//	    apache-accesslog-parser:
+//	      prefix: .apache.
+//
+// {{</ highlight >}}
+//
+// ## Pattern database parser {#db-parser}
+//
+// The db-parser classifies messages using a pattern database and extracts the fields defined by the matching pattern.
+// The pattern database XML is provided in a ConfigMap or Secret, listed in the `patternDBs` of the syslog-ng spec of the Logging resource,
+// and referenced by its name. For details, see the [syslog-ng documentation](https://www.syslog-ng.com/technical-documents/doc/syslog-ng-open-source-edition/3.37/administration-guide/94).
+//
+// {{< highlight yaml >}}
+//
+//	filters:
+//	- parser:
+//	    db-parser:
+//	      patterndb: sshd
+//	      template: ${json.message}
+//
+// {{</ highlight >}}
+//
+// The checksum of the pattern databases is added to the syslog-ng pod template, so the pods are restarted with the new content
+// the next time the Logging resource is reconciled. Flows referencing a pattern database that is not listed are left out of the configuration.
+//
+// ## Grouping-by parser {#grouping-by}
+//
+// The grouping-by parser correlates messages that share the same key, and emits an aggregate message when the context is closed.
+// For details, see the [syslog-ng documentation](https://www.syslog-ng.com/technical-documents/doc/syslog-ng-open-source-edition/3.37/administration-guide/97).
+//
+// {{< highlight yaml >}}
+//
+//	filters:
+//	- parser:
+//	    grouping-by:
+//	      key: ${json.session_id}
+//	      scope: global
+//	      timeout: 60
+//	      trigger:
+//	        regexp:
+//	          value: json.event
+//	          pattern: logout
+//	          type: string
+//	      aggregate:
+//	        values:
+//	          json.event: session
+//	          json.user: ${json.user}@1
+//	        inherit-mode: none
+//
+// {{</ highlight >}}
 type _docParser interface{} //nolint:deadcode,unused
 
 // +name:"Syslog-NG Parser"
@@ -66,8 +195,15 @@ type _metaParser interface{} //nolint:deadcode,unused
 // +kubebuilder:object:generate=true
 // +docName:"[Parser](https://www.syslog-ng.com/technical-documents/doc/syslog-ng-open-source-edition/3.36/administration-guide/82#TOPIC-1768819)"
 type ParserConfig struct {
-	Regexp       *RegexpParser `json:"regexp,omitempty" syslog-ng:"parser-drv,name=regexp-parser"`
-	SyslogParser *SyslogParser `json:"syslog-parser,omitempty," syslog-ng:"parser-drv,name=syslog-parser"`
+	Regexp                *RegexpParser          `json:"regexp,omitempty" syslog-ng:"parser-drv,name=regexp-parser"`
+	SyslogParser          *SyslogParser          `json:"syslog-parser,omitempty," syslog-ng:"parser-drv,name=syslog-parser"`
+	JSONParser            *JSONParser            `json:"json-parser,omitempty" syslog-ng:"parser-drv,name=json-parser"`
+	KVParser              *KVParser              `json:"kv-parser,omitempty" syslog-ng:"parser-drv,name=kv-parser"`
+	CSVParser             *CSVParser             `json:"csv-parser,omitempty" syslog-ng:"parser-drv,name=csv-parser"`
+	DateParser            *DateParser            `json:"date-parser,omitempty" syslog-ng:"parser-drv,name=date-parser"`
+	ApacheAccessLogParser *ApacheAccessLogParser `json:"apache-accesslog-parser,omitempty" syslog-ng:"parser-drv,name=apache-accesslog-parser"`
+	DBParser              *DBParser              `json:"db-parser,omitempty" syslog-ng:"parser-drv,name=db-parser"`
+	GroupingBy            *GroupingBy            `json:"grouping-by,omitempty" syslog-ng:"parser-drv,name=grouping-by"`
 }
 
 // +kubebuilder:object:generate=true
@@ -89,3 +225,147 @@ type SyslogParser struct {
 	// Pattern flags
 	Flags []string `json:"flags,omitempty"`
 }
+
+// +kubebuilder:object:generate=true
+// +docName:"[JSON parser](https://www.syslog-ng.com/technical-documents/doc/syslog-ng-open-source-edition/3.37/administration-guide/87)"
+type JSONParser struct {
+	// Insert a prefix before the name part of the parsed name-value pairs to help further processing.
+	Prefix string `json:"prefix,omitempty"`
+	// Specify a template of the record fields to parse, for example ${json.message}. (default: ${MESSAGE})
+	Template string `json:"template,omitempty"`
+	// Skip the leading characters of the message up to and including the marker, useful for JSON payloads embedded after a fixed header, for example @cee:
+	Marker string `json:"marker,omitempty"`
+	// Extract only the JSON member at the given path (for example foo.bar[1]) instead of the whole object.
+	ExtractPrefix string `json:"extract-prefix,omitempty"`
+	// The character used to separate the names of nested JSON members. (default: .)
+	KeyDelimiter string `json:"key-delimiter,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+// +docName:"[Key=value parser](https://www.syslog-ng.com/technical-documents/doc/syslog-ng-open-source-edition/3.37/administration-guide/88)"
+type KVParser struct {
+	// Insert a prefix before the name part of the parsed name-value pairs to help further processing.
+	Prefix string `json:"prefix,omitempty"`
+	// Specify a template of the record fields to parse. (default: ${MESSAGE})
+	Template string `json:"template,omitempty"`
+	// The character separating the key from the value. (default: =)
+	ValueSeparator string `json:"value-separator,omitempty"`
+	// The string separating the key=value pairs from each other. (default: ", ")
+	PairSeparator string `json:"pair-separator,omitempty"`
+	// Store the words that are not part of any key=value pair into the given field.
+	ExtractStrayWordsInto string `json:"extract-stray-words-into,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+// +docName:"[CSV parser](https://www.syslog-ng.com/technical-documents/doc/syslog-ng-open-source-edition/3.37/administration-guide/85)"
+type CSVParser struct {
+	// The names of the columns, the parsed values are stored in these fields.
+	Columns []string `json:"columns"`
+	// Insert a prefix before the name of the columns to help further processing.
+	Prefix string `json:"prefix,omitempty"`
+	// Specify a template of the record fields to parse. (default: ${MESSAGE})
+	Template string `json:"template,omitempty"`
+	// The characters that separate the columns, every character is a delimiter on its own. (default: space)
+	Delimiters string `json:"delimiters,omitempty"`
+	// The characters that enclose the column values, for example '""[]'.
+	QuotePairs string `json:"quote-pairs,omitempty"`
+	// How quotes are escaped inside the column values.
+	// +kubebuilder:validation:Enum=escape-none;escape-backslash;escape-double-char;escape-backslash-with-sequences
+	Dialect string `json:"dialect,omitempty"`
+	// Value that is treated as an empty column.
+	Null string `json:"null,omitempty"`
+	// Parser flags, for example greedy, strip-whitespace, drop-invalid.
+	Flags []string `json:"flags,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+// +docName:"[Date parser](https://www.syslog-ng.com/technical-documents/doc/syslog-ng-open-source-edition/3.37/administration-guide/86)"
+type DateParser struct {
+	// Date formats in strptime notation, the first matching format is used. (default: "%FT%T.%f%z")
+	Format []string `json:"format,omitempty"`
+	// Specify a template of the record fields to parse, for example ${json.time}. (default: ${MESSAGE})
+	Template string `json:"template,omitempty"`
+	// The timezone used if the parsed date does not contain one.
+	TimeZone string `json:"time-zone,omitempty"`
+	// Which timestamp of the message is set.
+	// +kubebuilder:validation:Enum=stamp;recvd
+	Value string `json:"value,omitempty"`
+	// Parser flags, for example guess-timezone.
+	Flags []string `json:"flags,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+// +docName:"[Apache access log parser](https://www.syslog-ng.com/technical-documents/doc/syslog-ng-open-source-edition/3.37/administration-guide/84)"
+type ApacheAccessLogParser struct {
+	// Insert a prefix before the name part of the parsed name-value pairs. (default: .apache.)
+	Prefix string `json:"prefix,omitempty"`
+	// Specify a template of the record fields to parse. (default: ${MESSAGE})
+	Template string `json:"template,omitempty"`
+}
+
+const (
+	// PatternDBPath is the directory the pattern databases are mounted under in the syslog-ng pods
+	PatternDBPath = "/etc/syslog-ng/patterndb"
+	// PatternDBFileName is the name of the pattern database XML within its directory
+	PatternDBFileName = "patterndb.xml"
+)
+
+// PatternDBRef references a pattern database by its name
+type PatternDBRef string
+
+// Path returns the path of the referenced pattern database XML in the syslog-ng pods.
+func (r PatternDBRef) Path() string {
+	return path.Join(PatternDBPath, string(r), PatternDBFileName)
+}
+
+// +kubebuilder:object:generate=true
+// +docName:"[Pattern database parser](https://www.syslog-ng.com/technical-documents/doc/syslog-ng-open-source-edition/3.37/administration-guide/94)"
+type DBParser struct {
+	// Name of the pattern database, it has to be listed in the patternDBs of the syslog-ng spec.
+	PatternDB PatternDBRef `json:"patterndb" syslog-ng:"name=file"`
+	// Insert a prefix before the name part of the parsed name-value pairs to help further processing.
+	Prefix string `json:"prefix,omitempty"`
+	// Specify a template of the record fields to parse. (default: ${MESSAGE})
+	Template string `json:"template,omitempty"`
+	// Drop the messages that do not match any pattern. (default: no)
+	DropUnmatched *bool `json:"drop-unmatched,omitempty"`
+	// How the messages generated by the correlation actions of the patterns are emitted.
+	// +kubebuilder:validation:Enum=internal;pass-through;aggregate-only
+	InjectMode string `json:"inject-mode,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+// +docName:"[Grouping-by parser](https://www.syslog-ng.com/technical-documents/doc/syslog-ng-open-source-edition/3.37/administration-guide/97)"
+type GroupingBy struct {
+	// Template identifying the context the messages belong to, for example ${json.session_id}
+	Key string `json:"key"`
+	// The scope of the context key.
+	// +kubebuilder:validation:Enum=process;program;host;global
+	Scope string `json:"scope,omitempty"`
+	// Seconds to wait for new messages of a context before the context is closed.
+	Timeout int `json:"timeout"`
+	// Filter expression that selects the messages added to a context.
+	Where *MatchExpr `json:"where,omitempty"`
+	// Filter expression that closes the context when a matching message is received.
+	Trigger *MatchExpr `json:"trigger,omitempty"`
+	// Filter expression the context has to match to emit the aggregate message.
+	Having *MatchExpr `json:"having,omitempty"`
+	// The aggregate message emitted when the context is closed.
+	// +docLink:"Aggregate,#grouping-by-aggregate"
+	Aggregate *GroupingByAggregate `json:"aggregate,omitempty"`
+	// How the aggregate message is emitted.
+	// +kubebuilder:validation:Enum=pass-through;aggregate-only
+	InjectMode string `json:"inject-mode,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+// +docName:"Aggregate"
+type GroupingByAggregate struct {
+	// Fields of the aggregate message, values are templates evaluated over the context, for example ${json.user}@1 refers to the last message.
+	Values map[string]string `json:"values,omitempty" syslog-ng:"name=value,pairs"`
+	// Tags added to the aggregate message.
+	Tags []string `json:"tags,omitempty"`
+	// Which message the aggregate inherits its fields from.
+	// +kubebuilder:validation:Enum=context;last-message;none
+	InheritMode string `json:"inherit-mode,omitempty"`
+}
@@ -21,6 +21,106 @@ package filter
 
 import ()
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApacheAccessLogParser) DeepCopyInto(out *ApacheAccessLogParser) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApacheAccessLogParser.
+func (in *ApacheAccessLogParser) DeepCopy() *ApacheAccessLogParser {
+	if in == nil {
+		return nil
+	}
+	out := new(ApacheAccessLogParser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CSVParser) DeepCopyInto(out *CSVParser) {
+	*out = *in
+	if in.Columns != nil {
+		in, out := &in.Columns, &out.Columns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Flags != nil {
+		in, out := &in.Flags, &out.Flags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CSVParser.
+func (in *CSVParser) DeepCopy() *CSVParser {
+	if in == nil {
+		return nil
+	}
+	out := new(CSVParser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CompareMatchExpr) DeepCopyInto(out *CompareMatchExpr) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompareMatchExpr.
+func (in *CompareMatchExpr) DeepCopy() *CompareMatchExpr {
+	if in == nil {
+		return nil
+	}
+	out := new(CompareMatchExpr)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBParser) DeepCopyInto(out *DBParser) {
+	*out = *in
+	if in.DropUnmatched != nil {
+		in, out := &in.DropUnmatched, &out.DropUnmatched
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBParser.
+func (in *DBParser) DeepCopy() *DBParser {
+	if in == nil {
+		return nil
+	}
+	out := new(DBParser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DateParser) DeepCopyInto(out *DateParser) {
+	*out = *in
+	if in.Format != nil {
+		in, out := &in.Format, &out.Format
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Flags != nil {
+		in, out := &in.Flags, &out.Flags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DateParser.
+func (in *DateParser) DeepCopy() *DateParser {
+	if in == nil {
+		return nil
+	}
+	out := new(DateParser)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GroupUnsetConfig) DeepCopyInto(out *GroupUnsetConfig) {
 	*out = *in
@@ -41,6 +141,113 @@ func (in *GroupUnsetConfig) DeepCopy() *GroupUnsetConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroupingBy) DeepCopyInto(out *GroupingBy) {
+	*out = *in
+	if in.Where != nil {
+		in, out := &in.Where, &out.Where
+		*out = new(MatchExpr)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Trigger != nil {
+		in, out := &in.Trigger, &out.Trigger
+		*out = new(MatchExpr)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Having != nil {
+		in, out := &in.Having, &out.Having
+		*out = new(MatchExpr)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Aggregate != nil {
+		in, out := &in.Aggregate, &out.Aggregate
+		*out = new(GroupingByAggregate)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupingBy.
+func (in *GroupingBy) DeepCopy() *GroupingBy {
+	if in == nil {
+		return nil
+	}
+	out := new(GroupingBy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroupingByAggregate) DeepCopyInto(out *GroupingByAggregate) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupingByAggregate.
+func (in *GroupingByAggregate) DeepCopy() *GroupingByAggregate {
+	if in == nil {
+		return nil
+	}
+	out := new(GroupingByAggregate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InListMatchExpr) DeepCopyInto(out *InListMatchExpr) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InListMatchExpr.
+func (in *InListMatchExpr) DeepCopy() *InListMatchExpr {
+	if in == nil {
+		return nil
+	}
+	out := new(InListMatchExpr)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JSONParser) DeepCopyInto(out *JSONParser) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JSONParser.
+func (in *JSONParser) DeepCopy() *JSONParser {
+	if in == nil {
+		return nil
+	}
+	out := new(JSONParser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KVParser) DeepCopyInto(out *KVParser) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KVParser.
+func (in *KVParser) DeepCopy() *KVParser {
+	if in == nil {
+		return nil
+	}
+	out := new(KVParser)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MatchConfig) DeepCopyInto(out *MatchConfig) {
 	*out = *in
@@ -68,6 +275,36 @@ func (in *MatchConfig) DeepCopyInto(out *MatchConfig) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Facility != nil {
+		in, out := &in.Facility, &out.Facility
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Level != nil {
+		in, out := &in.Level, &out.Level
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Host != nil {
+		in, out := &in.Host, &out.Host
+		*out = new(PatternMatchExpr)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Program != nil {
+		in, out := &in.Program, &out.Program
+		*out = new(PatternMatchExpr)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InList != nil {
+		in, out := &in.InList, &out.InList
+		*out = new(InListMatchExpr)
+		**out = **in
+	}
+	if in.Compare != nil {
+		in, out := &in.Compare, &out.Compare
+		*out = new(CompareMatchExpr)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatchConfig.
@@ -107,6 +344,36 @@ func (in *MatchExpr) DeepCopyInto(out *MatchExpr) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Facility != nil {
+		in, out := &in.Facility, &out.Facility
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Level != nil {
+		in, out := &in.Level, &out.Level
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Host != nil {
+		in, out := &in.Host, &out.Host
+		*out = new(PatternMatchExpr)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Program != nil {
+		in, out := &in.Program, &out.Program
+		*out = new(PatternMatchExpr)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InList != nil {
+		in, out := &in.InList, &out.InList
+		*out = new(InListMatchExpr)
+		**out = **in
+	}
+	if in.Compare != nil {
+		in, out := &in.Compare, &out.Compare
+		*out = new(CompareMatchExpr)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatchExpr.
@@ -132,6 +399,41 @@ func (in *ParserConfig) DeepCopyInto(out *ParserConfig) {
 		*out = new(SyslogParser)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.JSONParser != nil {
+		in, out := &in.JSONParser, &out.JSONParser
+		*out = new(JSONParser)
+		**out = **in
+	}
+	if in.KVParser != nil {
+		in, out := &in.KVParser, &out.KVParser
+		*out = new(KVParser)
+		**out = **in
+	}
+	if in.CSVParser != nil {
+		in, out := &in.CSVParser, &out.CSVParser
+		*out = new(CSVParser)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DateParser != nil {
+		in, out := &in.DateParser, &out.DateParser
+		*out = new(DateParser)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ApacheAccessLogParser != nil {
+		in, out := &in.ApacheAccessLogParser, &out.ApacheAccessLogParser
+		*out = new(ApacheAccessLogParser)
+		**out = **in
+	}
+	if in.DBParser != nil {
+		in, out := &in.DBParser, &out.DBParser
+		*out = new(DBParser)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GroupingBy != nil {
+		in, out := &in.GroupingBy, &out.GroupingBy
+		*out = new(GroupingBy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParserConfig.
@@ -144,6 +446,26 @@ func (in *ParserConfig) DeepCopy() *ParserConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PatternMatchExpr) DeepCopyInto(out *PatternMatchExpr) {
+	*out = *in
+	if in.Flags != nil {
+		in, out := &in.Flags, &out.Flags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PatternMatchExpr.
+func (in *PatternMatchExpr) DeepCopy() *PatternMatchExpr {
+	if in == nil {
+		return nil
+	}
+	out := new(PatternMatchExpr)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RegexpMatchExpr) DeepCopyInto(out *RegexpMatchExpr) {
 	*out = *in
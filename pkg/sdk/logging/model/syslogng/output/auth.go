@@ -0,0 +1,64 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import "github.com/cisco-open/operator-tools/pkg/secret"
+
+// +name:"Authentication for syslog-ng outputs"
+// +weight:"200"
+type _hugoAuth interface{} //nolint:deadcode,unused
+
+// +docName:"Authentication config for gRPC based syslog-ng outputs"
+// The authentication methods of the gRPC based destinations, for example `loki()`. Only one method can be set.
+// More info at https://syslog-ng.github.io/admin-guide/070_Destinations/035_Loki/README#auth
+type _docAuth interface{} //nolint:deadcode,unused
+
+// +name:"Authentication config for syslog-ng outputs"
+// +url:"https://syslog-ng.github.io/admin-guide/070_Destinations/035_Loki/README#auth"
+// +description:"Authentication config for gRPC based syslog-ng outputs"
+// +status:"Testing"
+type _metaAuth interface{} //nolint:deadcode,unused
+
+// +kubebuilder:object:generate=true
+type Auth struct {
+	// Application Layer Transport Security (ALTS) is a simple to use authentication, only available within Google’s infrastructure.
+	ALTS *ALTS `json:"alts,omitempty"`
+	// Application Default Credentials (ADC).
+	ADC *ADC `json:"adc,omitempty"`
+	// This is the default method, authentication is disabled (auth(insecure())).
+	Insecure *Insecure `json:"insecure,omitempty"`
+	// This option sets various options related to TLS encryption, for example, key/certificate files and trusted CA locations.
+	TLS *GrpcTLS `json:"tls,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+type ALTS struct {
+	// The list of service accounts that are allowed to serve the destination.
+	TargetServiceAccounts []string `json:"target-service-accounts,omitempty"`
+}
+
+type ADC struct{}
+
+type Insecure struct{}
+
+// +kubebuilder:object:generate=true
+type GrpcTLS struct {
+	// The name of a file that contains a set of trusted CA certificates in PEM format.
+	CaFile *secret.Secret `json:"ca_file,omitempty"`
+	// The name of a file that contains an unencrypted private key in PEM format, suitable as a TLS key.
+	KeyFile *secret.Secret `json:"key_file,omitempty"`
+	// Name of a file, that contains an X.509 certificate (or a certificate chain) in PEM format, suitable as a TLS certificate, matching the private key set in the key-file() option.
+	CertFile *secret.Secret `json:"cert_file,omitempty"`
+}
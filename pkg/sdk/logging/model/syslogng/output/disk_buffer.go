@@ -38,7 +38,7 @@ type DiskBuffer struct {
 	Reliable bool `json:"reliable"`
 	// Prunes the unused space in the LogMessage representation
 	Compaction *bool `json:"compaction,omitempty"`
-	// Description: Defines the folder where the disk-buffer files are stored.
+	// Description: Defines the folder where the disk-buffer files are stored. (default: /buffers if the bufferStorageVolume of the syslog-ng spec is configured)
 	Dir string `json:"dir,omitempty"`
 	// Use this option if the option reliable() is set to no. This option contains the number of messages stored in overflow queue.
 	MemBufLength *int64 `json:"mem_buf_length,omitempty"`
@@ -0,0 +1,82 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+// +name:"Elasticsearch"
+// +weight:"200"
+type _hugoElasticsearch interface{} //nolint:deadcode,unused
+
+// +docName:"Sending messages to Elasticsearch"
+// The `elasticsearch-http()` destination sends log messages to Elasticsearch using the bulk API over HTTP.
+// It is built on the [HTTP destination](../http/), so it supports the same batching, TLS, disk buffer and authentication options.
+// For details, see the [syslog-ng documentation](https://www.syslog-ng.com/technical-documents/doc/syslog-ng-open-source-edition/3.37/administration-guide/29#TOPIC-1829047).
+//
+// ## Example
+//
+// {{< highlight yaml >}}
+// apiVersion: logging.banzaicloud.io/v1beta1
+// kind: SyslogNGOutput
+// metadata:
+//
+//	name: elasticsearch
+//	namespace: default
+//
+// spec:
+//
+//	elasticsearch-http:
+//	  url: https://elasticsearch.elastic.svc:9200/_bulk
+//	  index: "k8s-${json.kubernetes.namespace_name}-${YEAR}.${MONTH}.${DAY}"
+//	  type: ""
+//	  batch-lines: 1000
+//	  batch-timeout: 5000
+//	  user: elastic
+//	  password:
+//	    valueFrom:
+//	      secretKeyRef:
+//	        name: elastic
+//	        key: password
+//	  tls:
+//	    ca_file:
+//	      mountFrom:
+//	        secretKeyRef:
+//	          name: elastic-tls
+//	          key: ca.crt
+//	  disk_buffer:
+//	    disk_buf_size: 512000000
+//	    reliable: true
+//
+// {{</ highlight >}}
+type _docElasticsearch interface{} //nolint:deadcode,unused
+
+// +name:"Elasticsearch"
+// +url:"https://www.syslog-ng.com/technical-documents/doc/syslog-ng-open-source-edition/3.37/administration-guide/29#TOPIC-1829047"
+// +description:"Sending messages to Elasticsearch"
+// +status:"Testing"
+type _metaElasticsearch interface{} //nolint:deadcode,unused
+
+// +kubebuilder:object:generate=true
+// Documentation: https://github.com/syslog-ng/syslog-ng/blob/master/scl/elasticsearch/elastic-http.conf
+type ElasticsearchOutput struct {
+	// The url, batching, TLS, disk buffer and authentication options of the underlying HTTP destination. The url must point to the bulk API endpoint, for example https://elasticsearch:9200/_bulk. Do not set body and method, they are set by the destination.
+	HTTPOutput `json:",inline"`
+	// Name of the data stream, index, or index alias to perform the action on. It can contain macros and templates, for example "k8s-${json.kubernetes.namespace_name}-${YEAR}.${MONTH}.${DAY}".
+	Index string `json:"index,omitempty"`
+	// The document type associated with the operation. Elasticsearch 7 and newer do not support mapping types, set it to an empty string. (default: "_doc")
+	Type *string `json:"type,omitempty"`
+	// The document ID. If no ID is specified, a document ID is automatically generated.
+	CustomID string `json:"custom_id,omitempty"`
+	// The template of the document, for example "$(format-json --scope rfc5424 --exclude DATE --key ISODATE @timestamp=${ISODATE})". (default: all the name-value pairs of the rfc5424 scope in JSON format)
+	Template string `json:"template,omitempty"`
+}
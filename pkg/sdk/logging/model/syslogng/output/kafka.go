@@ -0,0 +1,124 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import "github.com/cisco-open/operator-tools/pkg/secret"
+
+// +name:"Kafka"
+// +weight:"200"
+type _hugoKafka interface{} //nolint:deadcode,unused
+
+// +docName:"Sending messages to Apache Kafka"
+// The `kafka-c()` destination publishes log messages to Apache Kafka using the librdkafka client.
+// The topic can contain macros and templates, in that case set `fallback-topic` for the messages whose rendered topic is invalid.
+// For details, see the [syslog-ng documentation](https://www.syslog-ng.com/technical-documents/doc/syslog-ng-open-source-edition/3.37/administration-guide/34#TOPIC-1829055).
+//
+// ## Example
+//
+// {{< highlight yaml >}}
+// apiVersion: logging.banzaicloud.io/v1beta1
+// kind: SyslogNGOutput
+// metadata:
+//
+//	name: kafka
+//	namespace: default
+//
+// spec:
+//
+//	kafka:
+//	  bootstrap-servers: kafka-0.kafka:9092,kafka-1.kafka:9092
+//	  topic: "logs-${json.kubernetes.namespace_name}"
+//	  fallback-topic: logs
+//	  message: "$(format-json --scope rfc5424 --key json.*)"
+//	  sync-send: false
+//	  security:
+//	    protocol: SASL_SSL
+//	    sasl_mechanisms: SCRAM-SHA-512
+//	    sasl_username:
+//	      valueFrom:
+//	        secretKeyRef:
+//	          name: kafka-user
+//	          key: username
+//	    sasl_password:
+//	      valueFrom:
+//	        secretKeyRef:
+//	          name: kafka-user
+//	          key: password
+//	    ssl_ca_file:
+//	      mountFrom:
+//	        secretKeyRef:
+//	          name: kafka-ca
+//	          key: ca.crt
+//
+// {{</ highlight >}}
+type _docKafka interface{} //nolint:deadcode,unused
+
+// +name:"Kafka"
+// +url:"https://www.syslog-ng.com/technical-documents/doc/syslog-ng-open-source-edition/3.37/administration-guide/34#TOPIC-1829055"
+// +description:"Sending messages to Apache Kafka"
+// +status:"Testing"
+type _metaKafka interface{} //nolint:deadcode,unused
+
+// +kubebuilder:object:generate=true
+type KafkaOutput struct {
+	// Comma separated list of the Kafka brokers, for example kafka-0:9092,kafka-1:9092.
+	BootstrapServers string `json:"bootstrap-servers"`
+	// The Kafka topic the messages are published to. It can contain macros and templates, for example "logs-${json.kubernetes.namespace_name}".
+	Topic string `json:"topic"`
+	// The topic of the messages whose templated topic is not a valid topic name. Required if topic contains templates.
+	FallbackTopic string `json:"fallback-topic,omitempty"`
+	// The key of the Kafka messages, for example "${json.kubernetes.pod_name}". (default: empty)
+	Key string `json:"key,omitempty"`
+	// The message body, for example "$(format-json --scope rfc5424 --key json.*)". (default: "$ISODATE $HOST $MSGHDR$MSG")
+	Message string `json:"message,omitempty"`
+	// Publish the messages synchronously, waiting for the acknowledgement of every message. Slower, but no messages are lost when syslog-ng is restarted. (default: false)
+	SyncSend *bool `json:"sync-send,omitempty"`
+	// Additional librdkafka configuration properties, for example "compression.codec": "lz4".
+	Config map[string]string `json:"config,omitempty" syslog-ng:"arrows"`
+	// Security related librdkafka properties, the credentials and certificates are loaded from secrets. They are rendered into the same config() option as config.
+	Security *KafkaSecurity `json:"security,omitempty" syslog-ng:"name=config,arrows"`
+	// The time in milliseconds syslog-ng waits for the pending messages to be delivered when it shuts down. (default: 60000)
+	FlushTimeoutOnShutdown int `json:"flush-timeout-on-shutdown,omitempty"`
+	// The time in milliseconds syslog-ng waits for the pending messages to be delivered when it reloads. (default: 1000)
+	FlushTimeoutOnReload int `json:"flush-timeout-on-reload,omitempty"`
+	// The time in milliseconds the delivery reports of librdkafka are polled. (default: 10000)
+	PollTimeout int `json:"poll-timeout,omitempty"`
+	// This option enables putting outgoing messages into the disk buffer of the destination to avoid message loss in case of a system failure on the destination side. For details, see the [Syslog-ng DiskBuffer options](../disk_buffer/). (default: false)
+	DiskBuffer *DiskBuffer `json:"disk_buffer,omitempty"`
+	// Specifies the number of worker threads (at least 1) that syslog-ng OSE uses to send messages to the server. Increasing the number of worker threads can drastically improve the performance of the destination.
+	Workers     int    `json:"workers,omitempty"`
+	PersistName string `json:"persist_name,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+type KafkaSecurity struct {
+	// Protocol used to communicate with the brokers: PLAINTEXT, SSL, SASL_PLAINTEXT, SASL_SSL
+	// +kubebuilder:validation:Enum=PLAINTEXT;SSL;SASL_PLAINTEXT;SASL_SSL
+	Protocol string `json:"protocol,omitempty" syslog-ng:"name=security.protocol"`
+	// SASL mechanism to use for authentication, for example PLAIN, SCRAM-SHA-256, SCRAM-SHA-512
+	SASLMechanisms string `json:"sasl_mechanisms,omitempty" syslog-ng:"name=sasl.mechanisms"`
+	// SASL username
+	SASLUsername *secret.Secret `json:"sasl_username,omitempty" syslog-ng:"name=sasl.username"`
+	// SASL password
+	SASLPassword *secret.Secret `json:"sasl_password,omitempty" syslog-ng:"name=sasl.password"`
+	// CA certificate file for verifying the broker's certificate, must be set with mountFrom
+	SSLCaFile *secret.Secret `json:"ssl_ca_file,omitempty" syslog-ng:"name=ssl.ca.location"`
+	// Client certificate file, must be set with mountFrom
+	SSLCertFile *secret.Secret `json:"ssl_cert_file,omitempty" syslog-ng:"name=ssl.certificate.location"`
+	// Client private key file, must be set with mountFrom
+	SSLKeyFile *secret.Secret `json:"ssl_key_file,omitempty" syslog-ng:"name=ssl.key.location"`
+	// Password of the client private key
+	SSLKeyPassword *secret.Secret `json:"ssl_key_password,omitempty" syslog-ng:"name=ssl.key.password"`
+}
@@ -0,0 +1,83 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+// +name:"Grafana Loki"
+// +weight:"200"
+type _hugoLoki interface{} //nolint:deadcode,unused
+
+// +docName:"Sending messages to Loki over gRPC"
+// The `loki()` destination sends log messages to Grafana Loki over gRPC.
+// The labels of the streams are templated, so they can be set from the Kubernetes metadata of the records, for example `${json.kubernetes.namespace_name}`.
+// For details, see the [syslog-ng documentation](https://syslog-ng.github.io/admin-guide/070_Destinations/035_Loki/README).
+//
+// ## Example
+//
+// {{< highlight yaml >}}
+// apiVersion: logging.banzaicloud.io/v1beta1
+// kind: SyslogNGOutput
+// metadata:
+//
+//	name: loki-output
+//	namespace: default
+//
+// spec:
+//
+//	loki:
+//	  url: "loki.loki.svc:9095"
+//	  labels:
+//	    namespace: "${json.kubernetes.namespace_name}"
+//	    pod: "${json.kubernetes.pod_name}"
+//	    app: "${json.kubernetes.labels.app}"
+//	  tenant_id: team-a
+//	  auth:
+//	    insecure: {}
+//	  batch-lines: 1000
+//	  batch-timeout: 5000
+//
+// {{</ highlight >}}
+type _docLoki interface{} //nolint:deadcode,unused
+
+// +name:"Grafana Loki"
+// +url:"https://syslog-ng.github.io/admin-guide/070_Destinations/035_Loki/README"
+// +description:"Sending messages to Grafana Loki over gRPC"
+// +status:"Testing"
+type _metaLoki interface{} //nolint:deadcode,unused
+
+// +kubebuilder:object:generate=true
+type LokiOutput struct {
+	// Label mappings of the log streams. The values can contain macros and templates, for example "${json.kubernetes.namespace_name}".
+	Labels map[string]string `json:"labels,omitempty" syslog-ng:"arrows"`
+	// Specifies the hostname or IP address and optionally the port number of the Loki gRPC endpoint, for example loki:9095.
+	URL string `json:"url,omitempty"`
+	// Authentication configuration, see the [authentication options](../auth/).
+	Auth *Auth `json:"auth,omitempty"`
+	// The tenant of the log streams in multi-tenant Loki deployments, sent in the X-Scope-OrgID header.
+	TenantID string `json:"tenant_id,omitempty"`
+	// Template for the log line, for example "$ISODATE $HOST $MSGHDR$MSG". (default: "$ISODATE $HOST $MSGHDR$MSG")
+	Template string `json:"template,omitempty"`
+	// The timestamp of the log entries sent to Loki: current, received, msg. (default: current)
+	// +kubebuilder:validation:Enum=current;received;msg
+	Timestamp string `json:"timestamp,omitempty"`
+	// This option enables putting outgoing messages into the disk buffer of the destination to avoid message loss in case of a system failure on the destination side. For details, see the [Syslog-ng DiskBuffer options](../disk_buffer/). (default: false)
+	DiskBuffer *DiskBuffer `json:"disk_buffer,omitempty"`
+	// Batching parameters
+	Batch `json:",inline"`
+	// The time to wait in seconds before a dead connection is reestablished. (default: 60)
+	TimeReopen int `json:"time_reopen,omitempty"`
+	// Specifies the number of worker threads (at least 1) that syslog-ng OSE uses to send messages to the server. Increasing the number of worker threads can drastically improve the performance of the destination.
+	Workers     int    `json:"workers,omitempty"`
+	PersistName string `json:"persist_name,omitempty"`
+}
@@ -0,0 +1,69 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+// +name:"OpenSearch"
+// +weight:"200"
+type _hugoOpenSearch interface{} //nolint:deadcode,unused
+
+// +docName:"Sending messages to OpenSearch"
+// The `opensearch()` destination sends log messages to OpenSearch using the bulk API over HTTP.
+// It is built on the [HTTP destination](../http/), so it supports the same batching, TLS, disk buffer and authentication options.
+// For details, see the [syslog-ng documentation](https://syslog-ng.github.io/admin-guide/070_Destinations/045_OpenSearch/README).
+//
+// ## Example
+//
+// {{< highlight yaml >}}
+// apiVersion: logging.banzaicloud.io/v1beta1
+// kind: SyslogNGOutput
+// metadata:
+//
+//	name: opensearch
+//	namespace: default
+//
+// spec:
+//
+//	opensearch:
+//	  url: https://opensearch-cluster-master.opensearch.svc:9200/_bulk
+//	  index: "k8s-${YEAR}.${MONTH}.${DAY}"
+//	  batch-lines: 1000
+//	  user: admin
+//	  password:
+//	    valueFrom:
+//	      secretKeyRef:
+//	        name: opensearch
+//	        key: password
+//
+// {{</ highlight >}}
+type _docOpenSearch interface{} //nolint:deadcode,unused
+
+// +name:"OpenSearch"
+// +url:"https://syslog-ng.github.io/admin-guide/070_Destinations/045_OpenSearch/README"
+// +description:"Sending messages to OpenSearch"
+// +status:"Testing"
+type _metaOpenSearch interface{} //nolint:deadcode,unused
+
+// +kubebuilder:object:generate=true
+// Documentation: https://github.com/syslog-ng/syslog-ng/blob/master/scl/opensearch/opensearch.conf
+type OpenSearchOutput struct {
+	// The url, batching, TLS, disk buffer and authentication options of the underlying HTTP destination. The url must point to the bulk API endpoint, for example https://opensearch:9200/_bulk. Do not set body and method, they are set by the destination.
+	HTTPOutput `json:",inline"`
+	// Name of the data stream, index, or index alias to perform the action on. It can contain macros and templates, for example "k8s-${YEAR}.${MONTH}.${DAY}".
+	Index string `json:"index,omitempty"`
+	// The document ID. If no ID is specified, a document ID is automatically generated.
+	CustomID string `json:"custom_id,omitempty"`
+	// The template of the document, for example "$(format-json --scope rfc5424 --exclude DATE --key ISODATE @timestamp=${ISODATE})". (default: all the name-value pairs of the rfc5424 scope in JSON format)
+	Template string `json:"template,omitempty"`
+}
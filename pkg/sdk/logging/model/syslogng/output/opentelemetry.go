@@ -0,0 +1,76 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+// +name:"OpenTelemetry"
+// +weight:"200"
+type _hugoOpenTelemetry interface{} //nolint:deadcode,unused
+
+// +docName:"Sending messages over OpenTelemetry"
+// The `opentelemetry()` destination sends log messages to an OpenTelemetry collector (or any other OTLP receiver) over gRPC.
+// The Kubernetes metadata of the records is mapped into resource attributes, for example `k8s.namespace.name` and `k8s.pod.name`.
+// For details, see the [syslog-ng documentation](https://syslog-ng.github.io/admin-guide/070_Destinations/155_OpenTelemetry/README).
+//
+// ## Example
+//
+// {{< highlight yaml >}}
+// apiVersion: logging.banzaicloud.io/v1beta1
+// kind: SyslogNGOutput
+// metadata:
+//
+//	name: otlp
+//	namespace: default
+//
+// spec:
+//
+//	opentelemetry:
+//	  url: otel-collector.observability.svc:4317
+//	  auth:
+//	    insecure: {}
+//	  batch-lines: 1000
+//	  batch-timeout: 5000
+//	  resource_attributes:
+//	    k8s.cluster.name: production
+//
+// {{</ highlight >}}
+type _docOpenTelemetry interface{} //nolint:deadcode,unused
+
+// +name:"OpenTelemetry"
+// +url:"https://syslog-ng.github.io/admin-guide/070_Destinations/155_OpenTelemetry/README"
+// +description:"Sending messages over OpenTelemetry"
+// +status:"Testing"
+type _metaOpenTelemetry interface{} //nolint:deadcode,unused
+
+// +kubebuilder:object:generate=true
+type OpenTelemetryOutput struct {
+	// Specifies the hostname or IP address and optionally the port number of the OTLP/gRPC endpoint, for example otel-collector:4317.
+	URL string `json:"url"`
+	// Authentication configuration, see the [authentication options](../auth/).
+	Auth *Auth `json:"auth,omitempty"`
+	// Enable gzip compression of the requests. (default: false)
+	Compression *bool `json:"compression,omitempty"`
+	// Resource attributes added to the log records, the values can contain macros and templates.
+	// They are merged with the default Kubernetes attributes: k8s.namespace.name, k8s.pod.name, k8s.pod.uid, k8s.container.name, k8s.node.name
+	ResourceAttributes map[string]string `json:"resource_attributes,omitempty" syslog-ng:"ignore"`
+	// Template of the body of the log records. (default: "${json.message}", using the JSON key delimiter of the syslog-ng spec)
+	Body string `json:"body,omitempty" syslog-ng:"ignore"`
+	// This option enables putting outgoing messages into the disk buffer of the destination to avoid message loss in case of a system failure on the destination side. For details, see the [Syslog-ng DiskBuffer options](../disk_buffer/). (default: false)
+	DiskBuffer *DiskBuffer `json:"disk_buffer,omitempty"`
+	// Batching parameters
+	Batch `json:",inline"`
+	// Specifies the number of worker threads (at least 1) that syslog-ng OSE uses to send messages to the server. Increasing the number of worker threads can drastically improve the performance of the destination.
+	Workers     int    `json:"workers,omitempty"`
+	PersistName string `json:"persist_name,omitempty"`
+}
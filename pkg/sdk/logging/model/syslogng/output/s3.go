@@ -0,0 +1,107 @@
+// Copyright © 2023 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import "github.com/cisco-open/operator-tools/pkg/secret"
+
+// +name:"S3"
+// +weight:"200"
+type _hugoS3 interface{} //nolint:deadcode,unused
+
+// +docName:"Sending messages to S3 compatible object storage"
+// The `s3()` destination archives log messages to Amazon S3 or to an S3 compatible object storage, for example MinIO.
+// The messages are collected into objects, whose key can contain macros and templates. Large objects are uploaded in chunks using multipart uploads.
+// For details, see the [syslog-ng documentation](https://syslog-ng.github.io/admin-guide/070_Destinations/180_s3/README).
+//
+// ## Example
+//
+// {{< highlight yaml >}}
+// apiVersion: logging.banzaicloud.io/v1beta1
+// kind: SyslogNGOutput
+// metadata:
+//
+//	name: s3
+//	namespace: default
+//
+// spec:
+//
+//	s3:
+//	  url: "http://minio.minio.svc:9000"
+//	  bucket: "logs"
+//	  object_key: "${json.kubernetes.namespace_name}/${json.kubernetes.pod_name}"
+//	  access_key:
+//	    valueFrom:
+//	      secretKeyRef:
+//	        name: s3-credentials
+//	        key: access-key
+//	  secret_key:
+//	    valueFrom:
+//	      secretKeyRef:
+//	        name: s3-credentials
+//	        key: secret-key
+//	  compression: true
+//	  chunk_size: 5
+//
+// {{</ highlight >}}
+type _docS3 interface{} //nolint:deadcode,unused
+
+// +name:"S3"
+// +url:"https://syslog-ng.github.io/admin-guide/070_Destinations/180_s3/README"
+// +description:"Sending messages to S3 compatible object storage"
+// +status:"Testing"
+type _metaS3 interface{} //nolint:deadcode,unused
+
+// +kubebuilder:object:generate=true
+type S3Output struct {
+	// The URL of the S3 compatible server, for example http://minio:9000. Leave it empty to use Amazon S3.
+	URL string `json:"url,omitempty"`
+	// The name of the bucket.
+	Bucket string `json:"bucket"`
+	// The access key of the S3 credentials.
+	AccessKey *secret.Secret `json:"access_key,omitempty"`
+	// The secret key of the S3 credentials.
+	SecretKey *secret.Secret `json:"secret_key,omitempty"`
+	// The object key (or key prefix) of the uploaded objects. It can contain macros and templates, for example "${json.kubernetes.namespace_name}/${json.kubernetes.pod_name}".
+	ObjectKey string `json:"object_key,omitempty"`
+	// The timestamp appended to the object key, for example "${R_YEAR}${R_MONTH}${R_DAY}". (default: "${R_MONTH_ABBREV}${R_DAY}")
+	ObjectKeyTimestamp string `json:"object_key_timestamp,omitempty"`
+	// The template of the messages in the objects. (default: "${MESSAGE}\n")
+	Template string `json:"template,omitempty"`
+	// Compress the objects with gzip. (default: false)
+	Compression *bool `json:"compression,omitempty"`
+	// The gzip compression level, from 0 to 9. (default: 9)
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=9
+	CompressLevel int `json:"compresslevel,omitempty"`
+	// The size of the chunks of multipart uploads in MiB. (default: 5)
+	ChunkSize int `json:"chunk_size,omitempty"`
+	// The maximum size of an object in MiB, a new object is started when the limit is reached. (default: 5120000)
+	MaxObjectSize int `json:"max_object_size,omitempty"`
+	// The number of threads uploading the chunks in parallel. (default: 8)
+	UploadThreads int `json:"upload_threads,omitempty"`
+	// The maximum number of chunks waiting to be uploaded. (default: 32)
+	MaxPendingUploads int `json:"max_pending_uploads,omitempty"`
+	// Time in minutes after which an object that receives no more messages is closed and uploaded. (default: 60)
+	FlushGracePeriod int `json:"flush_grace_period,omitempty"`
+	// The region of the bucket.
+	Region string `json:"region,omitempty"`
+	// The storage class of the objects, for example STANDARD, STANDARD_IA, GLACIER. (default: STANDARD)
+	StorageClass string `json:"storage_class,omitempty"`
+	// The canned ACL of the objects, for example private, bucket-owner-full-control.
+	CannedAcl string `json:"canned_acl,omitempty"`
+	// This option enables putting outgoing messages into the disk buffer of the destination to avoid message loss in case of a system failure on the destination side. For details, see the [Syslog-ng DiskBuffer options](../disk_buffer/). (default: false)
+	DiskBuffer  *DiskBuffer `json:"disk_buffer,omitempty"`
+	PersistName string      `json:"persist_name,omitempty"`
+}
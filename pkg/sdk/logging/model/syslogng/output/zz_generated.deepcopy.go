@@ -23,6 +23,61 @@ import (
 	"github.com/cisco-open/operator-tools/pkg/secret"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ALTS) DeepCopyInto(out *ALTS) {
+	*out = *in
+	if in.TargetServiceAccounts != nil {
+		in, out := &in.TargetServiceAccounts, &out.TargetServiceAccounts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ALTS.
+func (in *ALTS) DeepCopy() *ALTS {
+	if in == nil {
+		return nil
+	}
+	out := new(ALTS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Auth) DeepCopyInto(out *Auth) {
+	*out = *in
+	if in.ALTS != nil {
+		in, out := &in.ALTS, &out.ALTS
+		*out = new(ALTS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ADC != nil {
+		in, out := &in.ADC, &out.ADC
+		*out = new(ADC)
+		**out = **in
+	}
+	if in.Insecure != nil {
+		in, out := &in.Insecure, &out.Insecure
+		*out = new(Insecure)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(GrpcTLS)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Auth.
+func (in *Auth) DeepCopy() *Auth {
+	if in == nil {
+		return nil
+	}
+	out := new(Auth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DiskBuffer) DeepCopyInto(out *DiskBuffer) {
 	*out = *in
@@ -58,6 +113,27 @@ func (in *DiskBuffer) DeepCopy() *DiskBuffer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchOutput) DeepCopyInto(out *ElasticsearchOutput) {
+	*out = *in
+	in.HTTPOutput.DeepCopyInto(&out.HTTPOutput)
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchOutput.
+func (in *ElasticsearchOutput) DeepCopy() *ElasticsearchOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FileOutput) DeepCopyInto(out *FileOutput) {
 	*out = *in
@@ -78,6 +154,36 @@ func (in *FileOutput) DeepCopy() *FileOutput {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrpcTLS) DeepCopyInto(out *GrpcTLS) {
+	*out = *in
+	if in.CaFile != nil {
+		in, out := &in.CaFile, &out.CaFile
+		*out = new(secret.Secret)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KeyFile != nil {
+		in, out := &in.KeyFile, &out.KeyFile
+		*out = new(secret.Secret)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CertFile != nil {
+		in, out := &in.CertFile, &out.CertFile
+		*out = new(secret.Secret)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrpcTLS.
+func (in *GrpcTLS) DeepCopy() *GrpcTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(GrpcTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HTTPOutput) DeepCopyInto(out *HTTPOutput) {
 	*out = *in
@@ -110,6 +216,88 @@ func (in *HTTPOutput) DeepCopy() *HTTPOutput {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaOutput) DeepCopyInto(out *KafkaOutput) {
+	*out = *in
+	if in.SyncSend != nil {
+		in, out := &in.SyncSend, &out.SyncSend
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Security != nil {
+		in, out := &in.Security, &out.Security
+		*out = new(KafkaSecurity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DiskBuffer != nil {
+		in, out := &in.DiskBuffer, &out.DiskBuffer
+		*out = new(DiskBuffer)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaOutput.
+func (in *KafkaOutput) DeepCopy() *KafkaOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaSecurity) DeepCopyInto(out *KafkaSecurity) {
+	*out = *in
+	if in.SASLUsername != nil {
+		in, out := &in.SASLUsername, &out.SASLUsername
+		*out = new(secret.Secret)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SASLPassword != nil {
+		in, out := &in.SASLPassword, &out.SASLPassword
+		*out = new(secret.Secret)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SSLCaFile != nil {
+		in, out := &in.SSLCaFile, &out.SSLCaFile
+		*out = new(secret.Secret)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SSLCertFile != nil {
+		in, out := &in.SSLCertFile, &out.SSLCertFile
+		*out = new(secret.Secret)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SSLKeyFile != nil {
+		in, out := &in.SSLKeyFile, &out.SSLKeyFile
+		*out = new(secret.Secret)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SSLKeyPassword != nil {
+		in, out := &in.SSLKeyPassword, &out.SSLKeyPassword
+		*out = new(secret.Secret)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaSecurity.
+func (in *KafkaSecurity) DeepCopy() *KafkaSecurity {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaSecurity)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LogScaleOutput) DeepCopyInto(out *LogScaleOutput) {
 	*out = *in
@@ -161,6 +349,39 @@ func (in *Loggly) DeepCopy() *Loggly {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LokiOutput) DeepCopyInto(out *LokiOutput) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(Auth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DiskBuffer != nil {
+		in, out := &in.DiskBuffer, &out.DiskBuffer
+		*out = new(DiskBuffer)
+		(*in).DeepCopyInto(*out)
+	}
+	out.Batch = in.Batch
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LokiOutput.
+func (in *LokiOutput) DeepCopy() *LokiOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(LokiOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MQTT) DeepCopyInto(out *MQTT) {
 	*out = *in
@@ -176,6 +397,95 @@ func (in *MQTT) DeepCopy() *MQTT {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchOutput) DeepCopyInto(out *OpenSearchOutput) {
+	*out = *in
+	in.HTTPOutput.DeepCopyInto(&out.HTTPOutput)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchOutput.
+func (in *OpenSearchOutput) DeepCopy() *OpenSearchOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenTelemetryOutput) DeepCopyInto(out *OpenTelemetryOutput) {
+	*out = *in
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(Auth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Compression != nil {
+		in, out := &in.Compression, &out.Compression
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ResourceAttributes != nil {
+		in, out := &in.ResourceAttributes, &out.ResourceAttributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.DiskBuffer != nil {
+		in, out := &in.DiskBuffer, &out.DiskBuffer
+		*out = new(DiskBuffer)
+		(*in).DeepCopyInto(*out)
+	}
+	out.Batch = in.Batch
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenTelemetryOutput.
+func (in *OpenTelemetryOutput) DeepCopy() *OpenTelemetryOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenTelemetryOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3Output) DeepCopyInto(out *S3Output) {
+	*out = *in
+	if in.AccessKey != nil {
+		in, out := &in.AccessKey, &out.AccessKey
+		*out = new(secret.Secret)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretKey != nil {
+		in, out := &in.SecretKey, &out.SecretKey
+		*out = new(secret.Secret)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Compression != nil {
+		in, out := &in.Compression, &out.Compression
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DiskBuffer != nil {
+		in, out := &in.DiskBuffer, &out.DiskBuffer
+		*out = new(DiskBuffer)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3Output.
+func (in *S3Output) DeepCopy() *S3Output {
+	if in == nil {
+		return nil
+	}
+	out := new(S3Output)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SumologicHTTPOutput) DeepCopyInto(out *SumologicHTTPOutput) {
 	*out = *in
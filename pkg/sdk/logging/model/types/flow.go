@@ -48,6 +48,7 @@ func (s *System) GetDirectives() []Directive {
 	// Add Flows after router
 	for _, flow := range s.Flows {
 		directives = append(directives, flow)
+		directives = append(directives, flow.continuations()...)
 	}
 	return directives
 }
@@ -81,20 +82,80 @@ func (f *Flow) GetParams() Params {
 }
 
 func (f *Flow) GetSections() []Directive {
-	var sections []Directive
+	return f.labelSections()[0]
+}
+
+// continuations returns the labels receiving the events re-emitted by the filters and the outputs of the flow
+func (f *Flow) continuations() []Directive {
+	var labels []Directive
+	for i, sections := range f.labelSections()[1:] {
+		labels = append(labels, &GenericDirective{
+			PluginMeta: PluginMeta{
+				Directive: "label",
+				Tag:       f.continuationLabel(i),
+			},
+			SubDirectives: sections,
+		})
+	}
+	_, outputLabels := f.outputs()
+	return append(labels, outputLabels...)
+}
+
+// outputs returns the outputs of the flow with the re-emitting outputs replaced by their re-emitting directive,
+// and the labels of the re-emitting outputs
+func (f *Flow) outputs() ([]Output, []Directive) {
+	var outputs []Output
+	var labels []Directive
+	for i, output := range f.Outputs {
+		reEmit, ok := output.(*ReEmitOutput)
+		if !ok {
+			outputs = append(outputs, output)
+			continue
+		}
+		directive := reEmit.ReEmitDirective
+		directive.Label = fmt.Sprintf("%s_output_%d", f.FlowLabel, i)
+		outputs = append(outputs, &directive)
+		labels = append(labels, &GenericDirective{
+			PluginMeta: PluginMeta{
+				Directive: "label",
+				Tag:       directive.Label,
+			},
+			SubDirectives: []Directive{reEmit.Output},
+		})
+	}
+	return outputs, labels
+}
+
+func (f *Flow) continuationLabel(index int) string {
+	return fmt.Sprintf("%s_%d", f.FlowLabel, index)
+}
+
+// labelSections splits the sections of the flow at every re-emitting filter
+func (f *Flow) labelSections() [][]Directive {
+	labels := [][]Directive{nil}
 	for _, filter := range f.Filters {
-		sections = append(sections, filter)
+		current := len(labels) - 1
+		if reEmit, ok := filter.(*ReEmitDirective); ok {
+			directive := *reEmit
+			directive.Label = f.continuationLabel(current)
+			labels[current] = append(labels[current], &directive)
+			labels = append(labels, nil)
+			continue
+		}
+		labels[current] = append(labels[current], filter)
 	}
-	if len(f.Outputs) > 1 {
+	current := len(labels) - 1
+	outputs, _ := f.outputs()
+	if len(outputs) > 1 {
 		// We have to convert to General directive
-		sections = append(sections, NewCopyDirective(f.Outputs))
+		labels[current] = append(labels[current], NewCopyDirective(outputs))
 	} else {
-		for _, output := range f.Outputs {
-			sections = append(sections, output)
+		for _, output := range outputs {
+			labels[current] = append(labels[current], output)
 		}
 	}
 
-	return sections
+	return labels
 }
 
 func (f *Flow) WithFilters(filter ...Filter) *Flow {
@@ -87,6 +87,39 @@ func (d *GenericDirective) GetSections() []Directive {
 	return d.SubDirectives
 }
 
+// ReEmitDirective is a match directive that re-emits the events with a new tag (like rewrite_tag_filter).
+// When it is used as a filter of a flow, the directives following it are moved into a continuation label
+// and the re-emitted events are sent there, so they neither go through the main router nor loop back into the same label.
+type ReEmitDirective struct {
+	GenericDirective
+}
+
+// ReEmitOutput is an output that receives the events through a directive re-emitting them with a new tag,
+// e.g. because the plugin sends the events to a destination named after their tag. In a flow the re-emitting
+// directive takes the place of the output, and the output is moved into a label receiving the re-emitted events.
+type ReEmitOutput struct {
+	ReEmitDirective
+	Output Directive
+}
+
+// DirectiveChain is a plugin config rendered as several consecutive directives, e.g. a filter that
+// needs a second filter to drop the records marked by the first one.
+type DirectiveChain struct {
+	Directives []Directive
+}
+
+func (c *DirectiveChain) GetPluginMeta() *PluginMeta {
+	return &PluginMeta{}
+}
+
+func (c *DirectiveChain) GetParams() Params {
+	return nil
+}
+
+func (c *DirectiveChain) GetSections() []Directive {
+	return c.Directives
+}
+
 type PluginParam struct {
 	Description string
 	Default     string
@@ -158,6 +191,7 @@ func NewCopyDirective(directives []Output) Directive {
 				Type:      d.GetPluginMeta().Type,
 				Id:        d.GetPluginMeta().Id,
 				LogLevel:  d.GetPluginMeta().LogLevel,
+				Label:     d.GetPluginMeta().Label,
 				Directive: "store",
 			},
 			Params:        d.GetParams(),
@@ -152,6 +152,9 @@ func AppendCRDResourceBuilders(rbs []reconciler.ResourceBuilder, modifiers ...CR
 		func() (runtime.Object, reconciler.DesiredState, error) {
 			return CRD(loggingv1beta1.GroupVersion.Group, "nodeagents", modifiers...)
 		},
+		func() (runtime.Object, reconciler.DesiredState, error) {
+			return CRD(loggingv1beta1.GroupVersion.Group, "nodeagentprofiles", modifiers...)
+		},
 		func() (runtime.Object, reconciler.DesiredState, error) {
 			return CRD(loggingv1beta1.GroupVersion.Group, "fluentbitagents", modifiers...)
 		},